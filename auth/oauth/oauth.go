@@ -28,13 +28,30 @@ type CheckTokenResponse struct {
 // Returns the credentials attached to an OAuth authentication token.
 // This method does not require user authentication, but the request must be api-signed.
 func CheckToken(client *flickr.FlickrClient, oauthToken string) (*CheckTokenResponse, error) {
-	client.EndpointUrl = flickr.API_ENDPOINT
-	client.ClearArgs()
-	client.Args.Set("method", "flickr.auth.oauth.checkToken")
-	client.Args.Set("oauth_token", oauthToken)
-	client.ApiSign()
+	return NewService(client).CheckToken(oauthToken)
+}
 
-	response := &CheckTokenResponse{}
-	err := flickr.DoGet(client, response)
-	return response, err
+// Response type representing data returned by GetAccessToken
+type GetAccessTokenResponse struct {
+	flickr.BasicResponse
+	Auth struct {
+		AccessToken struct {
+			// OAuth access token
+			Token string `xml:"oauth_token,attr"`
+			// OAuth access token secret
+			TokenSecret string `xml:"oauth_token_secret,attr"`
+		} `xml:"access_token"`
+	} `xml:"auth"`
+}
+
+// Exchange an auth token from the old Authentication API for an OAuth access token.
+// Flickr deletes the legacy token once the exchange succeeds, so the returned
+// OAuthToken should be persisted the same way as the one from flickr.GetAccessToken.
+// The client is set up to use the new token for convenience, then owner's data
+// are retrieved with CheckToken. If that check fails, the returned token is
+// valid nonetheless and is returned along with the error.
+// With client.DryRun the exchange is only logged and an empty token returned.
+// This method does not require user authentication, but the request must be api-signed.
+func GetAccessToken(client *flickr.FlickrClient, authToken string) (*flickr.OAuthToken, error) {
+	return NewService(client).GetAccessToken(authToken)
 }
@@ -1,6 +1,12 @@
 package oauth
 
 import (
+	"bytes"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"testing"
 
 	"gopkg.in/masci/flickr.v2"
@@ -62,3 +68,120 @@ func TestCheckTokenKo(t *testing.T) {
 	flickr.Expect(t, resp.HasErrors(), true)
 	flickr.Expect(t, resp.ErrorCode(), 98)
 }
+
+func TestGetAccessToken(t *testing.T) {
+	bodies := []string{
+		`<?xml version="1.0" encoding="utf-8" ?>
+		<rsp stat="ok">
+		<auth>
+			<access_token oauth_token="72157607082540144-8d5d7ea7696629bf" oauth_token_secret="f38bf58b2d95bc8b" />
+		</auth>
+		</rsp>`,
+		`<?xml version="1.0" encoding="utf-8" ?>
+		<rsp stat="ok">
+		<oauth>
+			<token>72157607082540144-8d5d7ea7696629bf</token>
+			<perms>write</perms>
+			<user nsid="12345678@N00" username="Massimiliano Pippi" fullname="Masci" />
+		</oauth>
+		</rsp>`,
+	}
+	var methods []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.FormValue("method"))
+		if r.FormValue("method") == "flickr.auth.oauth.getAccessToken" {
+			flickr.Expect(t, r.FormValue("auth_token"), "legacy-token")
+			flickr.Expect(t, r.FormValue("api_sig") != "", true)
+		}
+		fmt.Fprint(w, bodies[len(methods)-1])
+	}))
+	defer server.Close()
+	u, _ := url.Parse(server.URL)
+
+	fclient := flickr.NewFlickrClient("apikey", "apisecret")
+	fclient.HTTPClient = &http.Client{Transport: flickr.RewriteTransport{URL: u}}
+
+	tok, err := GetAccessToken(fclient, "legacy-token")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, len(methods), 2)
+	flickr.Expect(t, methods[1], "flickr.auth.oauth.checkToken")
+	flickr.Expect(t, tok.OAuthToken, "72157607082540144-8d5d7ea7696629bf")
+	flickr.Expect(t, tok.OAuthTokenSecret, "f38bf58b2d95bc8b")
+	flickr.Expect(t, tok.UserNsid, "12345678@N00")
+	flickr.Expect(t, tok.Username, "Massimiliano Pippi")
+	flickr.Expect(t, tok.Fullname, "Masci")
+	flickr.Expect(t, fclient.OAuthToken, tok.OAuthToken)
+	flickr.Expect(t, fclient.OAuthTokenSecret, tok.OAuthTokenSecret)
+	flickr.Expect(t, fclient.Id, "12345678@N00")
+}
+
+func TestGetAccessTokenKo(t *testing.T) {
+	body := `<?xml version="1.0" encoding="utf-8" ?>
+	<rsp stat="fail">
+	  <err code="98" msg="Invalid auth token" />
+	</rsp>`
+
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, body, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	tok, err := GetAccessToken(fclient, "legacy-token")
+	_, ok := err.(*flickErr.Error)
+	flickr.Expect(t, ok, true)
+	flickr.Expect(t, tok == nil, true)
+}
+
+// A failing check doesn't lose the new token, which Flickr won't issue again
+func TestGetAccessTokenCheckFails(t *testing.T) {
+	bodies := []string{
+		`<?xml version="1.0" encoding="utf-8" ?>
+		<rsp stat="ok">
+		<auth>
+			<access_token oauth_token="72157607082540144-8d5d7ea7696629bf" oauth_token_secret="f38bf58b2d95bc8b" />
+		</auth>
+		</rsp>`,
+		`<?xml version="1.0" encoding="utf-8" ?>
+		<rsp stat="fail">
+		<err code="98" msg="Invalid token" />
+		</rsp>`,
+	}
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprint(w, bodies[requests-1])
+	}))
+	defer server.Close()
+	u, _ := url.Parse(server.URL)
+
+	fclient := flickr.NewFlickrClient("apikey", "apisecret")
+	fclient.HTTPClient = &http.Client{Transport: flickr.RewriteTransport{URL: u}}
+
+	tok, err := GetAccessToken(fclient, "legacy-token")
+	flickr.Expect(t, err != nil, true)
+	flickr.Expect(t, tok.OAuthToken, "72157607082540144-8d5d7ea7696629bf")
+	flickr.Expect(t, fclient.OAuthToken, tok.OAuthToken)
+	flickr.Expect(t, fclient.OAuthTokenSecret, "f38bf58b2d95bc8b")
+}
+
+func TestGetAccessTokenDryRun(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer server.Close()
+	u, _ := url.Parse(server.URL)
+
+	buf := &bytes.Buffer{}
+	fclient := flickr.NewFlickrClient("apikey", "apisecret")
+	fclient.HTTPClient = &http.Client{Transport: flickr.RewriteTransport{URL: u}}
+	fclient.DryRun = true
+	fclient.DryRunLogger = log.New(buf, "", 0)
+
+	tok, err := GetAccessToken(fclient, "legacy-token")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, tok.OAuthToken, "")
+	flickr.Expect(t, fclient.OAuthToken, "")
+	flickr.Expect(t, requests, 0)
+	flickr.Expect(t, bytes.Contains(buf.Bytes(), []byte("flickr.auth.oauth.getAccessToken")), true)
+}
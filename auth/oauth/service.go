@@ -0,0 +1,73 @@
+package oauth
+
+import (
+	"gopkg.in/masci/flickr.v2"
+)
+
+// Methods of the flickr.auth.oauth namespace
+type Service interface {
+	// Returns the credentials attached to an OAuth authentication token.
+	CheckToken(oauthToken string) (*CheckTokenResponse, error)
+	// Exchange an auth token from the old Authentication API for an OAuth access token.
+	GetAccessToken(authToken string) (*flickr.OAuthToken, error)
+}
+
+// Return a Service checking and exchanging tokens with client
+func NewService(client *flickr.FlickrClient) Service {
+	return &service{client}
+}
+
+type service struct {
+	client *flickr.FlickrClient
+}
+
+func (s *service) CheckToken(oauthToken string) (*CheckTokenResponse, error) {
+	client := s.client
+	client.Init()
+	client.Args.Set("method", "flickr.auth.oauth.checkToken")
+	client.Args.Set("oauth_token", oauthToken)
+	client.ApiSign()
+
+	response := &CheckTokenResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+func (s *service) GetAccessToken(authToken string) (*flickr.OAuthToken, error) {
+	client := s.client
+	client.Init()
+	client.Args.Set("method", "flickr.auth.oauth.getAccessToken")
+	client.Args.Set("auth_token", authToken)
+	client.ApiSign()
+
+	response := &GetAccessTokenResponse{}
+	err := flickr.DoGet(client, response)
+	if err != nil {
+		return nil, err
+	}
+
+	accessTok := &flickr.OAuthToken{
+		OAuthToken:       response.Auth.AccessToken.Token,
+		OAuthTokenSecret: response.Auth.AccessToken.TokenSecret,
+	}
+	// the exchange was skipped, there is no token to check
+	if client.DryRun {
+		return accessTok, nil
+	}
+
+	// set client params for convenience, before checking the token so that
+	// the new credentials are not lost if the check fails
+	client.OAuthToken = accessTok.OAuthToken
+	client.OAuthTokenSecret = accessTok.OAuthTokenSecret
+
+	check, err := CheckToken(client, accessTok.OAuthToken)
+	if err != nil {
+		return accessTok, err
+	}
+	accessTok.UserNsid = check.OAuth.User.ID
+	accessTok.Username = check.OAuth.User.Username
+	accessTok.Fullname = check.OAuth.User.Fullname
+	client.Id = accessTok.UserNsid
+
+	return accessTok, nil
+}
@@ -1,7 +1,9 @@
 package flickr
 
 import (
+	"context"
 	"io/ioutil"
+	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
@@ -33,7 +35,7 @@ func ParseRequestToken(response string) (*RequestToken, error) {
 	oauth_problem := val.Get("oauth_problem")
 	if oauth_problem != "" {
 		ret.OAuthProblem = oauth_problem
-		return ret, flickErr.NewError(flickErr.RequestTokenError, oauth_problem)
+		return ret, flickErr.NewOAuthProblem(flickErr.RequestTokenError, 0, response)
 	}
 
 	confirmed, _ := strconv.ParseBool(val.Get("oauth_callback_confirmed"))
@@ -72,7 +74,7 @@ func ParseOAuthToken(response string) (*OAuthToken, error) {
 	oauth_problem := val.Get("oauth_problem")
 	if oauth_problem != "" {
 		ret.OAuthProblem = oauth_problem
-		return ret, flickErr.NewError(flickErr.OAuthTokenError, oauth_problem)
+		return ret, flickErr.NewOAuthProblem(flickErr.OAuthTokenError, 0, response)
 	}
 
 	ret.OAuthToken = val.Get("oauth_token")
@@ -87,67 +89,50 @@ func ParseOAuthToken(response string) (*OAuthToken, error) {
 // Retrieve a request token: this is the first step to get a fully functional
 // access token from Flickr
 func GetRequestToken(client *FlickrClient) (*RequestToken, error) {
-	client.EndpointUrl = REQUEST_TOKEN_URL
-	client.SetOAuthDefaults()
-	client.Args.Set("oauth_consumer_key", client.ApiKey)
-	client.Args.Set("oauth_callback", "oob")
-
-	// we don't have token secret at this stage, pass an empty string
-	client.Sign("")
-
-	res, err := client.HTTPClient.Get(client.GetUrl())
-	if err != nil {
-		return nil, err
-	}
-
-	defer res.Body.Close()
-	body, err := ioutil.ReadAll(res.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	return ParseRequestToken(string(body))
+	return NewAuthService(client).GetRequestToken()
 }
 
 // Returns the URL users need to reach to grant permission to our application
 func GetAuthorizeUrl(client *FlickrClient, reqToken *RequestToken) (string, error) {
-	client.EndpointUrl = AUTHORIZE_URL
-	client.Args = url.Values{}
-	client.Args.Set("oauth_token", reqToken.OauthToken)
-	// TODO make permission value parametric
-	client.Args.Set("perms", "delete")
-
-	return client.GetUrl(), nil
+	return NewAuthService(client).GetAuthorizeUrl(reqToken)
 }
 
 // Get an access token providing an OAuth verifier provided by Flickr once the user
 // authorizes your application
 func GetAccessToken(client *FlickrClient, reqToken *RequestToken, oauthVerifier string) (*OAuthToken, error) {
-	client.EndpointUrl = ACCESS_TOKEN_URL
-	client.SetOAuthDefaults()
-	client.Args.Set("oauth_verifier", oauthVerifier)
-	client.Args.Set("oauth_consumer_key", client.ApiKey)
-	client.Args.Set("oauth_token", reqToken.OauthToken)
-	// use the request token for signing
-	client.Sign(reqToken.OauthTokenSecret)
-
-	res, err := client.HTTPClient.Get(client.GetUrl())
+	return NewAuthService(client).GetAccessToken(reqToken, oauthVerifier)
+}
+
+// Perform a GET request against one of the OAuth endpoints and return the raw
+// response body. If Flickr refuses the OAuth timestamp because of a clock skew,
+// the request is signed again with a corrected timestamp and retried once.
+func getOAuthResponse(client *FlickrClient) (string, error) {
+	body, err := doGetOAuth(client)
+	if err == nil && oauthProblem(body) == "timestamp_refused" && client.resignOAuth() {
+		body, err = doGetOAuth(client)
+	}
+	return body, err
+}
+
+func doGetOAuth(client *FlickrClient) (string, error) {
+	req, err := http.NewRequest("GET", client.GetUrl(), nil)
 	if err != nil {
-		return nil, err
+		return "", err
+	}
+	res, err := client.send(context.Background(), req, client.HTTPClient)
+	if err != nil {
+		return "", err
 	}
 
 	defer res.Body.Close()
 	body, err := ioutil.ReadAll(res.Body)
 	if err != nil {
-		return nil, err
+		return "", err
+	}
+	// OAuth problems are reported by the parsers
+	if !statusOK(res.StatusCode) && oauthProblem(string(body)) == "" {
+		return "", flickErr.NewHTTPError(res.StatusCode)
 	}
 
-	accessTok, err := ParseOAuthToken(string(body))
-
-	// set client params for convenience
-	client.OAuthToken = accessTok.OAuthToken
-	client.OAuthTokenSecret = accessTok.OAuthTokenSecret
-	client.Id = accessTok.UserNsid
-
-	return accessTok, err
+	return string(body), nil
 }
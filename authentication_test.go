@@ -1,6 +1,7 @@
 package flickr
 
 import (
+	"net/url"
 	"testing"
 
 	flickErr "gopkg.in/masci/flickr.v2/error"
@@ -57,6 +58,11 @@ func TestGetAuthorizeUrl(t *testing.T) {
 	url, err := GetAuthorizeUrl(client, tok)
 	Expect(t, err, nil)
 	Expect(t, url, "https://www.flickr.com/services/oauth/authorize?oauth_token=token&perms=delete")
+
+	client.Endpoints.Authorize = "http://localhost/authorize"
+	url, err = GetAuthorizeUrl(client, tok)
+	Expect(t, err, nil)
+	Expect(t, url, "http://localhost/authorize?oauth_token=token&perms=delete")
 }
 
 func TestParseOAuthToken(t *testing.T) {
@@ -118,3 +124,18 @@ func TestGetAccessToken(t *testing.T) {
 	Expect(t, fclient.OAuthToken, "72157626318069415-087bfc7b5816092c")
 	Expect(t, fclient.OAuthTokenSecret, "a202d1f853ec69de")
 }
+
+func TestGetRequestTokenTimestampRefused(t *testing.T) {
+	mocked_body := "oauth_callback_confirmed=true&oauth_token=72157654304937659-8eedcda57d9d57e3&oauth_token_secret=8700d234e3fc00c6"
+	server, client, timestamps := timestampRefusedMock(mocked_body)
+	defer server.Close()
+
+	fclient := GetTestClient()
+	fclient.Args = url.Values{}
+	fclient.HTTPClient = client
+
+	tok, err := GetRequestToken(fclient)
+	Expect(t, err, nil)
+	Expect(t, tok.OauthToken, "72157654304937659-8eedcda57d9d57e3")
+	Expect(t, len(*timestamps), 2)
+}
@@ -0,0 +1,283 @@
+// Package cache provides a read-through cache of Flickr responses, plugged
+// into a FlickrClient as an interceptor:
+//
+//	c := cache.New(cache.NewLRU(1000))
+//	c.TTLs["flickr.photos.getInfo"] = 10 * time.Minute
+//	client.Interceptors = append(client.Interceptors, c.Interceptor())
+//
+// Responses of read methods are cached per caller, keyed by the method name
+// and the params without credentials and signatures. Requests of methods
+// changing data, uploads included, invalidate the entries
+// about the photos, photosets and groups they touch, whoever the caller.
+// Concurrent identical requests are sent once, so a Cache should be shared
+// by the clients of an application.
+package cache
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/masci/flickr.v2"
+)
+
+// Params left out of cache keys
+var unkeyedParams = []string{
+	"api_key",
+	"api_sig",
+	"auth_token",
+	"method",
+	"oauth_consumer_key",
+	"oauth_nonce",
+	"oauth_signature",
+	"oauth_signature_method",
+	"oauth_timestamp",
+	"oauth_token",
+	"oauth_version",
+}
+
+// Params identifying the objects a request is about. Their plural forms, like
+// photo_ids, hold comma separated lists.
+var objectParams = []string{
+	"photo_id",
+	"photoset_id",
+	"group_id",
+}
+
+// A cached response
+type Entry struct {
+	StatusCode  int
+	ContentType string
+	Body        []byte
+	// When the entry expires
+	Expires time.Time
+	// Objects the request was about, like "photo_id=123"
+	Objects []string
+}
+
+// Storage of cache entries, safe for concurrent use
+type Backend interface {
+	// Return the entry stored with key, if any
+	Get(key string) (*Entry, bool)
+	// Store an entry, replacing the one with the same key
+	Set(key string, e *Entry)
+	// Remove the entry stored with key
+	Delete(key string)
+	// Remove the entries about object
+	Invalidate(object string)
+}
+
+// How long a request shared by concurrent callers can take by default
+const DefaultTimeout = time.Minute
+
+// A read-through cache of Flickr responses, safe for concurrent use
+type Cache struct {
+	Backend Backend
+	// How long responses of each method are cached, like
+	// TTLs["flickr.photos.getSizes"] = time.Hour
+	TTLs map[string]time.Duration
+	// How long responses of read methods missing from TTLs are cached, by
+	// default they are not
+	DefaultTTL time.Duration
+	// Return the current time, defaults to time.Now when nil
+	Clock func() time.Time
+	// How long a request shared by concurrent callers can take, since none
+	// of them can cancel it. Defaults to DefaultTimeout when 0.
+	Timeout time.Duration
+
+	inFlight group
+	mu       sync.Mutex
+	// generation of each object, increased by the requests changing it
+	gens map[string]uint64
+}
+
+// Create a cache storing responses in backend
+func New(backend Backend) *Cache {
+	return &Cache{
+		Backend: backend,
+		TTLs:    map[string]time.Duration{},
+	}
+}
+
+// Return an interceptor serving requests from the cache, to add to the
+// Interceptors of clients
+func (c *Cache) Interceptor() flickr.Interceptor {
+	return func(ctx context.Context, req *flickr.RequestInfo, next flickr.Handler) (*flickr.ResponseInfo, error) {
+		objects := Objects(req.Params)
+		if req.Method == "" || !flickr.IsReadMethod(req.Method) {
+			// reads started during the change neither share the requests
+			// started before nor cache what they get
+			c.bump(objects)
+			res, err := next(ctx, req)
+			c.bump(objects)
+			for _, object := range objects {
+				c.Backend.Invalidate(object)
+			}
+			return res, err
+		}
+
+		ttl := c.ttl(req.Method)
+		if ttl <= 0 {
+			return next(ctx, req)
+		}
+
+		start := time.Now()
+		key := Key(req)
+		if e, ok := c.Backend.Get(key); ok {
+			if c.now().Before(e.Expires) {
+				return e.responseInfo(time.Since(start)), nil
+			}
+			c.Backend.Delete(key)
+		}
+
+		gen := c.generation(objects)
+		flight := key + "@" + strconv.FormatUint(gen, 10)
+		res, body, err := c.inFlight.do(ctx, flight, c.timeout(), func(ctx context.Context) (*flickr.ResponseInfo, []byte, error) {
+			res, err := next(ctx, req)
+			if err != nil || res == nil || res.Response == nil {
+				return res, nil, err
+			}
+			body, err := ioutil.ReadAll(res.Response.Body)
+			res.Response.Body.Close()
+			if err != nil {
+				return nil, nil, err
+			}
+
+			// errors are not cached, nor are OAuth problems, nor responses
+			// that may predate a change of their objects
+			if res.StatusCode == http.StatusOK && res.ErrorCode == 0 && c.generation(objects) == gen {
+				c.Backend.Set(key, &Entry{
+					StatusCode:  res.StatusCode,
+					ContentType: res.Response.Header.Get("Content-Type"),
+					Body:        body,
+					Expires:     c.now().Add(ttl),
+					Objects:     objects,
+				})
+			}
+			return res, body, nil
+		})
+		if err != nil || res == nil || res.Response == nil {
+			return res, err
+		}
+		return withBody(res, body), nil
+	}
+}
+
+// Return the sum of the generations of objects, which changes whenever one
+// of them does
+func (c *Cache) generation(objects []string) uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var gen uint64
+	for _, o := range objects {
+		gen += c.gens[o]
+	}
+	return gen
+}
+
+// Increase the generation of objects
+func (c *Cache) bump(objects []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.gens == nil {
+		c.gens = map[string]uint64{}
+	}
+	for _, o := range objects {
+		c.gens[o]++
+	}
+}
+
+func (c *Cache) timeout() time.Duration {
+	if c.Timeout > 0 {
+		return c.Timeout
+	}
+	return DefaultTimeout
+}
+
+// Return how long responses of method are cached
+func (c *Cache) ttl(method string) time.Duration {
+	if ttl, ok := c.TTLs[method]; ok {
+		return ttl
+	}
+	return c.DefaultTTL
+}
+
+func (c *Cache) now() time.Time {
+	if c.Clock != nil {
+		return c.Clock()
+	}
+	return time.Now()
+}
+
+// Return the cache key of a request, a hash of the caller, the method and the
+// params without credentials and signatures
+func Key(req *flickr.RequestInfo) string {
+	params := url.Values{}
+	for key, val := range req.Params {
+		params[key] = val
+	}
+	for _, key := range unkeyedParams {
+		params.Del(key)
+	}
+
+	sum := sha256.Sum256([]byte(req.Caller + "\n" + req.Method + "\n" + params.Encode()))
+	return hex.EncodeToString(sum[:])
+}
+
+// Return the objects a request with the given params is about, like
+// "photo_id=123"
+func Objects(params url.Values) []string {
+	var ret []string
+	for _, name := range objectParams {
+		if id := params.Get(name); id != "" {
+			ret = append(ret, name+"="+id)
+		}
+		for _, id := range strings.Split(params.Get(name+"s"), ",") {
+			if id = strings.TrimSpace(id); id != "" {
+				ret = append(ret, name+"="+id)
+			}
+		}
+	}
+	return ret
+}
+
+// Build the response of a cache hit
+func (e *Entry) responseInfo(latency time.Duration) *flickr.ResponseInfo {
+	header := http.Header{}
+	if e.ContentType != "" {
+		header.Set("Content-Type", e.ContentType)
+	}
+	return &flickr.ResponseInfo{
+		Response: &http.Response{
+			Status:        http.StatusText(e.StatusCode),
+			StatusCode:    e.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          ioutil.NopCloser(bytes.NewReader(e.Body)),
+			ContentLength: int64(len(e.Body)),
+		},
+		StatusCode: e.StatusCode,
+		Size:       len(e.Body),
+		Latency:    latency,
+	}
+}
+
+// Return a copy of res whose body reads body, so that responses shared by
+// concurrent requests can be read by each of them
+func withBody(res *flickr.ResponseInfo, body []byte) *flickr.ResponseInfo {
+	ret := *res
+	httpRes := *res.Response
+	httpRes.Body = ioutil.NopCloser(bytes.NewReader(body))
+	ret.Response = &httpRes
+	return &ret
+}
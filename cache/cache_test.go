@@ -0,0 +1,348 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"gopkg.in/masci/flickr.v2"
+	"gopkg.in/masci/flickr.v2/auth/oauth"
+	"gopkg.in/masci/flickr.v2/flickrtest"
+	"gopkg.in/masci/flickr.v2/photos"
+)
+
+// Count the requests reaching Flickr, by method
+type counter struct {
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+func (c *counter) interceptor() flickr.Interceptor {
+	c.calls = map[string]int{}
+	return func(ctx context.Context, req *flickr.RequestInfo, next flickr.Handler) (*flickr.ResponseInfo, error) {
+		c.mu.Lock()
+		c.calls[req.Method]++
+		c.mu.Unlock()
+		return next(ctx, req)
+	}
+}
+
+func (c *counter) count(method string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.calls[method]
+}
+
+func TestCache(t *testing.T) {
+	s := flickrtest.NewServer()
+	defer s.Close()
+	u := s.AddUser(flickrtest.User{Username: "gopher"})
+	photo, err := s.AddPhoto(flickrtest.Photo{Owner: u.NSID, Title: "Gopher", IsPublic: true})
+	flickr.Expect(t, err, nil)
+
+	c := New(NewLRU(10))
+	c.TTLs["flickr.photos.getInfo"] = time.Minute
+	sent := &counter{}
+	client := s.UserClient(u.NSID, "write")
+	client.Interceptors = []flickr.Interceptor{c.Interceptor(), sent.interceptor()}
+
+	for i := 0; i < 3; i++ {
+		info, err := photos.GetInfo(client, photo.ID, "")
+		flickr.Expect(t, err, nil)
+		flickr.Expect(t, info.Photo.Title, "Gopher")
+	}
+	flickr.Expect(t, sent.count("flickr.photos.getInfo"), 1)
+
+	// methods without a TTL are not cached
+	photos.GetSizes(client, photo.ID)
+	photos.GetSizes(client, photo.ID)
+	flickr.Expect(t, sent.count("flickr.photos.getSizes"), 2)
+
+	// writes invalidate the photo
+	err = photos.AddTags(client, photo.ID, []string{"foo"})
+	flickr.Expect(t, err, nil)
+	info, err := photos.GetInfo(client, photo.ID, "")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, len(info.Photo.Tags), 1)
+	flickr.Expect(t, sent.count("flickr.photos.getInfo"), 2)
+
+	// errors are not cached
+	_, err = photos.GetInfo(client, "404", "")
+	flickr.Expect(t, err != nil, true)
+	_, err = photos.GetInfo(client, "404", "")
+	flickr.Expect(t, err != nil, true)
+	flickr.Expect(t, sent.count("flickr.photos.getInfo"), 4)
+}
+
+func TestCacheCallers(t *testing.T) {
+	s := flickrtest.NewServer()
+	defer s.Close()
+	u1 := s.AddUser(flickrtest.User{Username: "gopher"})
+	u2 := s.AddUser(flickrtest.User{Username: "gordon"})
+	photo, err := s.AddPhoto(flickrtest.Photo{Owner: u1.NSID, Title: "Gopher", IsPublic: true})
+	flickr.Expect(t, err, nil)
+
+	c := New(NewLRU(10))
+	c.DefaultTTL = time.Minute
+	sent := &counter{}
+	interceptors := []flickr.Interceptor{c.Interceptor(), sent.interceptor()}
+	client1 := s.UserClient(u1.NSID, "write")
+	client1.Interceptors = interceptors
+	client2 := s.UserClient(u2.NSID, "write")
+	client2.Interceptors = interceptors
+
+	_, err = photos.GetInfo(client1, photo.ID, "")
+	flickr.Expect(t, err, nil)
+	_, err = photos.GetInfo(client2, photo.ID, "")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, sent.count("flickr.photos.getInfo"), 2)
+
+	// writes invalidate the entries of every caller
+	err = photos.AddTags(client1, photo.ID, []string{"foo"})
+	flickr.Expect(t, err, nil)
+	info, err := photos.GetInfo(client2, photo.ID, "")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, len(info.Photo.Tags), 1)
+	flickr.Expect(t, sent.count("flickr.photos.getInfo"), 3)
+}
+
+// Methods changing data are never cached, even when their name looks like a read
+func TestCacheWriteMethods(t *testing.T) {
+	s := flickrtest.NewServer()
+	defer s.Close()
+	u := s.AddUser(flickrtest.User{Username: "gopher"})
+	legacy := s.IssueLegacyToken(u.NSID, "read")
+
+	c := New(NewLRU(10))
+	c.DefaultTTL = time.Minute
+	sent := &counter{}
+	client := s.Client()
+	client.Interceptors = []flickr.Interceptor{c.Interceptor(), sent.interceptor()}
+
+	_, err := oauth.GetAccessToken(client, legacy)
+	flickr.Expect(t, err, nil)
+	// the legacy token is gone after the exchange
+	_, err = oauth.GetAccessToken(client, legacy)
+	flickr.Expect(t, err != nil, true)
+	flickr.Expect(t, sent.count("flickr.auth.oauth.getAccessToken"), 2)
+}
+
+func TestCacheExpiration(t *testing.T) {
+	s := flickrtest.NewServer()
+	defer s.Close()
+	u := s.AddUser(flickrtest.User{Username: "gopher"})
+	photo, err := s.AddPhoto(flickrtest.Photo{Owner: u.NSID, IsPublic: true})
+	flickr.Expect(t, err, nil)
+
+	now := time.Now()
+	c := New(NewLRU(10))
+	c.Clock = func() time.Time { return now }
+	c.TTLs["flickr.photos.getInfo"] = time.Minute
+	sent := &counter{}
+	client := s.UserClient(u.NSID, "read")
+	client.Interceptors = []flickr.Interceptor{c.Interceptor(), sent.interceptor()}
+
+	photos.GetInfo(client, photo.ID, "")
+	now = now.Add(59 * time.Second)
+	photos.GetInfo(client, photo.ID, "")
+	flickr.Expect(t, sent.count("flickr.photos.getInfo"), 1)
+	now = now.Add(time.Second)
+	photos.GetInfo(client, photo.ID, "")
+	flickr.Expect(t, sent.count("flickr.photos.getInfo"), 2)
+}
+
+func TestCacheSingleflight(t *testing.T) {
+	release := make(chan struct{})
+	var mu sync.Mutex
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests++
+		mu.Unlock()
+		<-release
+		fmt.Fprint(w, `<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"><photo id="123" /></rsp>`)
+	}))
+	defer server.Close()
+
+	c := New(NewLRU(10))
+	c.DefaultTTL = time.Minute
+	params := url.Values{}
+	params.Set("photo_id", "123")
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 5)
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// clients are not safe for concurrent use, the cache is
+			client := flickr.NewFlickrClient("key", "secret")
+			client.Endpoints.API = server.URL
+			client.Interceptors = append(client.Interceptors, c.Interceptor())
+			resp := &flickr.BasicResponse{}
+			errs <- client.Call(context.Background(), "flickr.photos.getInfo", params, resp)
+		}()
+	}
+	// let the requests pile up before answering
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		flickr.Expect(t, err, nil)
+	}
+	flickr.Expect(t, requests, 1)
+}
+
+// Callers giving up don't cancel the request shared with the others
+func TestCacheSingleflightCancel(t *testing.T) {
+	release := make(chan struct{})
+	arrived := make(chan struct{}, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		arrived <- struct{}{}
+		<-release
+		fmt.Fprint(w, `<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"><photo id="123" /></rsp>`)
+	}))
+	defer server.Close()
+
+	c := New(NewLRU(10))
+	c.DefaultTTL = time.Minute
+	sent := &counter{}
+	interceptors := []flickr.Interceptor{c.Interceptor(), sent.interceptor()}
+	params := url.Values{}
+	params.Set("photo_id", "123")
+	call := func(ctx context.Context) error {
+		client := flickr.NewFlickrClient("key", "secret")
+		client.Endpoints.API = server.URL
+		client.Interceptors = interceptors
+		return client.Call(ctx, "flickr.photos.getInfo", params, &flickr.BasicResponse{})
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error, 1)
+	go func() { first <- call(ctx) }()
+	<-arrived
+	second := make(chan error, 1)
+	go func() { second <- call(context.Background()) }()
+
+	cancel()
+	err := <-first
+	flickr.Expect(t, err != nil, true)
+	close(release)
+	flickr.Expect(t, <-second, nil)
+	flickr.Expect(t, sent.count("flickr.photos.getInfo"), 1)
+
+	// the response was cached
+	flickr.Expect(t, call(context.Background()), nil)
+	flickr.Expect(t, sent.count("flickr.photos.getInfo"), 1)
+}
+
+// A read sent before a write and answered after it is not cached
+func TestCacheReadDuringWrite(t *testing.T) {
+	release := make(chan struct{})
+	arrived := make(chan struct{}, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("method") == "flickr.photos.getInfo" {
+			arrived <- struct{}{}
+			<-release
+		}
+		fmt.Fprint(w, `<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"><photo id="123" /></rsp>`)
+	}))
+	defer server.Close()
+
+	c := New(NewLRU(10))
+	c.DefaultTTL = time.Minute
+	sent := &counter{}
+	interceptors := []flickr.Interceptor{c.Interceptor(), sent.interceptor()}
+	params := url.Values{}
+	params.Set("photo_id", "123")
+	call := func(method string) error {
+		client := flickr.NewFlickrClient("key", "secret")
+		client.Endpoints.API = server.URL
+		client.Interceptors = interceptors
+		return client.Call(context.Background(), method, params, &flickr.BasicResponse{})
+	}
+
+	read := make(chan error, 1)
+	go func() { read <- call("flickr.photos.getInfo") }()
+	<-arrived
+	flickr.Expect(t, call("flickr.photos.setDates"), nil)
+	close(release)
+	flickr.Expect(t, <-read, nil)
+
+	// the response of the first read may predate the write
+	flickr.Expect(t, call("flickr.photos.getInfo"), nil)
+	flickr.Expect(t, sent.count("flickr.photos.getInfo"), 2)
+	flickr.Expect(t, call("flickr.photos.getInfo"), nil)
+	flickr.Expect(t, sent.count("flickr.photos.getInfo"), 2)
+}
+
+// Shared requests don't outlive the cache timeout
+func TestCacheTimeout(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+
+	c := New(NewLRU(10))
+	c.DefaultTTL = time.Minute
+	c.Timeout = 50 * time.Millisecond
+	client := flickr.NewFlickrClient("key", "secret")
+	client.Endpoints.API = server.URL
+	client.Interceptors = append(client.Interceptors, c.Interceptor())
+
+	done := make(chan error, 1)
+	go func() {
+		done <- client.Call(context.Background(), "flickr.photos.getInfo", url.Values{}, &flickr.BasicResponse{})
+	}()
+	select {
+	case err := <-done:
+		flickr.Expect(t, err != nil, true)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the shared request did not time out")
+	}
+}
+
+func TestKey(t *testing.T) {
+	params := url.Values{}
+	params.Set("photo_id", "123")
+	params.Set("oauth_nonce", "abc")
+	req := &flickr.RequestInfo{Method: "flickr.photos.getInfo", Params: params, Caller: "gopher"}
+	key := Key(req)
+
+	// signatures don't change keys
+	params.Set("oauth_nonce", "def")
+	flickr.Expect(t, Key(req), key)
+	// params, methods and callers do
+	params.Set("secret", "s")
+	flickr.Expect(t, Key(req) != key, true)
+	params.Del("secret")
+	req.Method = "flickr.photos.getSizes"
+	flickr.Expect(t, Key(req) != key, true)
+	req.Method = "flickr.photos.getInfo"
+	req.Caller = "gordon"
+	flickr.Expect(t, Key(req) != key, true)
+}
+
+func TestObjects(t *testing.T) {
+	params := url.Values{}
+	params.Set("photoset_id", "1")
+	params.Set("photo_ids", "2, 3")
+	params.Set("title", "Gophers")
+	objects := Objects(params)
+	flickr.Expect(t, len(objects), 3)
+	flickr.Expect(t, objects[0], "photo_id=2")
+	flickr.Expect(t, objects[1], "photo_id=3")
+	flickr.Expect(t, objects[2], "photoset_id=1")
+}
@@ -0,0 +1,177 @@
+package cache
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// How often Set removes the expired entries
+const pruneInterval = time.Minute
+
+// A backend storing entries as JSON files in a directory, so that they
+// survive restarts. I/O errors are treated as cache misses.
+type Disk struct {
+	dir string
+	mu  sync.Mutex
+	// expiration and objects of the stored entries, by file name
+	entries map[string]diskItem
+	// file names of the entries about each object
+	objects   map[string]map[string]bool
+	lastPrune time.Time
+	now       func() time.Time
+}
+
+// What Disk keeps in memory about an entry
+type diskItem struct {
+	expires time.Time
+	objects []string
+}
+
+// Create a disk backend storing entries in dir, created if missing. Entries
+// already in dir are read once to build the index of their objects.
+func NewDisk(dir string) (*Disk, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	d := &Disk{
+		dir:     dir,
+		entries: map[string]diskItem{},
+		objects: map[string]map[string]bool{},
+		now:     time.Now,
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range files {
+		if e, ok := d.read(f); ok {
+			d.index(strings.TrimSuffix(filepath.Base(f), ".json"), e)
+		} else {
+			os.Remove(f)
+		}
+	}
+	d.prune()
+	return d, nil
+}
+
+// Implement Backend
+func (d *Disk) Get(key string) (*Entry, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.read(d.path(fileName(key)))
+}
+
+// Implement Backend, removing the expired entries every pruneInterval
+func (d *Disk) Set(key string, e *Entry) {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.now().Sub(d.lastPrune) >= pruneInterval {
+		d.prune()
+	}
+
+	// write a temporary file first so that readers never see partial entries
+	tmp, err := ioutil.TempFile(d.dir, "tmp-")
+	if err != nil {
+		return
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	name := fileName(key)
+	if err == nil {
+		err = os.Rename(tmp.Name(), d.path(name))
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return
+	}
+	d.unindex(name)
+	d.index(name, e)
+}
+
+// Implement Backend
+func (d *Disk) Delete(key string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.remove(fileName(key))
+}
+
+// Implement Backend
+func (d *Disk) Invalidate(object string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for name := range d.objects[object] {
+		d.remove(name)
+	}
+}
+
+// Remove the expired entries, holding the lock
+func (d *Disk) prune() {
+	now := d.now()
+	for name, item := range d.entries {
+		if !now.Before(item.expires) {
+			d.remove(name)
+		}
+	}
+	d.lastPrune = now
+}
+
+// Remove the entry stored in the file called name, holding the lock
+func (d *Disk) remove(name string) {
+	os.Remove(d.path(name))
+	d.unindex(name)
+}
+
+func (d *Disk) index(name string, e *Entry) {
+	d.entries[name] = diskItem{e.Expires, e.Objects}
+	for _, o := range e.Objects {
+		if d.objects[o] == nil {
+			d.objects[o] = map[string]bool{}
+		}
+		d.objects[o][name] = true
+	}
+}
+
+func (d *Disk) unindex(name string) {
+	for _, o := range d.entries[name].objects {
+		delete(d.objects[o], name)
+		if len(d.objects[o]) == 0 {
+			delete(d.objects, o)
+		}
+	}
+	delete(d.entries, name)
+}
+
+// Return the name of the file storing key, without extension
+func fileName(key string) string {
+	// keys are hex hashes, don't let others escape the directory
+	return strings.NewReplacer("/", "_", "\\", "_", ".", "_").Replace(key)
+}
+
+func (d *Disk) path(name string) string {
+	return filepath.Join(d.dir, name+".json")
+}
+
+func (d *Disk) read(path string) (*Entry, bool) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	e := &Entry{}
+	if err := json.Unmarshal(data, e); err != nil {
+		return nil, false
+	}
+	return e, true
+}
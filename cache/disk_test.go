@@ -0,0 +1,87 @@
+package cache
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gopkg.in/masci/flickr.v2"
+)
+
+func TestDisk(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cache")
+	flickr.Expect(t, err, nil)
+	defer os.RemoveAll(dir)
+
+	d, err := NewDisk(dir)
+	flickr.Expect(t, err, nil)
+	expires := time.Now().Add(time.Hour).Round(time.Second)
+	d.Set("a", &Entry{StatusCode: 200, Body: []byte("<rsp/>"), Expires: expires, Objects: []string{"photo_id=1"}})
+	d.Set("b", &Entry{Expires: expires, Objects: []string{"photo_id=2"}})
+
+	// entries survive restarts
+	d, err = NewDisk(dir)
+	flickr.Expect(t, err, nil)
+	e, ok := d.Get("a")
+	flickr.Expect(t, ok, true)
+	flickr.Expect(t, e.StatusCode, 200)
+	flickr.Expect(t, string(e.Body), "<rsp/>")
+	flickr.Expect(t, e.Expires.Equal(expires), true)
+
+	d.Invalidate("photo_id=1")
+	_, ok = d.Get("a")
+	flickr.Expect(t, ok, false)
+	_, ok = d.Get("b")
+	flickr.Expect(t, ok, true)
+
+	d.Delete("b")
+	_, ok = d.Get("b")
+	flickr.Expect(t, ok, false)
+
+	// invalidation goes through the index of objects built on startup
+	d.Set("c", &Entry{Expires: expires, Objects: []string{"photo_id=1", "photoset_id=3"}})
+	d, err = NewDisk(dir)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, len(d.objects["photoset_id=3"]), 1)
+	d.Invalidate("photoset_id=3")
+	_, ok = d.Get("c")
+	flickr.Expect(t, ok, false)
+	flickr.Expect(t, len(d.objects), 0)
+
+	// keys can't escape the directory
+	d.Set("../escaped", &Entry{})
+	_, err = os.Stat(dir + "/../escaped.json")
+	flickr.Expect(t, os.IsNotExist(err), true)
+}
+
+func TestDiskPrune(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cache")
+	flickr.Expect(t, err, nil)
+	defer os.RemoveAll(dir)
+
+	d, err := NewDisk(dir)
+	flickr.Expect(t, err, nil)
+	now := time.Now()
+	d.now = func() time.Time { return now }
+	d.Set("a", &Entry{Expires: now.Add(time.Second), Objects: []string{"photo_id=1"}})
+	d.Set("b", &Entry{Expires: now.Add(time.Hour)})
+
+	// expired entries are removed by the first Set after pruneInterval
+	now = now.Add(pruneInterval)
+	d.Set("c", &Entry{Expires: time.Now().Add(-time.Second)})
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	flickr.Expect(t, len(files), 2)
+	_, ok := d.Get("a")
+	flickr.Expect(t, ok, false)
+	flickr.Expect(t, len(d.objects), 0)
+	_, ok = d.Get("b")
+	flickr.Expect(t, ok, true)
+
+	// and on startup
+	_, err = NewDisk(dir)
+	flickr.Expect(t, err, nil)
+	files, _ = filepath.Glob(filepath.Join(dir, "*.json"))
+	flickr.Expect(t, len(files), 1)
+}
@@ -0,0 +1,96 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+)
+
+// An in-memory backend evicting the least recently used entries
+type LRU struct {
+	// Maximum number of entries, 0 for no limit
+	MaxEntries int
+
+	mu      sync.Mutex
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+// An element of the LRU list
+type lruItem struct {
+	key   string
+	entry *Entry
+}
+
+// Create an in-memory backend holding up to maxEntries entries
+func NewLRU(maxEntries int) *LRU {
+	return &LRU{
+		MaxEntries: maxEntries,
+		order:      list.New(),
+		entries:    map[string]*list.Element{},
+	}
+}
+
+// Implement Backend
+func (l *LRU) Get(key string) (*Entry, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	el, ok := l.entries[key]
+	if !ok {
+		return nil, false
+	}
+	l.order.MoveToFront(el)
+	return el.Value.(*lruItem).entry, true
+}
+
+// Implement Backend
+func (l *LRU) Set(key string, e *Entry) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if el, ok := l.entries[key]; ok {
+		el.Value.(*lruItem).entry = e
+		l.order.MoveToFront(el)
+		return
+	}
+
+	l.entries[key] = l.order.PushFront(&lruItem{key, e})
+	for l.MaxEntries > 0 && l.order.Len() > l.MaxEntries {
+		l.remove(l.order.Back())
+	}
+}
+
+// Implement Backend
+func (l *LRU) Delete(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if el, ok := l.entries[key]; ok {
+		l.remove(el)
+	}
+}
+
+// Implement Backend
+func (l *LRU) Invalidate(object string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for el := l.order.Front(); el != nil; {
+		next := el.Next()
+		for _, o := range el.Value.(*lruItem).entry.Objects {
+			if o == object {
+				l.remove(el)
+				break
+			}
+		}
+		el = next
+	}
+}
+
+// Return the number of entries
+func (l *LRU) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.order.Len()
+}
+
+func (l *LRU) remove(el *list.Element) {
+	l.order.Remove(el)
+	delete(l.entries, el.Value.(*lruItem).key)
+}
@@ -0,0 +1,42 @@
+package cache
+
+import (
+	"testing"
+
+	"gopkg.in/masci/flickr.v2"
+)
+
+func TestLRU(t *testing.T) {
+	l := NewLRU(2)
+	l.Set("a", &Entry{Body: []byte("a")})
+	l.Set("b", &Entry{Body: []byte("b")})
+	// a becomes the most recently used
+	_, ok := l.Get("a")
+	flickr.Expect(t, ok, true)
+	l.Set("c", &Entry{Body: []byte("c")})
+
+	flickr.Expect(t, l.Len(), 2)
+	_, ok = l.Get("b")
+	flickr.Expect(t, ok, false)
+	e, ok := l.Get("a")
+	flickr.Expect(t, ok, true)
+	flickr.Expect(t, string(e.Body), "a")
+
+	l.Delete("a")
+	_, ok = l.Get("a")
+	flickr.Expect(t, ok, false)
+}
+
+func TestLRUInvalidate(t *testing.T) {
+	l := NewLRU(0)
+	l.Set("a", &Entry{Objects: []string{"photo_id=1"}})
+	l.Set("b", &Entry{Objects: []string{"photo_id=2", "photoset_id=3"}})
+	l.Set("c", &Entry{})
+
+	l.Invalidate("photoset_id=3")
+	flickr.Expect(t, l.Len(), 2)
+	_, ok := l.Get("b")
+	flickr.Expect(t, ok, false)
+	_, ok = l.Get("a")
+	flickr.Expect(t, ok, true)
+}
@@ -0,0 +1,65 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"gopkg.in/masci/flickr.v2"
+)
+
+// A request in flight, waited for by identical requests
+type call struct {
+	done chan struct{}
+	res  *flickr.ResponseInfo
+	body []byte
+	err  error
+}
+
+// Run identical requests once at a time
+type group struct {
+	mu    sync.Mutex
+	calls map[string]*call
+}
+
+// A context keeping the values of its parent but never canceled, so that a
+// call shared by several callers doesn't stop when the first one gives up
+type detached struct {
+	context.Context
+}
+
+func (detached) Deadline() (time.Time, bool) { return time.Time{}, false }
+func (detached) Done() <-chan struct{}       { return nil }
+func (detached) Err() error                  { return nil }
+
+// Call fn, unless a call with the same key is in flight: in that case wait for
+// it and return its results. fn runs on a context detached from ctx and
+// canceled after timeout, callers stop waiting when their own ctx is done.
+func (g *group) do(ctx context.Context, key string, timeout time.Duration, fn func(ctx context.Context) (*flickr.ResponseInfo, []byte, error)) (*flickr.ResponseInfo, []byte, error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = map[string]*call{}
+	}
+	c, ok := g.calls[key]
+	if !ok {
+		c = &call{done: make(chan struct{})}
+		g.calls[key] = c
+		go func() {
+			fctx, cancel := context.WithTimeout(detached{ctx}, timeout)
+			defer cancel()
+			c.res, c.body, c.err = fn(fctx)
+			g.mu.Lock()
+			delete(g.calls, key)
+			g.mu.Unlock()
+			close(c.done)
+		}()
+	}
+	g.mu.Unlock()
+
+	select {
+	case <-c.done:
+		return c.res, c.body, c.err
+	case <-ctx.Done():
+		return nil, nil, ctx.Err()
+	}
+}
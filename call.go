@@ -0,0 +1,243 @@
+package flickr
+
+import (
+	"context"
+	"io/ioutil"
+	"net/url"
+
+	flickErr "gopkg.in/masci/flickr.v2/error"
+)
+
+// How Call signs requests
+type SignMethod int
+
+const (
+	// Sign with OAuth when the client has an access token, with the api
+	// secret otherwise
+	SignAuto SignMethod = iota
+	// Don't sign, only send the api key
+	SignNone
+	// Sign with the api secret, see FlickrClient.ApiSign
+	SignApi
+	// Sign with the client access token, see FlickrClient.OAuthSign
+	SignOAuth
+)
+
+// Settings of a generic call, zero values pick sensible defaults
+type CallOptions struct {
+	// How to sign the request, SignAuto by default
+	Sign SignMethod
+	// Either "GET" or "POST". By default, methods changing data on Flickr are
+	// called with POST and the others with GET.
+	HTTPVerb string
+}
+
+// Flickr methods changing data, any other method only reads it. Some of them
+// don't look like it, as flickr.auth.oauth.getAccessToken which deletes the
+// legacy token it exchanges.
+var writeMethods = map[string]bool{
+	"flickr.auth.oauth.getAccessToken": true,
+
+	"flickr.blogs.postPhoto": true,
+
+	"flickr.favorites.add":    true,
+	"flickr.favorites.remove": true,
+
+	"flickr.galleries.addPhoto":    true,
+	"flickr.galleries.create":      true,
+	"flickr.galleries.editMeta":    true,
+	"flickr.galleries.editPhoto":   true,
+	"flickr.galleries.editPhotos":  true,
+	"flickr.galleries.removePhoto": true,
+
+	"flickr.groups.join":                   true,
+	"flickr.groups.joinRequest":            true,
+	"flickr.groups.leave":                  true,
+	"flickr.groups.discuss.replies.add":    true,
+	"flickr.groups.discuss.replies.delete": true,
+	"flickr.groups.discuss.replies.edit":   true,
+	"flickr.groups.discuss.topics.add":     true,
+	"flickr.groups.pools.add":              true,
+	"flickr.groups.pools.remove":           true,
+
+	"flickr.photos.addTags":                       true,
+	"flickr.photos.delete":                        true,
+	"flickr.photos.removeTag":                     true,
+	"flickr.photos.setContentType":                true,
+	"flickr.photos.setDates":                      true,
+	"flickr.photos.setMeta":                       true,
+	"flickr.photos.setPerms":                      true,
+	"flickr.photos.setSafetyLevel":                true,
+	"flickr.photos.setTags":                       true,
+	"flickr.photos.comments.addComment":           true,
+	"flickr.photos.comments.deleteComment":        true,
+	"flickr.photos.comments.editComment":          true,
+	"flickr.photos.geo.batchCorrectLocation":      true,
+	"flickr.photos.geo.correctLocation":           true,
+	"flickr.photos.geo.removeLocation":            true,
+	"flickr.photos.geo.setContext":                true,
+	"flickr.photos.geo.setLocation":               true,
+	"flickr.photos.geo.setPerms":                  true,
+	"flickr.photos.licenses.setLicense":           true,
+	"flickr.photos.notes.add":                     true,
+	"flickr.photos.notes.delete":                  true,
+	"flickr.photos.notes.edit":                    true,
+	"flickr.photos.people.add":                    true,
+	"flickr.photos.people.delete":                 true,
+	"flickr.photos.people.deleteCoords":           true,
+	"flickr.photos.people.editCoords":             true,
+	"flickr.photos.suggestions.approveSuggestion": true,
+	"flickr.photos.suggestions.rejectSuggestion":  true,
+	"flickr.photos.suggestions.removeSuggestion":  true,
+	"flickr.photos.suggestions.suggestLocation":   true,
+	"flickr.photos.transform.rotate":              true,
+
+	"flickr.photosets.addPhoto":               true,
+	"flickr.photosets.create":                 true,
+	"flickr.photosets.delete":                 true,
+	"flickr.photosets.editMeta":               true,
+	"flickr.photosets.editPhotos":             true,
+	"flickr.photosets.orderSets":              true,
+	"flickr.photosets.removePhoto":            true,
+	"flickr.photosets.removePhotos":           true,
+	"flickr.photosets.reorderPhotos":          true,
+	"flickr.photosets.setPrimaryPhoto":        true,
+	"flickr.photosets.comments.addComment":    true,
+	"flickr.photosets.comments.deleteComment": true,
+	"flickr.photosets.comments.editComment":   true,
+
+	"flickr.push.subscribe":   true,
+	"flickr.push.unsubscribe": true,
+
+	"flickr.testimonials.addTestimonial":     true,
+	"flickr.testimonials.approveTestimonial": true,
+	"flickr.testimonials.deleteTestimonial":  true,
+	"flickr.testimonials.editTestimonial":    true,
+}
+
+// Return whether a Flickr method only reads data, like "flickr.photos.getInfo"
+// as opposed to "flickr.photos.delete"
+func IsReadMethod(method string) bool {
+	return !writeMethods[method]
+}
+
+// Call any Flickr method with the given params, e.g. "flickr.photos.getExif",
+// and unmarshal the XML response in out. Use it for methods without a
+// dedicated wrapper, embedding BasicResponse in the out type.
+// The request is signed and sent as described by CallOptions defaults.
+func (c *FlickrClient) Call(ctx context.Context, method string, params url.Values, out FlickrResponse) error {
+	return c.CallWithOptions(ctx, method, params, CallOptions{}, out)
+}
+
+// Same as Call, with explicit signing method and HTTP verb
+func (c *FlickrClient) CallWithOptions(ctx context.Context, method string, params url.Values, opts CallOptions, out FlickrResponse) error {
+	verb := c.prepareCall(method, params, opts)
+	// responses are unmarshalled from XML
+	c.Args.Del("format")
+	c.Args.Del("nojsoncallback")
+	c.sign(opts.Sign)
+
+	return doWithRetry(ctx, c, verb, out)
+}
+
+// Call any Flickr method and return the raw response body, handy to explore
+// the API. The body is XML, or JSON if params contain format=json.
+// An error is returned along with the body if Flickr reports one.
+func (c *FlickrClient) CallRaw(ctx context.Context, method string, params url.Values) ([]byte, error) {
+	return c.CallRawWithOptions(ctx, method, params, CallOptions{})
+}
+
+// Same as CallRaw, with explicit signing method and HTTP verb
+func (c *FlickrClient) CallRawWithOptions(ctx context.Context, method string, params url.Values, opts CallOptions) ([]byte, error) {
+	verb := c.prepareCall(method, params, opts)
+	if c.Args.Get("format") == "json" {
+		// get plain JSON instead of a JSONP callback
+		c.Args.Set("nojsoncallback", "1")
+	}
+	c.sign(opts.Sign)
+
+	body, err := c.doRaw(ctx, verb)
+	if timestampRefused(err) && c.resignOAuth() {
+		body, err = c.doRaw(ctx, verb)
+	}
+	return body, err
+}
+
+// Set up the client Args for a call, return the HTTP verb to use
+func (c *FlickrClient) prepareCall(method string, params url.Values, opts CallOptions) string {
+	c.Init()
+	for key, val := range params {
+		c.Args[key] = append([]string(nil), val...)
+	}
+	c.Args.Set("method", method)
+
+	verb := opts.HTTPVerb
+	if verb == "" {
+		verb = "POST"
+		if IsReadMethod(method) {
+			verb = "GET"
+		}
+	}
+	c.HTTPVerb = verb
+	return verb
+}
+
+// Sign the client Args with the given method
+func (c *FlickrClient) sign(method SignMethod) {
+	if method == SignAuto {
+		switch {
+		case c.OAuthToken != "":
+			method = SignOAuth
+		case c.ApiSecret != "":
+			method = SignApi
+		default:
+			method = SignNone
+		}
+	}
+
+	switch method {
+	case SignOAuth:
+		c.OAuthSign()
+	case SignApi:
+		c.ApiSign()
+	default:
+		c.Args.Set("api_key", c.ApiKey)
+	}
+}
+
+// Perform a request with the client Args and return the response body, along
+// with the error it reports if any
+func (c *FlickrClient) doRaw(ctx context.Context, verb string) ([]byte, error) {
+	req, err := newRequest(c, verb)
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.send(ctx, req, c.HTTPClient)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	return body, rawResponseError(res.StatusCode, body)
+}
+
+// Return the error reported by a raw XML or JSON response, if any
+func rawResponseError(statusCode int, body []byte) error {
+	code, msg := responseError(body)
+	switch {
+	case code > 0:
+		e := flickErr.NewError(flickErr.ApiError, msg)
+		e.ApiCode = code
+		e.StatusCode = statusCode
+		return e
+	case code < 0:
+		return nonApiError(statusCode, string(body))
+	case !statusOK(statusCode):
+		return flickErr.NewHTTPError(statusCode)
+	}
+	return nil
+}
@@ -0,0 +1,229 @@
+package flickr
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	flickErr "gopkg.in/masci/flickr.v2/error"
+)
+
+// Mock a Flickr server answering body and recording the requests it receives
+func callMock(body string) (*httptest.Server, *[]*http.Request) {
+	var requests []*http.Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseMultipartForm(1 << 20)
+		requests = append(requests, r)
+		fmt.Fprint(w, body)
+	}))
+	return server, &requests
+}
+
+type exifResponse struct {
+	BasicResponse
+	Photo struct {
+		ID     string `xml:"id,attr"`
+		Camera string `xml:"camera,attr"`
+	} `xml:"photo"`
+}
+
+func TestIsReadMethod(t *testing.T) {
+	Expect(t, IsReadMethod("flickr.photos.getExif"), true)
+	Expect(t, IsReadMethod("flickr.photos.search"), true)
+	Expect(t, IsReadMethod("flickr.test.login"), true)
+	Expect(t, IsReadMethod("flickr.places.placesForUser"), true)
+	Expect(t, IsReadMethod("flickr.photos.delete"), false)
+	Expect(t, IsReadMethod("flickr.photosets.addPhoto"), false)
+	Expect(t, IsReadMethod("flickr.groups.join"), false)
+	Expect(t, IsReadMethod("flickr.photos.recentlyUpdated"), true)
+	Expect(t, IsReadMethod("flickr.groups.browse"), true)
+	Expect(t, IsReadMethod("flickr.photos.comments.getRecentForContacts"), true)
+	Expect(t, IsReadMethod("flickr.photos.upload.checkTickets"), true)
+	// deletes the legacy token
+	Expect(t, IsReadMethod("flickr.auth.oauth.getAccessToken"), false)
+	Expect(t, IsReadMethod("flickr.photos.transform.rotate"), false)
+	Expect(t, IsReadMethod("flickr.photos.geo.batchCorrectLocation"), false)
+}
+
+func TestCall(t *testing.T) {
+	server, requests := callMock(`<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"><photo id="123" camera="Gopher Cam"></photo></rsp>`)
+	defer server.Close()
+
+	client := NewFlickrClient("key", "secret")
+	client.Endpoints.API = server.URL
+	params := url.Values{}
+	params.Set("photo_id", "123")
+	params.Set("format", "json")
+
+	resp := &exifResponse{}
+	err := client.Call(context.Background(), "flickr.photos.getExif", params, resp)
+	Expect(t, err, nil)
+	Expect(t, resp.Photo.ID, "123")
+	Expect(t, resp.Photo.Camera, "Gopher Cam")
+
+	// read methods are api-signed GET requests when the client has no token
+	r := (*requests)[0]
+	Expect(t, r.Method, "GET")
+	Expect(t, r.Form.Get("method"), "flickr.photos.getExif")
+	Expect(t, r.Form.Get("photo_id"), "123")
+	Expect(t, r.Form.Get("api_key"), "key")
+	Expect(t, r.Form.Get("api_sig") != "", true)
+	Expect(t, r.Form.Get("format"), "")
+	// params are not modified
+	Expect(t, params.Get("format"), "json")
+
+	// write methods are OAuth-signed POST requests when the client has a token
+	client.OAuthToken = "token"
+	client.OAuthTokenSecret = "token-secret"
+	err = client.Call(context.Background(), "flickr.photos.delete", params, &BasicResponse{})
+	Expect(t, err, nil)
+	r = (*requests)[1]
+	Expect(t, r.Method, "POST")
+	Expect(t, r.Form.Get("oauth_token"), "token")
+	Expect(t, r.Form.Get("oauth_signature") != "", true)
+	Expect(t, r.Form.Get("api_sig"), "")
+}
+
+func TestCallWithOptions(t *testing.T) {
+	server, requests := callMock(`<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"></rsp>`)
+	defer server.Close()
+
+	client := NewFlickrClient("key", "secret")
+	client.Endpoints.API = server.URL
+	client.OAuthToken = "token"
+
+	opts := CallOptions{Sign: SignNone, HTTPVerb: "POST"}
+	err := client.CallWithOptions(context.Background(), "flickr.photos.getInfo", nil, opts, &BasicResponse{})
+	Expect(t, err, nil)
+	r := (*requests)[0]
+	Expect(t, r.Method, "POST")
+	Expect(t, r.Form.Get("api_key"), "key")
+	Expect(t, r.Form.Get("api_sig"), "")
+	Expect(t, r.Form.Get("oauth_signature"), "")
+
+	opts = CallOptions{Sign: SignApi}
+	err = client.CallWithOptions(context.Background(), "flickr.photos.getInfo", nil, opts, &BasicResponse{})
+	Expect(t, err, nil)
+	r = (*requests)[1]
+	Expect(t, r.Method, "GET")
+	Expect(t, r.Form.Get("api_sig") != "", true)
+	Expect(t, r.Form.Get("oauth_token"), "")
+}
+
+func TestCallError(t *testing.T) {
+	server, _ := callMock(`<?xml version="1.0" encoding="utf-8" ?><rsp stat="fail"><err code="1" msg="Photo not found" /></rsp>`)
+	defer server.Close()
+
+	client := NewFlickrClient("key", "secret")
+	client.Endpoints.API = server.URL
+	resp := &BasicResponse{}
+	err := client.Call(context.Background(), "flickr.photos.getInfo", nil, resp)
+	_, ok := err.(*flickErr.Error)
+	Expect(t, ok, true)
+	Expect(t, resp.ErrorCode(), 1)
+}
+
+func TestCallContext(t *testing.T) {
+	server, requests := callMock(`<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"></rsp>`)
+	defer server.Close()
+
+	client := NewFlickrClient("key", "secret")
+	client.Endpoints.API = server.URL
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := client.Call(ctx, "flickr.test.null", nil, &BasicResponse{})
+	Expect(t, err != nil, true)
+	Expect(t, len(*requests), 0)
+}
+
+func TestCallRaw(t *testing.T) {
+	server, requests := callMock(`{"photo":{"id":"123"},"stat":"ok"}`)
+	defer server.Close()
+
+	client := NewFlickrClient("key", "secret")
+	client.Endpoints.API = server.URL
+	params := url.Values{}
+	params.Set("format", "json")
+	body, err := client.CallRaw(context.Background(), "flickr.photos.getExif", params)
+	Expect(t, err, nil)
+	Expect(t, string(body), `{"photo":{"id":"123"},"stat":"ok"}`)
+	Expect(t, (*requests)[0].Form.Get("nojsoncallback"), "1")
+}
+
+func TestCallRawError(t *testing.T) {
+	bodies := []string{
+		`<?xml version="1.0" encoding="utf-8" ?><rsp stat="fail"><err code="1" msg="Photo not found" /></rsp>`,
+		`{"stat":"fail","code":1,"message":"Photo not found"}`,
+		`oauth_problem=signature_invalid`,
+	}
+	for _, b := range bodies {
+		server, _ := callMock(b)
+		client := NewFlickrClient("key", "secret")
+		client.Endpoints.API = server.URL
+		body, err := client.CallRaw(context.Background(), "flickr.photos.getExif", nil)
+		server.Close()
+
+		Expect(t, string(body), b)
+		e, ok := err.(*flickErr.Error)
+		Expect(t, ok, true)
+		Expect(t, strings.Contains(e.Message, "Photo not found") || strings.Contains(e.Message, "signature_invalid"), true)
+	}
+}
+
+func TestCallRawTimestampRefused(t *testing.T) {
+	body := `<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"></rsp>`
+	server, client, timestamps := timestampRefusedMock(body)
+	defer server.Close()
+
+	fclient := NewFlickrClient("key", "secret")
+	fclient.HTTPClient = client
+	fclient.OAuthToken = "token"
+	raw, err := fclient.CallRaw(context.Background(), "flickr.test.null", nil)
+	Expect(t, err, nil)
+	Expect(t, string(raw), body)
+	Expect(t, len(*timestamps), 2)
+}
+
+func TestCallGatewayError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.WriteHeader(http.StatusBadGateway)
+		fmt.Fprint(w, "<html><body><h1>502 Bad Gateway</h1></body></html>")
+	}))
+	defer server.Close()
+
+	client := NewFlickrClient("key", "secret")
+	client.Endpoints.API = server.URL
+	err := client.Call(context.Background(), "flickr.photos.getInfo", nil, &BasicResponse{})
+	e, ok := err.(*flickErr.Error)
+	Expect(t, ok, true)
+	Expect(t, e.ErrorCode, flickErr.HTTPError)
+	Expect(t, e.IsGatewayError(), true)
+	Expect(t, e.Retryable(), true)
+
+	_, err = client.CallRaw(context.Background(), "flickr.photos.getInfo", nil)
+	e, ok = err.(*flickErr.Error)
+	Expect(t, ok, true)
+	Expect(t, e.StatusCode, http.StatusBadGateway)
+
+	client.Endpoints.RequestToken = server.URL
+	_, err = GetRequestToken(client)
+	e, ok = err.(*flickErr.Error)
+	Expect(t, ok, true)
+	Expect(t, e.ErrorCode, flickErr.HTTPError)
+}
+
+func TestCallTransportError(t *testing.T) {
+	client := NewFlickrClient("key", "secret")
+	client.Endpoints.API = "http://127.0.0.1:0"
+	err := client.Call(context.Background(), "flickr.photos.getInfo", nil, &BasicResponse{})
+	e, ok := err.(*flickErr.Error)
+	Expect(t, ok, true)
+	Expect(t, e.ErrorCode, flickErr.TransportError)
+	Expect(t, e.Err != nil, true)
+	Expect(t, e.Retryable(), true)
+}
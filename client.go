@@ -4,25 +4,56 @@ import (
 	"bytes"
 	"crypto/hmac"
 	"crypto/md5"
+	"crypto/rand"
 	"crypto/sha1"
 	"encoding/base64"
 	"fmt"
-	"math/rand"
+	"io"
+	"log"
+	"math/big"
 	"net/http"
 	"net/url"
 	"sort"
 	"strings"
+	"sync/atomic"
 	"time"
 )
 
-// Generate a random string of 8 chars, needed for OAuth signature
+// For convenience, nonces use a set of chars we don't need to url-escape
+const nonceLetters = "123456789abcdefghijkmnopqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ"
+
+// Source of the random nonce chars
+var nonceReader io.Reader = rand.Reader
+
+// Nonces generated so far, mixed into the ones not using nonceReader
+var nonceCount uint64
+
+// Generate a random string of 8 chars, needed for OAuth signature.
+// Chars are picked using crypto/rand so that nonces don't collide across
+// goroutines or processes started at the same time. If it fails, the nonce
+// is derived from the current time instead.
 func generateNonce() string {
-	rand.Seed(time.Now().UTC().UnixNano())
-	// For convenience, use a set of chars we don't need to url-escape
-	var letters = []rune("123456789abcdefghijkmnopqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ")
-	b := make([]rune, 8)
+	max := big.NewInt(int64(len(nonceLetters)))
+	b := make([]byte, 8)
+	for i := range b {
+		n, err := rand.Int(nonceReader, max)
+		if err != nil {
+			return timeNonce()
+		}
+		b[i] = nonceLetters[n.Int64()]
+	}
+	return string(b)
+}
+
+// Generate a nonce from the current time and a counter, so that nonces
+// generated at the same time by a process differ
+func timeNonce() string {
+	count := atomic.AddUint64(&nonceCount, 1)
+	v := uint64(time.Now().UnixNano()) ^ count*0x9E3779B97F4A7C15
+	b := make([]byte, 8)
 	for i := range b {
-		b[i] = letters[rand.Intn(len(letters))]
+		b[i] = nonceLetters[v%uint64(len(nonceLetters))]
+		v /= uint64(len(nonceLetters))
 	}
 	return string(b)
 }
@@ -36,7 +67,10 @@ type FlickrClient struct {
 	ApiSecret string
 	// A generic HTTP client to perform GET and POST requests
 	HTTPClient *http.Client
-	// The base url for API endpoints
+	// The base urls of Flickr endpoints, empty fields default to DefaultEndpoints
+	Endpoints Endpoints
+	// The url of the endpoint the next request is sent to, set by Init and
+	// by the functions performing requests
 	EndpointUrl string
 	// A string containing POST or GET, needed for OAuth signing
 	HTTPVerb string
@@ -48,6 +82,30 @@ type FlickrClient struct {
 	OAuthTokenSecret string
 	// User flickr ID
 	Id string
+	// Return the current local time, used to compute OAuth timestamps.
+	// Defaults to time.Now when nil.
+	Clock func() time.Time
+	// Return a new OAuth nonce for each signed request. Defaults to a
+	// crypto/rand based generator when nil.
+	NonceGenerator func() string
+	// Functions wrapping every request sent to Flickr, to log or measure
+	// them. The first one is the outermost, see Interceptor.
+	Interceptors []Interceptor
+	// Keep the raw body of API responses in BasicResponse.RawBody and Extra,
+	// handy to debug. Off by default, bodies are decoded as they're read.
+	KeepRawBody bool
+	// Don't send requests changing data, uploads included: log them to
+	// DryRunLogger once signed and answer with an empty successful response.
+	// Requests of read methods are still sent.
+	DryRun bool
+	// Where requests skipped by DryRun are logged, the standard logger when nil
+	DryRunLogger *log.Logger
+	// Offset between Flickr servers clock and the local one in nanoseconds,
+	// learnt from the Date header of API responses
+	clockSkew int64
+	// The token secret used to sign the current request, kept to sign it
+	// again in case Flickr refuses the OAuth timestamp
+	signingSecret string
 }
 
 // Create a Flickr client, apiKey and apiSecret are mandatory
@@ -66,14 +124,64 @@ func (c *FlickrClient) Sign(tokenSecret string) {
 	// the "oauth_signature" param must not be included in the signing process
 	c.Args.Del("oauth_signature")
 	c.Args.Set("oauth_signature", c.getSignature(tokenSecret))
+	c.signingSecret = tokenSecret
 }
 
 // Set the mandatory params for an OAuth request
 func (c *FlickrClient) SetOAuthDefaults() {
-	c.Args.Add("oauth_version", "1.0")
-	c.Args.Add("oauth_signature_method", "HMAC-SHA1")
-	c.Args.Add("oauth_nonce", generateNonce())
-	c.Args.Add("oauth_timestamp", fmt.Sprintf("%d", time.Now().Unix()))
+	c.Args.Set("oauth_version", "1.0")
+	c.Args.Set("oauth_signature_method", "HMAC-SHA1")
+	c.Args.Set("oauth_nonce", c.nonce())
+	c.Args.Set("oauth_timestamp", fmt.Sprintf("%d", c.now().Unix()))
+}
+
+// Return the local time as given by the client Clock
+func (c *FlickrClient) localTime() time.Time {
+	if c.Clock != nil {
+		return c.Clock()
+	}
+	return time.Now()
+}
+
+// Return a new nonce as given by the client NonceGenerator
+func (c *FlickrClient) nonce() string {
+	if c.NonceGenerator != nil {
+		return c.NonceGenerator()
+	}
+	return generateNonce()
+}
+
+// Return the time estimated on Flickr servers, that is the local time
+// corrected by the clock skew observed so far
+func (c *FlickrClient) now() time.Time {
+	return c.localTime().Add(c.ClockSkew())
+}
+
+// Return the offset between Flickr servers clock and the local one
+func (c *FlickrClient) ClockSkew() time.Duration {
+	return time.Duration(atomic.LoadInt64(&c.clockSkew))
+}
+
+// Update the clock skew using the Date header of a response from Flickr.
+// Responses without a valid Date header are ignored.
+func (c *FlickrClient) updateClockSkew(res *http.Response) {
+	date, err := http.ParseTime(res.Header.Get("Date"))
+	if err != nil {
+		return
+	}
+	atomic.StoreInt64(&c.clockSkew, int64(date.Sub(c.localTime())))
+}
+
+// Refresh timestamp and nonce of an OAuth signed request and sign it again
+// with the same token secret. Return false if the request wasn't OAuth signed.
+func (c *FlickrClient) resignOAuth() bool {
+	if c.Args.Get("oauth_signature") == "" {
+		return false
+	}
+	c.Args.Set("oauth_nonce", c.nonce())
+	c.Args.Set("oauth_timestamp", fmt.Sprintf("%d", c.now().Unix()))
+	c.Sign(c.signingSecret)
+	return true
 }
 
 // Sign the request with a default set of OAuth parameters, needed to authorize
@@ -106,26 +214,42 @@ func (c *FlickrClient) ClearArgs() {
 	c.Args = url.Values{}
 }
 
-// Reset Args and set the default endpoint
+// Reset Args and set the REST API endpoint
 func (c *FlickrClient) Init() {
 	c.ClearArgs()
-	c.EndpointUrl = API_ENDPOINT
+	c.EndpointUrl = c.GetEndpoints().API
+	c.HTTPVerb = "GET"
+}
+
+// Return the endpoints used by the client, empty fields set to the Flickr ones
+func (c *FlickrClient) GetEndpoints() Endpoints {
+	return c.Endpoints.withDefaults()
 }
 
 // Get the base string to compose the signature
 func (c *FlickrClient) getSigningBaseString() string {
-	request_url := url.QueryEscape(c.EndpointUrl)
-	flickr_encoded := strings.Replace(c.Args.Encode(), "+", "%20", -1)
+	return signingBaseString(c.HTTPVerb, c.EndpointUrl, c.Args)
+}
+
+// Compose the OAuth base string of a request given its HTTP verb, its URL
+// without query and its params
+func signingBaseString(verb string, requestUrl string, args url.Values) string {
+	request_url := url.QueryEscape(requestUrl)
+	flickr_encoded := strings.Replace(args.Encode(), "+", "%20", -1)
 	query := url.QueryEscape(flickr_encoded)
 
-	ret := fmt.Sprintf("%s&%s&%s", c.HTTPVerb, request_url, query)
+	ret := fmt.Sprintf("%s&%s&%s", verb, request_url, query)
 	return ret
 }
 
 // Compute the signature of a signed request
 func (c *FlickrClient) getSignature(token_secret string) string {
-	key := fmt.Sprintf("%s&%s", url.QueryEscape(c.ApiSecret), url.QueryEscape(token_secret))
-	base_string := c.getSigningBaseString()
+	return hmacSignature(c.ApiSecret, token_secret, c.getSigningBaseString())
+}
+
+// Compute the HMAC-SHA1 signature of a base string
+func hmacSignature(consumer_secret string, token_secret string, base_string string) string {
+	key := fmt.Sprintf("%s&%s", url.QueryEscape(consumer_secret), url.QueryEscape(token_secret))
 
 	mac := hmac.New(sha1.New, []byte(key))
 	mac.Write([]byte(base_string))
@@ -1,7 +1,13 @@
 package flickr
 
 import (
+	"crypto/rand"
+	"errors"
+	"net/http"
+	"sync"
 	"testing"
+	"testing/iotest"
+	"time"
 )
 
 func TestGetSigningBaseString(t *testing.T) {
@@ -46,6 +52,58 @@ func TestGenerateNonce(t *testing.T) {
 	Expect(t, 8, len(nonce))
 }
 
+// A failing random source doesn't stop signing
+func TestGenerateNonceFallback(t *testing.T) {
+	nonceReader = iotest.ErrReader(errors.New("no entropy"))
+	defer func() { nonceReader = rand.Reader }()
+
+	seen := map[string]bool{}
+	for i := 0; i < 50; i++ {
+		nonce := generateNonce()
+		Expect(t, len(nonce), 8)
+		Expect(t, seen[nonce], false)
+		seen[nonce] = true
+	}
+
+	c := NewFlickrClient("key", "secret")
+	c.Init()
+	c.OAuthSign()
+	Expect(t, len(c.Args.Get("oauth_nonce")), 8)
+}
+
+func TestGenerateNonceConcurrent(t *testing.T) {
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	seen := map[string]bool{}
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			nonce := generateNonce()
+			mu.Lock()
+			defer mu.Unlock()
+			if seen[nonce] {
+				t.Error("Duplicated nonce", nonce)
+			}
+			seen[nonce] = true
+		}()
+	}
+	wg.Wait()
+}
+
+func TestDeterministicSigning(t *testing.T) {
+	c := GetTestClient()
+	c.ClearArgs()
+	c.SetOAuthDefaults()
+	c.Args.Set("oauth_consumer_key", "768fe946d252b119746fda82e1599980")
+	c.Args.Set("oauth_callback", "http://www.wackylabs.net/oauth/test")
+
+	c.Sign("token12345secret")
+	Expect(t, c.Args.Get("oauth_nonce"), "C2F26CD5C075BA9050AD8EE90644CF29")
+	Expect(t, c.Args.Get("oauth_timestamp"), "1316657628")
+	Expect(t, c.Args.Get("oauth_signature"), "dXyfrCetFSTpzD3djSrkFhj0MIQ=")
+}
+
 func TestSetDefaultArgs(t *testing.T) {
 	c := GetTestClient()
 	c.SetOAuthDefaults()
@@ -60,6 +118,11 @@ func TestSetDefaultArgs(t *testing.T) {
 	check("oauth_signature_method")
 	check("oauth_nonce")
 	check("oauth_timestamp")
+
+	// calling it again must not duplicate params
+	c.SetOAuthDefaults()
+	Expect(t, len(c.Args["oauth_nonce"]), 1)
+	Expect(t, len(c.Args["oauth_timestamp"]), 1)
 }
 
 func TestNewFlickrClient(t *testing.T) {
@@ -86,7 +149,58 @@ func TestInit(t *testing.T) {
 	client := GetTestClient()
 	client.Args.Set("foo", "bar")
 	client.EndpointUrl = ""
+	client.HTTPVerb = "POST"
 	client.Init()
 	Expect(t, len(client.Args), 0)
 	Expect(t, client.EndpointUrl != "", true)
+	Expect(t, client.HTTPVerb, "GET")
+}
+
+func TestGetEndpoints(t *testing.T) {
+	client := GetTestClient()
+	Expect(t, client.GetEndpoints(), DefaultEndpoints)
+
+	client.Endpoints.API = "http://localhost/rest"
+	e := client.GetEndpoints()
+	Expect(t, e.API, "http://localhost/rest")
+	Expect(t, e.Upload, UPLOAD_ENDPOINT)
+	Expect(t, e.AccessToken, ACCESS_TOKEN_URL)
+
+	client.Init()
+	Expect(t, client.EndpointUrl, "http://localhost/rest")
+}
+
+func TestUpdateClockSkew(t *testing.T) {
+	client := GetTestClient()
+	Expect(t, client.ClockSkew(), time.Duration(0))
+
+	res := &http.Response{Header: http.Header{}}
+	client.updateClockSkew(res)
+	Expect(t, client.ClockSkew(), time.Duration(0))
+
+	res.Header.Set("Date", time.Unix(1316657628+3600, 0).UTC().Format(http.TimeFormat))
+	client.updateClockSkew(res)
+	Expect(t, client.ClockSkew(), time.Hour)
+
+	client.ClearArgs()
+	client.SetOAuthDefaults()
+	Expect(t, client.Args.Get("oauth_timestamp"), "1316661228")
+}
+
+func TestResignOAuth(t *testing.T) {
+	client := GetTestClient()
+	Expect(t, client.resignOAuth(), false)
+
+	client.Sign("token12345secret")
+	Expect(t, client.Args.Get("oauth_signature"), "dXyfrCetFSTpzD3djSrkFhj0MIQ=")
+
+	client.Clock = time.Now
+	client.NonceGenerator = nil
+	Expect(t, client.resignOAuth(), true)
+	Expect(t, client.Args.Get("oauth_timestamp") != "1316657628", true)
+	Expect(t, client.Args.Get("oauth_nonce") != "C2F26CD5C075BA9050AD8EE90644CF29", true)
+	signature := client.Args.Get("oauth_signature")
+	Expect(t, signature != "dXyfrCetFSTpzD3djSrkFhj0MIQ=", true)
+	client.Args.Del("oauth_signature")
+	Expect(t, signature, client.getSignature("token12345secret"))
 }
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"encoding/xml"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Output of flickr.reflection.getMethods
+type methodList struct {
+	Methods []string `xml:"methods>method"`
+}
+
+// Directories not part of the library, skipped when looking for wrappers
+var skippedDirs = map[string]bool{
+	"cmd":        true,
+	"examples":   true,
+	"flickrtest": true,
+	"testdata":   true,
+}
+
+// A Flickr method name
+var methodName = regexp.MustCompile(`^flickr(\.[A-Za-z]+)+$`)
+
+// Load the method names stored in a getMethods response
+func loadMethodList(path string) ([]string, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	list := &methodList{}
+	if err := xml.Unmarshal(data, list); err != nil {
+		return nil, err
+	}
+	return list.Methods, nil
+}
+
+// Collect the Flickr methods called by the Go sources of the library rooted
+// at dir, test files excluded. A method is called when its name is set as the
+// "method" arg, like in client.Args.Set("method", "flickr.test.echo"), or
+// passed to a function of the library taking a method param, like Call.
+func wrappedMethods(dir string) (map[string]bool, error) {
+	fset := token.NewFileSet()
+	var files []*ast.File
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if path != dir && (skippedDirs[info.Name()] || strings.HasPrefix(info.Name(), ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+		f, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return err
+		}
+		files = append(files, f)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// position of the method param of the library functions, by name
+	params := map[string]int{"Call": 1, "CallRaw": 1}
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok {
+				continue
+			}
+			i := 0
+			for _, field := range fn.Type.Params.List {
+				for _, name := range field.Names {
+					if name.Name == "method" {
+						params[fn.Name.Name] = i
+					}
+					i++
+				}
+				if len(field.Names) == 0 {
+					i++
+				}
+			}
+		}
+	}
+
+	ret := map[string]bool{}
+	add := func(arg ast.Expr) {
+		lit, ok := arg.(*ast.BasicLit)
+		if !ok || lit.Kind != token.STRING {
+			return
+		}
+		if name, err := strconv.Unquote(lit.Value); err == nil && methodName.MatchString(name) {
+			ret[name] = true
+		}
+	}
+	for _, f := range files {
+		ast.Inspect(f, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			var name string
+			switch fun := call.Fun.(type) {
+			case *ast.Ident:
+				name = fun.Name
+			case *ast.SelectorExpr:
+				name = fun.Sel.Name
+				if name == "Set" && len(call.Args) == 2 && isArgs(fun.X) {
+					if key, ok := call.Args[0].(*ast.BasicLit); ok && key.Value == `"method"` {
+						add(call.Args[1])
+					}
+					return true
+				}
+			}
+			if i, ok := params[name]; ok && i < len(call.Args) {
+				add(call.Args[i])
+			}
+			return true
+		})
+	}
+	return ret, nil
+}
+
+// Whether x is the Args field of a client, like client.Args
+func isArgs(x ast.Expr) bool {
+	sel, ok := x.(*ast.SelectorExpr)
+	return ok && sel.Sel.Name == "Args"
+}
+
+// Split methods between the ones wrapped by the library and the others
+func checkMethods(methods []string, wrapped map[string]bool) (done, missing []string) {
+	for _, m := range methods {
+		if wrapped[m] {
+			done = append(done, m)
+		} else {
+			missing = append(missing, m)
+		}
+	}
+	sort.Strings(done)
+	sort.Strings(missing)
+	return done, missing
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"testing"
+
+	"gopkg.in/masci/flickr.v2"
+)
+
+func TestLoadMethodList(t *testing.T) {
+	methods, err := loadMethodList("reflection/getMethods.xml")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, len(methods) > 200, true)
+	flickr.Expect(t, methods[0], "flickr.activity.userComments")
+}
+
+func TestWrappedMethods(t *testing.T) {
+	wrapped, err := wrappedMethods("../..")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, wrapped["flickr.photos.getInfo"], true)
+	flickr.Expect(t, wrapped["flickr.photosets.create"], true)
+	// wrapped by the comments helpers taking a method param
+	flickr.Expect(t, wrapped["flickr.photos.comments.addComment"], true)
+	// only named in the table of write methods
+	flickr.Expect(t, wrapped["flickr.blogs.postPhoto"], false)
+	flickr.Expect(t, wrapped["flickr.push.subscribe"], false)
+	// the generated example and the fake server are not part of the library
+	flickr.Expect(t, wrapped["flickr.photos.getExif"], false)
+}
+
+func TestWrappedMethodsCalls(t *testing.T) {
+	wrapped, err := wrappedMethods("testdata/check")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, wrapped["flickr.test.echo"], true)
+	flickr.Expect(t, wrapped["flickr.test.login"], true)
+	flickr.Expect(t, wrapped["flickr.test.null"], true)
+	// names that are not set as the method arg
+	flickr.Expect(t, wrapped["flickr.test.text"], false)
+	flickr.Expect(t, wrapped["flickr.test.id"], false)
+	flickr.Expect(t, wrapped["flickr.blogs.postPhoto"], false)
+	flickr.Expect(t, wrapped["flickr.photos.notes.add"], false)
+	flickr.Expect(t, len(wrapped), 3)
+}
+
+func TestCheckMethods(t *testing.T) {
+	methods := []string{"flickr.test.null", "flickr.photos.getExif", "flickr.test.echo"}
+	wrapped := map[string]bool{"flickr.test.echo": true, "flickr.test.null": true}
+	done, missing := checkMethods(methods, wrapped)
+	flickr.Expect(t, len(done), 2)
+	flickr.Expect(t, done[0], "flickr.test.echo")
+	flickr.Expect(t, len(missing), 1)
+	flickr.Expect(t, missing[0], "flickr.photos.getExif")
+}
@@ -0,0 +1,5 @@
+// Package example holds the flickr.photos wrappers generated by flickr-gen from
+// the responses in ../reflection/methods, kept up to date by the flickr-gen tests.
+package example
+
+//go:generate go run .. -in ../reflection/methods -prefix flickr.photos -pkg example -out photos.go
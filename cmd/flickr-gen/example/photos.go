@@ -0,0 +1,197 @@
+// Code generated by flickr-gen from flickr.reflection.getMethodInfo responses. DO NOT EDIT.
+
+package example
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"gopkg.in/masci/flickr.v2"
+)
+
+// Arguments of flickr.photos.getContext
+type GetContextArgs struct {
+	// The id of the photo to fetch the context for.
+	// Required.
+	PhotoId string
+}
+
+// Returns next and previous photos for a photo in a photostream.
+// No permissions required, requests are not signed.
+// Errors:
+//
+//	1: Photo not found
+//	100: Invalid API Key
+func GetContext(ctx context.Context, client *flickr.FlickrClient, args *GetContextArgs, out flickr.FlickrResponse) error {
+	params := url.Values{}
+	if args.PhotoId == "" {
+		return fmt.Errorf("flickr.photos.getContext: argument photo_id is required")
+	}
+	params.Set("photo_id", args.PhotoId)
+
+	opts := flickr.CallOptions{Sign: flickr.SignNone, HTTPVerb: "GET"}
+	return client.CallWithOptions(ctx, "flickr.photos.getContext", params, opts, out)
+}
+
+// Arguments of flickr.photos.getExif
+type GetExifArgs struct {
+	// The id of the photo to fetch information for.
+	// Required.
+	PhotoId string
+	// The secret for the photo. If the correct secret is passed then permissions
+	// checking is skipped. This enables the 'sharing' of individual photos by
+	// passing around the id and secret.
+	Secret string
+}
+
+// Retrieves a list of EXIF/TIFF/GPS tags for a given photo. The calling user
+// must have permission to view the photo.
+// No permissions required, requests are not signed.
+// Errors:
+//
+//	1: Photo not found
+//	2: Permission denied
+//	100: Invalid API Key
+func GetExif(ctx context.Context, client *flickr.FlickrClient, args *GetExifArgs, out flickr.FlickrResponse) error {
+	params := url.Values{}
+	if args.PhotoId == "" {
+		return fmt.Errorf("flickr.photos.getExif: argument photo_id is required")
+	}
+	params.Set("photo_id", args.PhotoId)
+	if args.Secret != "" {
+		params.Set("secret", args.Secret)
+	}
+
+	opts := flickr.CallOptions{Sign: flickr.SignNone, HTTPVerb: "GET"}
+	return client.CallWithOptions(ctx, "flickr.photos.getExif", params, opts, out)
+}
+
+// Arguments of flickr.photos.getFavorites
+type GetFavoritesArgs struct {
+	// The ID of the photo to fetch the favoriters list for.
+	// Required.
+	PhotoId string
+	// The page of results to return. If this argument is omitted, it defaults to
+	// 1.
+	Page int
+	// Number of usres to return per page. If this argument is omitted, it defaults
+	// to 10. The maximum allowed value is 50.
+	PerPage int
+}
+
+// Returns the list of people who have favorited a given photo.
+// No permissions required, requests are not signed.
+// Errors:
+//
+//	1: Photo not found
+//	100: Invalid API Key
+func GetFavorites(ctx context.Context, client *flickr.FlickrClient, args *GetFavoritesArgs, out flickr.FlickrResponse) error {
+	params := url.Values{}
+	if args.PhotoId == "" {
+		return fmt.Errorf("flickr.photos.getFavorites: argument photo_id is required")
+	}
+	params.Set("photo_id", args.PhotoId)
+	if args.Page != 0 {
+		params.Set("page", strconv.Itoa(args.Page))
+	}
+	if args.PerPage != 0 {
+		params.Set("per_page", strconv.Itoa(args.PerPage))
+	}
+
+	opts := flickr.CallOptions{Sign: flickr.SignNone, HTTPVerb: "GET"}
+	return client.CallWithOptions(ctx, "flickr.photos.getFavorites", params, opts, out)
+}
+
+// Arguments of flickr.photos.licenses.getInfo
+type LicensesGetInfoArgs struct {
+}
+
+// Fetches a list of available photo licenses for Flickr.
+// No permissions required, requests are not signed.
+// Errors:
+//
+//	100: Invalid API Key
+func LicensesGetInfo(ctx context.Context, client *flickr.FlickrClient, args *LicensesGetInfoArgs, out flickr.FlickrResponse) error {
+	params := url.Values{}
+
+	opts := flickr.CallOptions{Sign: flickr.SignNone, HTTPVerb: "GET"}
+	return client.CallWithOptions(ctx, "flickr.photos.licenses.getInfo", params, opts, out)
+}
+
+// Arguments of flickr.photos.setMeta
+type SetMetaArgs struct {
+	// The id of the photo to set information for.
+	// Required.
+	PhotoId string
+	// The title for the photo. At least one of title or description must be set.
+	Title string
+	// The description for the photo. At least one of title or description must be
+	// set.
+	Description string
+}
+
+// Set the meta information for a photo.
+// Requires write permissions, requests are OAuth signed with the client access token.
+// Errors:
+//
+//	1: Photo not found
+//	2: User has not configured default title and description
+//	99: Insufficient permissions
+func SetMeta(ctx context.Context, client *flickr.FlickrClient, args *SetMetaArgs, out flickr.FlickrResponse) error {
+	params := url.Values{}
+	if args.PhotoId == "" {
+		return fmt.Errorf("flickr.photos.setMeta: argument photo_id is required")
+	}
+	params.Set("photo_id", args.PhotoId)
+	if args.Title != "" {
+		params.Set("title", args.Title)
+	}
+	if args.Description != "" {
+		params.Set("description", args.Description)
+	}
+
+	opts := flickr.CallOptions{Sign: flickr.SignOAuth, HTTPVerb: "POST"}
+	return client.CallWithOptions(ctx, "flickr.photos.setMeta", params, opts, out)
+}
+
+// Arguments of flickr.photos.setSafetyLevel
+type SetSafetyLevelArgs struct {
+	// The id of the photo to set the adultness of.
+	// Required.
+	PhotoId string
+	// The safety level of the photo. Must be one of: 1 for Safe, 2 for Moderate,
+	// and 3 for Restricted.
+	SafetyLevel int
+	// Whether or not to additionally hide the photo from public searches. Must be
+	// either 1 for Yes or 0 for No.
+	Hidden *bool
+}
+
+// Set the safety level of a photo.
+// Requires write permissions, requests are OAuth signed with the client access token.
+// Errors:
+//
+//	1: Photo not found
+//	2: Invalid or missing value for safety_level
+//	99: Insufficient permissions
+func SetSafetyLevel(ctx context.Context, client *flickr.FlickrClient, args *SetSafetyLevelArgs, out flickr.FlickrResponse) error {
+	params := url.Values{}
+	if args.PhotoId == "" {
+		return fmt.Errorf("flickr.photos.setSafetyLevel: argument photo_id is required")
+	}
+	params.Set("photo_id", args.PhotoId)
+	if args.SafetyLevel != 0 {
+		params.Set("safety_level", strconv.Itoa(args.SafetyLevel))
+	}
+	if args.Hidden != nil {
+		params.Set("hidden", "0")
+		if *args.Hidden {
+			params.Set("hidden", "1")
+		}
+	}
+
+	opts := flickr.CallOptions{Sign: flickr.SignOAuth, HTTPVerb: "POST"}
+	return client.CallWithOptions(ctx, "flickr.photos.setSafetyLevel", params, opts, out)
+}
@@ -0,0 +1,465 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// Output of flickr.reflection.getMethodInfo
+type methodInfo struct {
+	Method struct {
+		Name          string `xml:"name,attr"`
+		NeedsLogin    int    `xml:"needslogin,attr"`
+		NeedsSigning  int    `xml:"needssigning,attr"`
+		RequiredPerms int    `xml:"requiredperms,attr"`
+		Description   string `xml:"description"`
+	} `xml:"method"`
+	Arguments []methodArgument `xml:"arguments>argument"`
+	Errors    []methodError    `xml:"errors>error"`
+}
+
+type methodArgument struct {
+	Name        string `xml:"name,attr"`
+	Optional    int    `xml:"optional,attr"`
+	Description string `xml:",chardata"`
+}
+
+type methodError struct {
+	Code    int    `xml:"code,attr"`
+	Message string `xml:"message,attr"`
+}
+
+// Output of flickr.reflection.getMethodInfo with format=json, where text
+// goes in _content fields
+type jsonMethodInfo struct {
+	Method struct {
+		Name          string      `json:"name"`
+		NeedsLogin    jsonInt     `json:"needslogin"`
+		NeedsSigning  jsonInt     `json:"needssigning"`
+		RequiredPerms jsonInt     `json:"requiredperms"`
+		Description   jsonContent `json:"description"`
+	} `json:"method"`
+	Arguments struct {
+		Argument []struct {
+			Name     string  `json:"name"`
+			Optional jsonInt `json:"optional"`
+			Content  string  `json:"_content"`
+		} `json:"argument"`
+	} `json:"arguments"`
+	Errors struct {
+		Error []struct {
+			Code    jsonInt `json:"code"`
+			Message string  `json:"message"`
+		} `json:"error"`
+	} `json:"errors"`
+}
+
+type jsonContent struct {
+	Content string `json:"_content"`
+}
+
+// An integer Flickr writes either as a JSON number or as a string
+type jsonInt int
+
+func (i *jsonInt) UnmarshalJSON(data []byte) error {
+	n, err := strconv.Atoi(strings.Trim(string(data), `"`))
+	*i = jsonInt(n)
+	return err
+}
+
+// Return the same method info as the XML response
+func (j *jsonMethodInfo) methodInfo() *methodInfo {
+	info := &methodInfo{}
+	info.Method.Name = j.Method.Name
+	info.Method.NeedsLogin = int(j.Method.NeedsLogin)
+	info.Method.NeedsSigning = int(j.Method.NeedsSigning)
+	info.Method.RequiredPerms = int(j.Method.RequiredPerms)
+	info.Method.Description = j.Method.Description.Content
+	for _, a := range j.Arguments.Argument {
+		info.Arguments = append(info.Arguments, methodArgument{a.Name, int(a.Optional), a.Content})
+	}
+	for _, e := range j.Errors.Error {
+		info.Errors = append(info.Errors, methodError{int(e.Code), e.Message})
+	}
+	return info
+}
+
+// Permission levels, indexed by the requiredperms attribute
+var permLevels = []string{"none", "read", "write", "delete"}
+
+// Arguments set by the client, not exposed in argument structs
+var clientArguments = map[string]bool{
+	"api_key": true,
+}
+
+// getMethodInfo doesn't tell argument types: arguments are strings unless
+// listed here, named like is_public for booleans or like photo_ids for lists
+// of IDs
+var intArguments = map[string]bool{
+	"accuracy":       true,
+	"content_type":   true,
+	"count":          true,
+	"degrees":        true,
+	"geo_context":    true,
+	"license_id":     true,
+	"num_next":       true,
+	"num_prev":       true,
+	"page":           true,
+	"per_page":       true,
+	"perm_addmeta":   true,
+	"perm_comment":   true,
+	"privacy_filter": true,
+	"safety_level":   true,
+}
+
+var boolArguments = map[string]bool{
+	"has_geo":      true,
+	"hidden":       true,
+	"in_gallery":   true,
+	"include_self": true,
+}
+
+// A method wrapper to generate
+type wrapper struct {
+	Method      string
+	Func        string
+	Description []string
+	Perms       string
+	Sign        string
+	SignDoc     string
+	HTTPVerb    string
+	Args        []argument
+	Errors      []string
+}
+
+// An argument of a method wrapper
+type argument struct {
+	Name        string
+	Field       string
+	Required    bool
+	Description []string
+	// Either "string", "int", "bool" or "list"
+	Kind string
+}
+
+// Return the kind of the argument called name
+func argumentKind(name string) string {
+	switch {
+	case intArguments[name]:
+		return "int"
+	case boolArguments[name] || strings.HasPrefix(name, "is_"):
+		return "bool"
+	case strings.HasSuffix(name, "_ids"):
+		return "list"
+	}
+	return "string"
+}
+
+// Go type of the argument field, optional booleans are pointers to tell
+// false from unset
+func (a argument) GoType() string {
+	switch a.Kind {
+	case "int":
+		return "int"
+	case "bool":
+		if a.Required {
+			return "bool"
+		}
+		return "*bool"
+	case "list":
+		return "[]string"
+	}
+	return "string"
+}
+
+// Go expression true when the argument is not set
+func (a argument) Unset() string {
+	field := "args." + a.Field
+	switch a.Kind {
+	case "int":
+		return field + " == 0"
+	case "list":
+		return "len(" + field + ") == 0"
+	}
+	return field + ` == ""`
+}
+
+// Go expression true when the argument is set
+func (a argument) Set() string {
+	field := "args." + a.Field
+	switch a.Kind {
+	case "int":
+		return field + " != 0"
+	case "list":
+		return "len(" + field + ") > 0"
+	}
+	return field + ` != ""`
+}
+
+// Go expression of the argument value as a string
+func (a argument) Value() string {
+	field := "args." + a.Field
+	switch a.Kind {
+	case "int":
+		return "strconv.Itoa(" + field + ")"
+	case "list":
+		return "strings.Join(" + field + `, ",")`
+	}
+	return field
+}
+
+// Load the getMethodInfo responses stored in dir, one method per .xml or
+// .json file, keeping the methods whose name starts with prefix
+func loadMethods(dir, prefix string) ([]*methodInfo, error) {
+	var files []string
+	for _, ext := range []string{"*.xml", "*.json"} {
+		matches, err := filepath.Glob(filepath.Join(dir, ext))
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, matches...)
+	}
+
+	var ret []*methodInfo
+	for _, f := range files {
+		info, err := loadMethod(f)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", f, err)
+		}
+		if info.Method.Name == "" {
+			return nil, fmt.Errorf("%s: not a flickr.reflection.getMethodInfo response", f)
+		}
+		if strings.HasPrefix(info.Method.Name, prefix+".") {
+			ret = append(ret, info)
+		}
+	}
+
+	sort.Slice(ret, func(i, j int) bool { return ret[i].Method.Name < ret[j].Method.Name })
+	return ret, nil
+}
+
+// Decode a getMethodInfo response, in JSON when the file name ends with .json
+func loadMethod(path string) (*methodInfo, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if filepath.Ext(path) != ".json" {
+		info := &methodInfo{}
+		err = xml.Unmarshal(data, info)
+		return info, err
+	}
+
+	j := &jsonMethodInfo{}
+	if err := json.Unmarshal(data, j); err != nil {
+		return nil, err
+	}
+	return j.methodInfo(), nil
+}
+
+// Generate the source of a package wrapping the given methods. Function names
+// are the method names without prefix, like LicensesGetInfo for
+// flickr.photos.licenses.getInfo when prefix is flickr.photos.
+func generate(pkg, prefix string, methods []*methodInfo) ([]byte, error) {
+	var wrappers []*wrapper
+	for _, info := range methods {
+		wrappers = append(wrappers, newWrapper(prefix, info))
+	}
+
+	// fmt is only needed to report missing required arguments, booleans are
+	// never missing
+	needsFmt, needsStrconv, needsStrings := false, false, false
+	for _, w := range wrappers {
+		for _, a := range w.Args {
+			needsFmt = needsFmt || a.Required && a.Kind != "bool"
+			needsStrconv = needsStrconv || a.Kind == "int"
+			needsStrings = needsStrings || a.Kind == "list"
+		}
+	}
+
+	buf := &bytes.Buffer{}
+	err := sourceTemplate.Execute(buf, struct {
+		Package      string
+		NeedsFmt     bool
+		NeedsStrconv bool
+		NeedsStrings bool
+		Wrappers     []*wrapper
+	}{pkg, needsFmt, needsStrconv, needsStrings, wrappers})
+	if err != nil {
+		return nil, err
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("generated invalid code: %v\n%s", err, buf.Bytes())
+	}
+	return src, nil
+}
+
+func newWrapper(prefix string, info *methodInfo) *wrapper {
+	m := info.Method
+	w := &wrapper{
+		Method:      m.Name,
+		Func:        exportedName(strings.Split(strings.TrimPrefix(m.Name, prefix+"."), ".")...),
+		Description: commentLines(m.Description),
+		Perms:       "none",
+		HTTPVerb:    "GET",
+	}
+	if m.RequiredPerms >= 0 && m.RequiredPerms < len(permLevels) {
+		w.Perms = permLevels[m.RequiredPerms]
+	}
+	// requests changing data must be POSTed
+	if m.RequiredPerms >= 2 {
+		w.HTTPVerb = "POST"
+	}
+
+	switch {
+	case m.NeedsLogin == 1:
+		w.Sign, w.SignDoc = "SignOAuth", "OAuth signed with the client access token"
+	case m.NeedsSigning == 1:
+		w.Sign, w.SignDoc = "SignApi", "signed with the api secret"
+	default:
+		w.Sign, w.SignDoc = "SignNone", "not signed"
+	}
+
+	for _, a := range info.Arguments {
+		if clientArguments[a.Name] {
+			continue
+		}
+		w.Args = append(w.Args, argument{
+			Name:        a.Name,
+			Field:       exportedName(strings.Split(a.Name, "_")...),
+			Required:    a.Optional == 0,
+			Description: commentLines(a.Description),
+			Kind:        argumentKind(a.Name),
+		})
+	}
+	for _, e := range info.Errors {
+		w.Errors = append(w.Errors, fmt.Sprintf("%d: %s", e.Code, e.Message))
+	}
+	return w
+}
+
+// Join words capitalizing each of them, like PhotoId for photo and id
+func exportedName(words ...string) string {
+	ret := ""
+	for _, w := range words {
+		if w != "" {
+			ret += strings.ToUpper(w[:1]) + w[1:]
+		}
+	}
+	return ret
+}
+
+var (
+	htmlTag    = regexp.MustCompile(`<[^>]*>`)
+	whitespace = regexp.MustCompile(`\s+`)
+)
+
+// Turn an HTML description into lines of comment text
+func commentLines(description string) []string {
+	text := htmlTag.ReplaceAllString(description, "")
+	words := strings.Fields(whitespace.ReplaceAllString(text, " "))
+
+	var lines []string
+	line := ""
+	for _, w := range words {
+		if line != "" && len(line)+len(w) >= 76 {
+			lines = append(lines, line)
+			line = ""
+		}
+		if line != "" {
+			line += " "
+		}
+		line += w
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+var sourceTemplate = template.Must(template.New("source").Parse(`// Code generated by flickr-gen from flickr.reflection.getMethodInfo responses. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"context"
+	{{- if .NeedsFmt}}
+	"fmt"
+	{{- end}}
+	"net/url"
+	{{- if .NeedsStrconv}}
+	"strconv"
+	{{- end}}
+	{{- if .NeedsStrings}}
+	"strings"
+	{{- end}}
+
+	"gopkg.in/masci/flickr.v2"
+)
+{{range $w := .Wrappers}}
+// Arguments of {{$w.Method}}
+type {{$w.Func}}Args struct {
+{{- range $w.Args}}
+	{{- range .Description}}
+	// {{.}}
+	{{- end}}
+	{{- if .Required}}
+	// Required.
+	{{- end}}
+	{{.Field}} {{.GoType}}
+{{- end}}
+}
+
+{{range $w.Description -}}
+// {{.}}
+{{end -}}
+// {{if eq $w.Perms "none"}}No permissions required{{else}}Requires {{$w.Perms}} permissions{{end}}, requests are {{$w.SignDoc}}.
+{{- if $w.Errors}}
+// Errors:
+{{- range $w.Errors}}
+//   {{.}}
+{{- end}}
+{{- end}}
+func {{$w.Func}}(ctx context.Context, client *flickr.FlickrClient, args *{{$w.Func}}Args, out flickr.FlickrResponse) error {
+	params := url.Values{}
+{{- range $w.Args}}
+{{- if and (eq .Kind "bool") .Required}}
+	params.Set("{{.Name}}", "0")
+	if args.{{.Field}} {
+		params.Set("{{.Name}}", "1")
+	}
+{{- else if eq .Kind "bool"}}
+	if args.{{.Field}} != nil {
+		params.Set("{{.Name}}", "0")
+		if *args.{{.Field}} {
+			params.Set("{{.Name}}", "1")
+		}
+	}
+{{- else if .Required}}
+	if {{.Unset}} {
+		return fmt.Errorf("{{$w.Method}}: argument {{.Name}} is required")
+	}
+	params.Set("{{.Name}}", {{.Value}})
+{{- else}}
+	if {{.Set}} {
+		params.Set("{{.Name}}", {{.Value}})
+	}
+{{- end}}
+{{- end}}
+
+	opts := flickr.CallOptions{Sign: flickr.{{$w.Sign}}, HTTPVerb: "{{$w.HTTPVerb}}"}
+	return client.CallWithOptions(ctx, "{{$w.Method}}", params, opts, out)
+}
+{{end}}`))
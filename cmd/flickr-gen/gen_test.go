@@ -0,0 +1,163 @@
+package main
+
+import (
+	"context"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"gopkg.in/masci/flickr.v2"
+	"gopkg.in/masci/flickr.v2/cmd/flickr-gen/example"
+)
+
+func TestLoadMethods(t *testing.T) {
+	methods, err := loadMethods("reflection/methods", "flickr.photos")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, len(methods), 6)
+	flickr.Expect(t, methods[0].Method.Name, "flickr.photos.getContext")
+
+	exif := methods[1]
+	flickr.Expect(t, exif.Method.Name, "flickr.photos.getExif")
+	flickr.Expect(t, len(exif.Arguments), 3)
+	flickr.Expect(t, exif.Arguments[1].Name, "photo_id")
+	flickr.Expect(t, exif.Arguments[2].Optional, 1)
+	flickr.Expect(t, len(exif.Errors), 3)
+
+	methods, err = loadMethods("reflection/methods", "flickr.people")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, len(methods), 1)
+}
+
+func TestLoadMethodsJSON(t *testing.T) {
+	methods, err := loadMethods("reflection/methods", "flickr.photosets")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, len(methods), 1)
+
+	m := methods[0]
+	flickr.Expect(t, m.Method.Name, "flickr.photosets.removePhotos")
+	flickr.Expect(t, m.Method.NeedsLogin, 1)
+	flickr.Expect(t, m.Method.RequiredPerms, 2)
+	flickr.Expect(t, m.Method.Description, "Remove multiple photos from a photoset.")
+	flickr.Expect(t, len(m.Arguments), 3)
+	flickr.Expect(t, m.Arguments[2].Name, "photo_ids")
+	flickr.Expect(t, m.Arguments[2].Optional, 0)
+	flickr.Expect(t, m.Arguments[2].Description, "Comma-delimited list of photo ids to remove from the photoset.")
+	flickr.Expect(t, len(m.Errors), 3)
+	flickr.Expect(t, m.Errors[1].Code, 96)
+	flickr.Expect(t, m.Errors[1].Message, "Invalid signature")
+
+	w := newWrapper("flickr.photosets", m)
+	flickr.Expect(t, w.Func, "RemovePhotos")
+	flickr.Expect(t, w.Sign, "SignOAuth")
+	flickr.Expect(t, w.HTTPVerb, "POST")
+}
+
+func TestNewWrapper(t *testing.T) {
+	methods, err := loadMethods("reflection/methods", "flickr.photos")
+	flickr.Expect(t, err, nil)
+
+	w := newWrapper("flickr.photos", methods[1])
+	flickr.Expect(t, w.Func, "GetExif")
+	flickr.Expect(t, w.Sign, "SignNone")
+	flickr.Expect(t, w.HTTPVerb, "GET")
+	flickr.Expect(t, w.Perms, "none")
+	// api_key is set by the client
+	flickr.Expect(t, len(w.Args), 2)
+	flickr.Expect(t, w.Args[0].Field, "PhotoId")
+	flickr.Expect(t, w.Args[0].Required, true)
+	flickr.Expect(t, w.Args[1].Required, false)
+
+	w = newWrapper("flickr.photos", methods[3])
+	flickr.Expect(t, w.Func, "LicensesGetInfo")
+
+	w = newWrapper("flickr.photos", methods[4])
+	flickr.Expect(t, w.Func, "SetMeta")
+	flickr.Expect(t, w.Sign, "SignOAuth")
+	flickr.Expect(t, w.HTTPVerb, "POST")
+	flickr.Expect(t, w.Perms, "write")
+}
+
+func TestArgumentKind(t *testing.T) {
+	flickr.Expect(t, argumentKind("photo_id"), "string")
+	flickr.Expect(t, argumentKind("per_page"), "int")
+	flickr.Expect(t, argumentKind("is_public"), "bool")
+	flickr.Expect(t, argumentKind("hidden"), "bool")
+	flickr.Expect(t, argumentKind("photo_ids"), "list")
+
+	a := argument{Field: "Hidden", Kind: "bool"}
+	flickr.Expect(t, a.GoType(), "*bool")
+	a.Required = true
+	flickr.Expect(t, a.GoType(), "bool")
+}
+
+func TestGenerateListArguments(t *testing.T) {
+	methods, err := loadMethods("reflection/methods", "flickr.photosets")
+	flickr.Expect(t, err, nil)
+	src, err := generate("photosets", "flickr.photosets", methods)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, strings.Contains(string(src), "PhotoIds []string"), true)
+	flickr.Expect(t, strings.Contains(string(src), "if len(args.PhotoIds) == 0 {"), true)
+	flickr.Expect(t, strings.Contains(string(src), `params.Set("photo_ids", strings.Join(args.PhotoIds, ","))`), true)
+	flickr.Expect(t, strings.Contains(string(src), `"strconv"`), false)
+}
+
+// Typed arguments of the generated example are sent as Flickr expects them
+func TestGeneratedArguments(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<rsp stat="ok"></rsp>`, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	hidden := false
+	args := &example.SetSafetyLevelArgs{PhotoId: "123", SafetyLevel: 2, Hidden: &hidden}
+	err := example.SetSafetyLevel(context.Background(), fclient, args, &flickr.BasicResponse{})
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("safety_level"), "2")
+	flickr.Expect(t, fclient.Args.Get("hidden"), "0")
+
+	err = example.SetSafetyLevel(context.Background(), fclient, &example.SetSafetyLevelArgs{PhotoId: "123"}, &flickr.BasicResponse{})
+	flickr.Expect(t, err, nil)
+	_, ok := fclient.Args["safety_level"]
+	flickr.Expect(t, ok, false)
+	_, ok = fclient.Args["hidden"]
+	flickr.Expect(t, ok, false)
+
+	err = example.GetFavorites(context.Background(), fclient, &example.GetFavoritesArgs{PhotoId: "123", PerPage: 50}, &flickr.BasicResponse{})
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("per_page"), "50")
+}
+
+func TestCommentLines(t *testing.T) {
+	lines := commentLines(`Your API application key. <a href="/services/api/misc.api_keys.html">See here</a> for more details.`)
+	flickr.Expect(t, len(lines), 1)
+	flickr.Expect(t, lines[0], "Your API application key. See here for more details.")
+
+	lines = commentLines(strings.Repeat("word ", 40))
+	flickr.Expect(t, len(lines), 3)
+	for _, l := range lines {
+		flickr.Expect(t, len(l) < 80, true)
+	}
+}
+
+// The example package must match the generator output, run go generate in
+// the example directory to update it
+func TestGenerateExample(t *testing.T) {
+	methods, err := loadMethods("reflection/methods", "flickr.photos")
+	flickr.Expect(t, err, nil)
+	src, err := generate("example", "flickr.photos", methods)
+	flickr.Expect(t, err, nil)
+
+	expected, err := ioutil.ReadFile("example/photos.go")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, string(src), string(expected))
+}
+
+func TestGenerateWithoutRequiredArguments(t *testing.T) {
+	methods, err := loadMethods("reflection/methods", "flickr.people")
+	flickr.Expect(t, err, nil)
+	src, err := generate("people", "flickr.people", methods)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, strings.Contains(string(src), `"fmt"`), false)
+	flickr.Expect(t, strings.Contains(string(src), "func GetLimits("), true)
+	flickr.Expect(t, strings.Contains(string(src), "Requires read permissions"), true)
+}
@@ -0,0 +1,94 @@
+// Command flickr-gen generates Go wrappers for Flickr API methods from the
+// output of flickr.reflection.getMethodInfo, stored in XML or JSON files so
+// that no network access is needed. Each wrapper gets a typed argument struct,
+// checks required arguments and signs requests as the method requires.
+//
+// Generate the wrappers of the flickr.photos methods described in reflection/methods:
+//
+//	flickr-gen -in reflection/methods -prefix flickr.photos -pkg photos -out photos_gen.go
+//
+// Report which methods listed in a flickr.reflection.getMethods response are
+// not wrapped by the library sources found in a directory:
+//
+//	flickr-gen -check -methods reflection/getMethods.xml -src ../..
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+)
+
+func main() {
+	in := flag.String("in", "reflection/methods", "directory of flickr.reflection.getMethodInfo responses")
+	prefix := flag.String("prefix", "flickr.photos", "generate wrappers for methods under this namespace")
+	pkg := flag.String("pkg", "", "package name of the generated code, defaults to the last part of prefix")
+	out := flag.String("out", "", "output file, defaults to stdout")
+	check := flag.Bool("check", false, "report methods not wrapped by the library instead of generating code")
+	methods := flag.String("methods", "reflection/getMethods.xml", "flickr.reflection.getMethods response, with -check")
+	src := flag.String("src", ".", "root of the library sources, with -check")
+	flag.Parse()
+
+	var err error
+	if *check {
+		err = runCheck(*methods, *src)
+	} else {
+		err = runGenerate(*in, *prefix, *pkg, *out)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "flickr-gen:", err)
+		os.Exit(1)
+	}
+}
+
+func runGenerate(in, prefix, pkg, out string) error {
+	if pkg == "" {
+		pkg = lastPart(prefix)
+	}
+	methods, err := loadMethods(in, prefix)
+	if err != nil {
+		return err
+	}
+	if len(methods) == 0 {
+		return fmt.Errorf("no method under %s in %s", prefix, in)
+	}
+
+	src, err := generate(pkg, prefix, methods)
+	if err != nil {
+		return err
+	}
+	if out == "" {
+		_, err = os.Stdout.Write(src)
+		return err
+	}
+	return ioutil.WriteFile(out, src, 0644)
+}
+
+func runCheck(methodsPath, src string) error {
+	methods, err := loadMethodList(methodsPath)
+	if err != nil {
+		return err
+	}
+	wrapped, err := wrappedMethods(src)
+	if err != nil {
+		return err
+	}
+
+	done, missing := checkMethods(methods, wrapped)
+	for _, m := range missing {
+		fmt.Println(m)
+	}
+	fmt.Printf("%d of %d methods wrapped, %d missing\n", len(done), len(methods), len(missing))
+	return nil
+}
+
+// Return the last dot separated part of a method namespace
+func lastPart(name string) string {
+	for i := len(name) - 1; i >= 0; i-- {
+		if name[i] == '.' {
+			return name[i+1:]
+		}
+	}
+	return name
+}
@@ -0,0 +1,37 @@
+package check
+
+import (
+	"context"
+
+	"gopkg.in/masci/flickr.v2"
+)
+
+// names only, not calls
+var writeMethods = map[string]bool{
+	"flickr.blogs.postPhoto":  true,
+	"flickr.photos.notes.add": true,
+}
+
+func echo(client *flickr.FlickrClient) error {
+	client.Init()
+	client.Args.Set("method", "flickr.test.echo")
+	client.Args.Set("text", "flickr.test.text")
+	client.ApiSign()
+	return flickr.DoGet(client, &flickr.BasicResponse{})
+}
+
+func null(client *flickr.FlickrClient) error {
+	return client.Call(context.Background(), "flickr.test.null", nil, &flickr.BasicResponse{})
+}
+
+func login(client *flickr.FlickrClient) error {
+	return call(client, "flickr.test.login", "flickr.test.id")
+}
+
+// Call method with the given id
+func call(client *flickr.FlickrClient, method, id string) error {
+	client.Init()
+	client.Args.Set("method", method)
+	client.Args.Set("id", id)
+	return flickr.DoGet(client, &flickr.BasicResponse{})
+}
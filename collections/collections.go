@@ -0,0 +1,130 @@
+// Package implementing methods: flickr.collections.*
+package collections
+
+import (
+	"errors"
+
+	"gopkg.in/masci/flickr.v2"
+	"gopkg.in/masci/flickr.v2/photosets"
+)
+
+// A photoset in a collection tree
+type Set struct {
+	Id          string `xml:"id,attr"`
+	Title       string `xml:"title,attr"`
+	Description string `xml:"description,attr"`
+}
+
+// A node of a collection tree, holding either sets or other collections
+type Collection struct {
+	Id          string       `xml:"id,attr"`
+	Title       string       `xml:"title,attr"`
+	Description string       `xml:"description,attr"`
+	IconLarge   string       `xml:"iconlarge,attr"`
+	IconSmall   string       `xml:"iconsmall,attr"`
+	Sets        []Set        `xml:"set"`
+	Collections []Collection `xml:"collection"`
+}
+
+type TreeResponse struct {
+	flickr.BasicResponse
+	Tree struct {
+		Collections []Collection `xml:"collection"`
+	} `xml:"collections"`
+}
+
+// A photo used for the icon of a collection
+type IconPhoto struct {
+	Id     string `xml:"id,attr"`
+	Owner  string `xml:"owner,attr"`
+	Secret string `xml:"secret,attr"`
+	Server string `xml:"server,attr"`
+	Farm   string `xml:"farm,attr"`
+	Title  string `xml:"title,attr"`
+}
+
+type CollectionInfo struct {
+	Id         string `xml:"id,attr"`
+	ChildCount int    `xml:"child_count,attr"`
+	// Unix timestamp
+	DateCreate  string      `xml:"datecreate,attr"`
+	IconLarge   string      `xml:"iconlarge,attr"`
+	IconSmall   string      `xml:"iconsmall,attr"`
+	Server      string      `xml:"server,attr"`
+	Secret      string      `xml:"secret,attr"`
+	Title       string      `xml:"title"`
+	Description string      `xml:"description"`
+	IconPhotos  []IconPhoto `xml:"iconphotos>photo"`
+}
+
+type InfoResponse struct {
+	flickr.BasicResponse
+	Collection CollectionInfo `xml:"collection"`
+}
+
+// Return the tree of collections of the user with userId, starting from the
+// collection with collectionId. Both are optional: userId defaults to the
+// calling user and collectionId to the root of the tree.
+// This method requires authentication to retrieve the tree of the calling user.
+func GetTree(client *flickr.FlickrClient, collectionId, userId string) (*TreeResponse, error) {
+	return NewService(client).GetTree(collectionId, userId)
+}
+
+// Return information about a collection
+// This method requires authentication with 'read' permission.
+func GetInfo(client *flickr.FlickrClient, collectionId string) (*InfoResponse, error) {
+	return NewService(client).GetInfo(collectionId)
+}
+
+// Return the photosets of the user with userId found in collections, indexed
+// by id. Photosets are retrieved with photosets.GetList, sets missing from the
+// list are retrieved one by one with photosets.GetInfo.
+func ResolveSets(client *flickr.FlickrClient, authenticate bool, userId string, collections []Collection) (map[string]photosets.Photoset, error) {
+	return NewService(client).ResolveSets(authenticate, userId, collections)
+}
+
+// Function called by Walk for each collection, parents holds the collections
+// containing c, the outermost first.
+type WalkFunc func(c *Collection, parents []*Collection) error
+
+// Returned by a WalkFunc to skip the collections nested in the current one
+var SkipChildren = errors.New("skip children")
+
+// Visit the collections depth first, each collection before the ones it
+// contains. The walk stops at the first error returned by fn other than
+// SkipChildren, and returns it.
+func Walk(collections []Collection, fn WalkFunc) error {
+	return walk(collections, nil, fn)
+}
+
+func walk(collections []Collection, parents []*Collection, fn WalkFunc) error {
+	for i := range collections {
+		c := &collections[i]
+		err := fn(c, parents)
+		if err == SkipChildren {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		// copy parents so that fn can keep the slice it is given
+		nested := make([]*Collection, len(parents)+1)
+		copy(nested, parents)
+		nested[len(parents)] = c
+		if err := walk(c.Collections, nested, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Return the sets found in collections and in the collections they contain,
+// in the order Walk visits them
+func Sets(collections []Collection) []Set {
+	var sets []Set
+	Walk(collections, func(c *Collection, parents []*Collection) error {
+		sets = append(sets, c.Sets...)
+		return nil
+	})
+	return sets
+}
@@ -0,0 +1,169 @@
+package collections
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"gopkg.in/masci/flickr.v2"
+)
+
+var treeBody = `<?xml version="1.0" encoding="utf-8" ?>
+	<rsp stat="ok">
+		<collections>
+			<collection id="12-72157594586579649" title="All My Photos" description="a collection" iconlarge="http://www.flickr.com/images/collection_default_l.gif" iconsmall="http://www.flickr.com/images/collection_default_s.gif">
+				<collection id="12-72157594586579650" title="Sports" description="">
+					<set id="72157594171298291" title="kitesurfing" description="" />
+					<set id="72157594171298292" title="skiing" description="" />
+				</collection>
+				<collection id="12-72157594586579651" title="Travels" description="">
+					<collection id="12-72157594586579652" title="Italy" description="">
+						<set id="72157594171298293" title="Rome" description="Eternal city" />
+					</collection>
+				</collection>
+			</collection>
+			<collection id="12-72157594586579653" title="Work" description="">
+				<set id="72157594171298294" title="Office" description="" />
+			</collection>
+		</collections>
+	</rsp>`
+
+func getTree(t *testing.T) []Collection {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, treeBody, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetTree(fclient, "", "")
+	flickr.Expect(t, err, nil)
+	return resp.Tree.Collections
+}
+
+func TestGetTree(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, treeBody, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetTree(fclient, "12-72157594586579649", "12037949754@N01")
+	flickr.Expect(t, err, nil)
+	tree := resp.Tree.Collections
+	flickr.Expect(t, len(tree), 2)
+	flickr.Expect(t, tree[0].Title, "All My Photos")
+	flickr.Expect(t, tree[0].IconSmall, "http://www.flickr.com/images/collection_default_s.gif")
+	flickr.Expect(t, len(tree[0].Sets), 0)
+	flickr.Expect(t, len(tree[0].Collections), 2)
+	flickr.Expect(t, tree[0].Collections[0].Sets[1].Title, "skiing")
+	flickr.Expect(t, tree[0].Collections[1].Collections[0].Sets[0].Description, "Eternal city")
+	flickr.Expect(t, tree[1].Sets[0].Id, "72157594171298294")
+	flickr.AssertParamsInBody(t, fclient, []string{"collection_id", "user_id", "oauth_signature"})
+}
+
+func TestGetInfo(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	body := `<rsp stat="ok">
+		<collection id="12-72157594586579649" child_count="6" datecreate="1173812218" iconlarge="http://farm1.static.flickr.com/187/cols/72157594586579649_l.jpg" iconsmall="http://farm1.static.flickr.com/187/cols/72157594586579649_s.jpg" server="187" secret="36">
+			<title>All My Photos</title>
+			<description>Photos!</description>
+			<iconphotos>
+				<photo id="14" owner="12037949754@N01" secret="a9a8a7" server="1" farm="1" title="in the kitchen" />
+				<photo id="15" owner="12037949754@N01" secret="b9b8b7" server="1" farm="1" title="cat" />
+			</iconphotos>
+		</collection>
+	</rsp>`
+	server, client := flickr.FlickrMock(200, body, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetInfo(fclient, "12-72157594586579649")
+	flickr.Expect(t, err, nil)
+	c := resp.Collection
+	flickr.Expect(t, c.Id, "12-72157594586579649")
+	flickr.Expect(t, c.ChildCount, 6)
+	flickr.Expect(t, c.DateCreate, "1173812218")
+	flickr.Expect(t, c.Title, "All My Photos")
+	flickr.Expect(t, c.Description, "Photos!")
+	flickr.Expect(t, len(c.IconPhotos), 2)
+	flickr.Expect(t, c.IconPhotos[1].Title, "cat")
+	flickr.AssertParamsInBody(t, fclient, []string{"collection_id"})
+}
+
+func TestWalk(t *testing.T) {
+	tree := getTree(t)
+
+	var visited []string
+	depths := map[string]int{}
+	err := Walk(tree, func(c *Collection, parents []*Collection) error {
+		visited = append(visited, c.Title)
+		depths[c.Title] = len(parents)
+		if c.Title == "Italy" {
+			flickr.Expect(t, parents[0].Title, "All My Photos")
+			flickr.Expect(t, parents[1].Title, "Travels")
+		}
+		return nil
+	})
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, strings.Join(visited, ","), "All My Photos,Sports,Travels,Italy,Work")
+	flickr.Expect(t, depths["Italy"], 2)
+	flickr.Expect(t, depths["Work"], 0)
+
+	visited = nil
+	err = Walk(tree, func(c *Collection, parents []*Collection) error {
+		visited = append(visited, c.Title)
+		if c.Title == "Travels" {
+			return SkipChildren
+		}
+		return nil
+	})
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, strings.Join(visited, ","), "All My Photos,Sports,Travels,Work")
+
+	stop := errors.New("stop")
+	visited = nil
+	err = Walk(tree, func(c *Collection, parents []*Collection) error {
+		visited = append(visited, c.Title)
+		if c.Title == "Sports" {
+			return stop
+		}
+		return nil
+	})
+	flickr.Expect(t, err, stop)
+	flickr.Expect(t, strings.Join(visited, ","), "All My Photos,Sports")
+}
+
+func TestSets(t *testing.T) {
+	sets := Sets(getTree(t))
+	flickr.Expect(t, len(sets), 4)
+	flickr.Expect(t, sets[0].Title, "kitesurfing")
+	flickr.Expect(t, sets[2].Title, "Rome")
+	flickr.Expect(t, sets[3].Title, "Office")
+
+	flickr.Expect(t, len(Sets(nil)), 0)
+}
+
+func TestResolveSets(t *testing.T) {
+	tree := getTree(t)
+
+	fclient := flickr.GetTestClient()
+	body := `<rsp stat="ok">
+		<photosets page="1" pages="1" perpage="500" total="5">
+			<photoset id="72157594171298291" primary="1" secret="a" server="1" farm="1" photos="12"><title>kitesurfing</title><description></description></photoset>
+			<photoset id="72157594171298292" primary="2" secret="b" server="1" farm="1" photos="7"><title>skiing</title><description></description></photoset>
+			<photoset id="72157594171298293" primary="3" secret="c" server="1" farm="1" photos="40"><title>Rome</title><description>Eternal city</description></photoset>
+			<photoset id="72157594171298294" primary="4" secret="d" server="1" farm="1" photos="3"><title>Office</title><description></description></photoset>
+			<photoset id="72157594171298295" primary="5" secret="e" server="1" farm="1" photos="1"><title>Not in a collection</title><description></description></photoset>
+		</photosets>
+	</rsp>`
+	server, client := flickr.FlickrMock(200, body, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resolved, err := ResolveSets(fclient, true, "12037949754@N01", tree)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, len(resolved), 4)
+	flickr.Expect(t, resolved["72157594171298293"].Photos, 40)
+	flickr.Expect(t, resolved["72157594171298293"].Title, "Rome")
+	_, ok := resolved["72157594171298295"]
+	flickr.Expect(t, ok, false)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photosets.getList")
+}
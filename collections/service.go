@@ -0,0 +1,95 @@
+package collections
+
+import (
+	"gopkg.in/masci/flickr.v2"
+	"gopkg.in/masci/flickr.v2/photosets"
+)
+
+// Methods of the flickr.collections namespace
+type Service interface {
+	// Return the tree of collections of a user
+	GetTree(collectionId, userId string) (*TreeResponse, error)
+	// Return information about a collection
+	GetInfo(collectionId string) (*InfoResponse, error)
+	// Return the photosets of a user found in collections, indexed by id
+	ResolveSets(authenticate bool, userId string, collections []Collection) (map[string]photosets.Photoset, error)
+}
+
+// Return a Service reading collections with client
+func NewService(client *flickr.FlickrClient) Service {
+	return &service{client}
+}
+
+type service struct {
+	client *flickr.FlickrClient
+}
+
+func (s *service) GetTree(collectionId, userId string) (*TreeResponse, error) {
+	client := s.client
+	client.Init()
+	client.Args.Set("method", "flickr.collections.getTree")
+	if collectionId != "" {
+		client.Args.Set("collection_id", collectionId)
+	}
+	if userId != "" {
+		client.Args.Set("user_id", userId)
+	}
+
+	client.OAuthSign()
+
+	response := &TreeResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+func (s *service) GetInfo(collectionId string) (*InfoResponse, error) {
+	client := s.client
+	client.Init()
+	client.Args.Set("method", "flickr.collections.getInfo")
+	client.Args.Set("collection_id", collectionId)
+
+	client.OAuthSign()
+
+	response := &InfoResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+func (s *service) ResolveSets(authenticate bool, userId string, collections []Collection) (map[string]photosets.Photoset, error) {
+	sets := Sets(collections)
+	resolved := make(map[string]photosets.Photoset, len(sets))
+	if len(sets) == 0 {
+		return resolved, nil
+	}
+
+	wanted := make(map[string]bool, len(sets))
+	for _, set := range sets {
+		wanted[set.Id] = true
+	}
+
+	ps := photosets.NewService(s.client)
+	for page, pages := 1, 1; page <= pages && len(resolved) < len(wanted); page++ {
+		list, err := ps.GetList(authenticate, userId, page)
+		if err != nil {
+			return nil, err
+		}
+		pages = list.Photosets.Pages
+		for _, p := range list.Photosets.Items {
+			if wanted[p.Id] {
+				resolved[p.Id] = p
+			}
+		}
+	}
+
+	for _, set := range sets {
+		if _, ok := resolved[set.Id]; ok {
+			continue
+		}
+		info, err := ps.GetInfo(authenticate, set.Id, userId)
+		if err != nil {
+			return nil, err
+		}
+		resolved[set.Id] = info.Set
+	}
+	return resolved, nil
+}
@@ -0,0 +1,105 @@
+// Package implementing methods: flickr.photos.comments.* and
+// flickr.photosets.comments.*
+package comments
+
+import (
+	"gopkg.in/masci/flickr.v2"
+	"gopkg.in/masci/flickr.v2/people"
+)
+
+type Comment struct {
+	Id string `xml:"id,attr"`
+	// Flickr ID of the author
+	Author          string `xml:"author,attr"`
+	AuthorName      string `xml:"authorname,attr"`
+	AuthorIsDeleted bool   `xml:"author_is_deleted,attr"`
+	RealName        string `xml:"realname,attr"`
+	PathAlias       string `xml:"path_alias,attr"`
+	IconServer      string `xml:"iconserver,attr"`
+	IconFarm        string `xml:"iconfarm,attr"`
+	// When the comment was posted, unix timestamp
+	DateCreate string `xml:"datecreate,attr"`
+	Permalink  string `xml:"permalink,attr"`
+	// The comment, which may contain HTML
+	Text string `xml:",chardata"`
+}
+
+type CommentResponse struct {
+	flickr.BasicResponse
+	// The new comment, only its id is returned by Flickr
+	Comment Comment `xml:"comment"`
+}
+
+type CommentListResponse struct {
+	flickr.BasicResponse
+	Comments struct {
+		// Set for the comments of a photo
+		PhotoId string `xml:"photo_id,attr"`
+		// Set for the comments of a photoset
+		PhotosetId string    `xml:"photoset_id,attr"`
+		Comments   []Comment `xml:"comment"`
+	} `xml:"comments"`
+}
+
+type GetRecentForContactsOptionalArgs struct {
+	DateLastComment string   // optional, set to "" to ignore. unix timestamp, defaults to an hour ago
+	ContactsFilter  []string // optional, set to nil to ignore. Flickr IDs of the contacts to include
+	Extras          string   // optional, set to "" to ignore. comma separated string.
+	PerPage         int      // 0 to ignore
+	Page            int      // 0 to ignore
+}
+
+// Add a comment to a photo
+// This method requires authentication with 'write' permission.
+func AddComment(client *flickr.FlickrClient, photoId, text string) (*CommentResponse, error) {
+	return NewService(client).AddComment(photoId, text)
+}
+
+// Edit the text of a comment on a photo
+// This method requires authentication with 'write' permission.
+func EditComment(client *flickr.FlickrClient, commentId, text string) (*flickr.BasicResponse, error) {
+	return NewService(client).EditComment(commentId, text)
+}
+
+// Delete a comment on a photo
+// This method requires authentication with 'write' permission.
+func DeleteComment(client *flickr.FlickrClient, commentId string) (*flickr.BasicResponse, error) {
+	return NewService(client).DeleteComment(commentId)
+}
+
+// Return the comments of a photo, posted between minCommentDate and
+// maxCommentDate. Both are unix timestamps, set them to "" to ignore.
+// This method requires authentication to list the comments of private photos.
+func GetList(client *flickr.FlickrClient, authenticate bool, photoId, minCommentDate, maxCommentDate string) (*CommentListResponse, error) {
+	return NewService(client).GetList(authenticate, photoId, minCommentDate, maxCommentDate)
+}
+
+// Return the photos of the contacts of the calling user that have been
+// recently commented
+// This method requires authentication with 'read' permission.
+func GetRecentForContacts(client *flickr.FlickrClient, opts GetRecentForContactsOptionalArgs) (*people.PhotoListResponse, error) {
+	return NewService(client).GetRecentForContacts(opts)
+}
+
+// Add a comment to a photoset
+// This method requires authentication with 'write' permission.
+func AddPhotosetComment(client *flickr.FlickrClient, photosetId, text string) (*CommentResponse, error) {
+	return NewService(client).AddPhotosetComment(photosetId, text)
+}
+
+// Edit the text of a comment on a photoset
+// This method requires authentication with 'write' permission.
+func EditPhotosetComment(client *flickr.FlickrClient, commentId, text string) (*flickr.BasicResponse, error) {
+	return NewService(client).EditPhotosetComment(commentId, text)
+}
+
+// Delete a comment on a photoset
+// This method requires authentication with 'write' permission.
+func DeletePhotosetComment(client *flickr.FlickrClient, commentId string) (*flickr.BasicResponse, error) {
+	return NewService(client).DeletePhotosetComment(commentId)
+}
+
+// Return the comments of a photoset
+func GetPhotosetList(client *flickr.FlickrClient, photosetId string) (*CommentListResponse, error) {
+	return NewService(client).GetPhotosetList(photosetId)
+}
@@ -0,0 +1,161 @@
+package comments
+
+import (
+	"testing"
+
+	"gopkg.in/masci/flickr.v2"
+	flickErr "gopkg.in/masci/flickr.v2/error"
+)
+
+var (
+	listBody = `<?xml version="1.0" encoding="utf-8" ?>
+		<rsp stat="ok">
+			<comments photo_id="109722179">
+				<comment id="6065-109722179-72057594077818641" author="35468159852@N01" authorname="Rev Dan Catt" realname="Daniel Catt" iconserver="1" iconfarm="1" path_alias="revdancatt" datecreate="1141841470" permalink="https://www.flickr.com/photos/straup/109722179/#comment72057594077818641">Umm, I'm not sure, can I get back to you on that one?</comment>
+				<comment id="6065-109722179-72057594077818642" author="12037949754@N01" authorname="Bees" author_is_deleted="1" datecreate="1141841480" permalink="https://www.flickr.com/photos/straup/109722179/#comment72057594077818642">&lt;b&gt;Sure&lt;/b&gt;</comment>
+			</comments>
+		</rsp>`
+
+	photosetListBody = `<?xml version="1.0" encoding="utf-8" ?>
+		<rsp stat="ok">
+			<comments photoset_id="72157594162487419">
+				<comment id="6065-72157594162487419-72057594077818643" author="35468159852@N01" authorname="Rev Dan Catt" datecreate="1141841490" permalink="https://www.flickr.com/photos/straup/sets/72157594162487419/comments#comment72057594077818643">Nice set</comment>
+			</comments>
+		</rsp>`
+
+	recentBody = `<?xml version="1.0" encoding="utf-8" ?>
+		<rsp stat="ok">
+			<photos page="1" pages="1" perpage="100" total="1">
+				<photo id="2636" owner="47058503995@N01" secret="a123456" server="2" farm="1" title="test_04" ispublic="1" isfriend="0" isfamily="0" />
+			</photos>
+		</rsp>`
+)
+
+func TestAddComment(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<rsp stat="ok"><comment id="97777-72057594037941949-72057594037942602" /></rsp>`, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := AddComment(fclient, "72057594037941949", "Nice photo!")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, resp.Comment.Id, "97777-72057594037941949-72057594037942602")
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photos.comments.addComment")
+	flickr.Expect(t, fclient.Args.Get("comment_text"), "Nice photo!")
+	flickr.AssertParamsInBody(t, fclient, []string{"photo_id", "comment_text", "oauth_signature"})
+
+	server, client = flickr.FlickrMock(200, `<rsp stat="fail"><err code="1" msg="Photo not found" /></rsp>`, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+	resp, err = AddComment(fclient, "72057594037941949", "Nice photo!")
+	_, ok := err.(*flickErr.Error)
+	flickr.Expect(t, ok, true)
+	flickr.Expect(t, resp.ErrorCode(), 1)
+}
+
+func TestEditDeleteComment(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<rsp stat="ok"></rsp>`, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	_, err := EditComment(fclient, "6065-109722179-72057594077818641", "Edited")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photos.comments.editComment")
+	flickr.AssertParamsInBody(t, fclient, []string{"comment_id", "comment_text"})
+
+	fclient.HTTPClient = client
+	_, err = DeleteComment(fclient, "6065-109722179-72057594077818641")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photos.comments.deleteComment")
+	flickr.Expect(t, fclient.Args.Get("comment_text"), "")
+	flickr.AssertParamsInBody(t, fclient, []string{"comment_id"})
+}
+
+func TestGetList(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, listBody, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetList(fclient, false, "109722179", "1141841400", "1141841500")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, resp.Comments.PhotoId, "109722179")
+	flickr.Expect(t, len(resp.Comments.Comments), 2)
+
+	c := resp.Comments.Comments[0]
+	flickr.Expect(t, c.Id, "6065-109722179-72057594077818641")
+	flickr.Expect(t, c.Author, "35468159852@N01")
+	flickr.Expect(t, c.AuthorName, "Rev Dan Catt")
+	flickr.Expect(t, c.RealName, "Daniel Catt")
+	flickr.Expect(t, c.PathAlias, "revdancatt")
+	flickr.Expect(t, c.DateCreate, "1141841470")
+	flickr.Expect(t, c.Permalink, "https://www.flickr.com/photos/straup/109722179/#comment72057594077818641")
+	flickr.Expect(t, c.Text, "Umm, I'm not sure, can I get back to you on that one?")
+	flickr.Expect(t, resp.Comments.Comments[1].AuthorIsDeleted, true)
+	flickr.Expect(t, resp.Comments.Comments[1].Text, "<b>Sure</b>")
+
+	flickr.AssertParamsInBody(t, fclient, []string{"photo_id", "min_comment_date", "max_comment_date", "api_sig"})
+}
+
+func TestGetListAuthenticated(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, listBody, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	_, err := GetList(fclient, true, "109722179", "", "")
+	flickr.Expect(t, err, nil)
+	flickr.AssertParamsInBody(t, fclient, []string{"photo_id", "oauth_signature"})
+	flickr.Expect(t, fclient.Args.Get("api_sig"), "")
+}
+
+func TestGetRecentForContacts(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, recentBody, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	opts := GetRecentForContactsOptionalArgs{
+		DateLastComment: "1141841400",
+		ContactsFilter:  []string{"35468159852@N01", "12037949754@N01"},
+		PerPage:         100,
+	}
+	resp, err := GetRecentForContacts(fclient, opts)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, len(resp.Photos.Photos), 1)
+	flickr.Expect(t, resp.Photos.Photos[0].Id, "2636")
+	flickr.Expect(t, fclient.Args.Get("contacts_filter"), "35468159852@N01,12037949754@N01")
+	flickr.AssertParamsInBody(t, fclient, []string{"date_lastcomment", "contacts_filter", "per_page", "oauth_signature"})
+}
+
+func TestPhotosetComments(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<rsp stat="ok"><comment id="6065-72157594162487419-72057594077818643" /></rsp>`, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := AddPhotosetComment(fclient, "72157594162487419", "Nice set")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, resp.Comment.Id, "6065-72157594162487419-72057594077818643")
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photosets.comments.addComment")
+	flickr.AssertParamsInBody(t, fclient, []string{"photoset_id", "comment_text"})
+
+	fclient.HTTPClient = client
+	_, err = EditPhotosetComment(fclient, "6065-72157594162487419-72057594077818643", "Very nice set")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photosets.comments.editComment")
+
+	_, err = DeletePhotosetComment(fclient, "6065-72157594162487419-72057594077818643")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photosets.comments.deleteComment")
+
+	server, client = flickr.FlickrMock(200, photosetListBody, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+	list, err := GetPhotosetList(fclient, "72157594162487419")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, list.Comments.PhotosetId, "72157594162487419")
+	flickr.Expect(t, len(list.Comments.Comments), 1)
+	flickr.Expect(t, list.Comments.Comments[0].Text, "Nice set")
+}
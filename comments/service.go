@@ -0,0 +1,174 @@
+package comments
+
+import (
+	"strconv"
+	"strings"
+
+	"gopkg.in/masci/flickr.v2"
+	"gopkg.in/masci/flickr.v2/people"
+)
+
+// Methods of the flickr.photos.comments and flickr.photosets.comments namespaces
+type Service interface {
+	// Add a comment to a photo
+	AddComment(photoId, text string) (*CommentResponse, error)
+	// Edit the text of a comment on a photo
+	EditComment(commentId, text string) (*flickr.BasicResponse, error)
+	// Delete a comment on a photo
+	DeleteComment(commentId string) (*flickr.BasicResponse, error)
+	// Return the comments of a photo
+	GetList(authenticate bool, photoId, minCommentDate, maxCommentDate string) (*CommentListResponse, error)
+	// Return the recently commented photos of the contacts of the calling user
+	GetRecentForContacts(opts GetRecentForContactsOptionalArgs) (*people.PhotoListResponse, error)
+	// Add a comment to a photoset
+	AddPhotosetComment(photosetId, text string) (*CommentResponse, error)
+	// Edit the text of a comment on a photoset
+	EditPhotosetComment(commentId, text string) (*flickr.BasicResponse, error)
+	// Delete a comment on a photoset
+	DeletePhotosetComment(commentId string) (*flickr.BasicResponse, error)
+	// Return the comments of a photoset
+	GetPhotosetList(photosetId string) (*CommentListResponse, error)
+}
+
+// Return a Service managing photo and photoset comments with client
+func NewService(client *flickr.FlickrClient) Service {
+	return &service{client}
+}
+
+type service struct {
+	client *flickr.FlickrClient
+}
+
+func (s *service) AddComment(photoId, text string) (*CommentResponse, error) {
+	return s.add("flickr.photos.comments.addComment", "photo_id", photoId, text)
+}
+
+func (s *service) EditComment(commentId, text string) (*flickr.BasicResponse, error) {
+	return s.edit("flickr.photos.comments.editComment", commentId, text)
+}
+
+func (s *service) DeleteComment(commentId string) (*flickr.BasicResponse, error) {
+	return s.delete("flickr.photos.comments.deleteComment", commentId)
+}
+
+func (s *service) GetList(authenticate bool, photoId, minCommentDate, maxCommentDate string) (*CommentListResponse, error) {
+	client := s.client
+	client.Init()
+	client.Args.Set("method", "flickr.photos.comments.getList")
+	client.Args.Set("photo_id", photoId)
+	if minCommentDate != "" {
+		client.Args.Set("min_comment_date", minCommentDate)
+	}
+	if maxCommentDate != "" {
+		client.Args.Set("max_comment_date", maxCommentDate)
+	}
+
+	if authenticate {
+		client.OAuthSign()
+	} else {
+		client.ApiSign()
+	}
+
+	response := &CommentListResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+func (s *service) GetRecentForContacts(opts GetRecentForContactsOptionalArgs) (*people.PhotoListResponse, error) {
+	client := s.client
+	client.Init()
+	client.Args.Set("method", "flickr.photos.comments.getRecentForContacts")
+	if opts.DateLastComment != "" {
+		client.Args.Set("date_lastcomment", opts.DateLastComment)
+	}
+	if len(opts.ContactsFilter) > 0 {
+		client.Args.Set("contacts_filter", strings.Join(opts.ContactsFilter, ","))
+	}
+	if opts.Extras != "" {
+		client.Args.Set("extras", opts.Extras)
+	}
+	if opts.PerPage != 0 {
+		client.Args.Set("per_page", strconv.Itoa(opts.PerPage))
+	}
+	if opts.Page != 0 {
+		client.Args.Set("page", strconv.Itoa(opts.Page))
+	}
+
+	client.OAuthSign()
+
+	response := &people.PhotoListResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+func (s *service) AddPhotosetComment(photosetId, text string) (*CommentResponse, error) {
+	return s.add("flickr.photosets.comments.addComment", "photoset_id", photosetId, text)
+}
+
+func (s *service) EditPhotosetComment(commentId, text string) (*flickr.BasicResponse, error) {
+	return s.edit("flickr.photosets.comments.editComment", commentId, text)
+}
+
+func (s *service) DeletePhotosetComment(commentId string) (*flickr.BasicResponse, error) {
+	return s.delete("flickr.photosets.comments.deleteComment", commentId)
+}
+
+func (s *service) GetPhotosetList(photosetId string) (*CommentListResponse, error) {
+	client := s.client
+	client.Init()
+	client.Args.Set("method", "flickr.photosets.comments.getList")
+	client.Args.Set("photoset_id", photosetId)
+
+	client.ApiSign()
+
+	response := &CommentListResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+// Add a comment with method to the object whose id is set in idParam
+func (s *service) add(method, idParam, id, text string) (*CommentResponse, error) {
+	client := s.client
+	client.Init()
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", method)
+	client.Args.Set(idParam, id)
+	client.Args.Set("comment_text", text)
+
+	client.OAuthSign()
+
+	response := &CommentResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+// Edit a comment with method
+func (s *service) edit(method, commentId, text string) (*flickr.BasicResponse, error) {
+	client := s.client
+	client.Init()
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", method)
+	client.Args.Set("comment_id", commentId)
+	client.Args.Set("comment_text", text)
+
+	client.OAuthSign()
+
+	response := &flickr.BasicResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+// Delete a comment with method
+func (s *service) delete(method, commentId string) (*flickr.BasicResponse, error) {
+	client := s.client
+	client.Init()
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", method)
+	client.Args.Set("comment_id", commentId)
+
+	client.OAuthSign()
+
+	response := &flickr.BasicResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
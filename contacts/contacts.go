@@ -0,0 +1,87 @@
+// Package implementing methods: flickr.contacts.*
+package contacts
+
+import (
+	"gopkg.in/masci/flickr.v2"
+)
+
+// Values of the filter argument of GetList and GetListRecentlyUploaded
+const (
+	NoFilter = ""
+	Friends  = "friends"
+	Family   = "family"
+	Both     = "both"
+	Neither  = "neither"
+)
+
+type Contact struct {
+	NSID       string `xml:"nsid,attr" json:"nsid"`
+	Username   string `xml:"username,attr" json:"username"`
+	RealName   string `xml:"realname,attr" json:"realname,omitempty"`
+	PathAlias  string `xml:"path_alias,attr" json:"path_alias,omitempty"`
+	Location   string `xml:"location,attr" json:"location,omitempty"`
+	IconServer string `xml:"iconserver,attr" json:"iconserver,omitempty"`
+	IconFarm   string `xml:"iconfarm,attr" json:"iconfarm,omitempty"`
+	Friend     bool   `xml:"friend,attr" json:"friend"`
+	Family     bool   `xml:"family,attr" json:"family"`
+	// The calling user ignores the contact
+	Ignored bool `xml:"ignored,attr" json:"ignored"`
+	// The contact ignores the calling user
+	RevIgnored bool `xml:"rev_ignored,attr" json:"rev_ignored"`
+	// Set by GetListRecentlyUploaded
+	PhotosUploaded int `xml:"photos_uploaded,attr" json:"photos_uploaded,omitempty"`
+}
+
+type ContactList struct {
+	Page     int       `xml:"page,attr"`
+	Pages    int       `xml:"pages,attr"`
+	PerPage  int       `xml:"perpage,attr"`
+	Total    int       `xml:"total,attr"`
+	Contacts []Contact `xml:"contact"`
+}
+
+type ContactListResponse struct {
+	flickr.BasicResponse
+	Contacts ContactList `xml:"contacts"`
+}
+
+type GetListOptionalArgs struct {
+	Filter  string // optional, set to NoFilter to ignore. one of Friends, Family, Both, Neither
+	Sort    string // optional, set to "" to ignore. "name" or "time"
+	PerPage int    // 0 to ignore
+	Page    int    // 0 to ignore
+}
+
+// Return the contacts of the calling user
+// This method requires authentication with 'read' permission.
+func GetList(client *flickr.FlickrClient, opts GetListOptionalArgs) (*ContactListResponse, error) {
+	return NewService(client).GetList(opts)
+}
+
+// Return the public contacts of a user, set perPage and page to 0 to use
+// Flickr defaults
+func GetPublicList(client *flickr.FlickrClient, userId string, perPage, page int) (*ContactListResponse, error) {
+	return NewService(client).GetPublicList(userId, perPage, page)
+}
+
+// Return the contacts of the calling user who uploaded photos since
+// dateLastUpload, a unix timestamp defaulting to the last hour when "".
+// This method requires authentication with 'read' permission.
+func GetListRecentlyUploaded(client *flickr.FlickrClient, dateLastUpload, filter string) (*ContactListResponse, error) {
+	return NewService(client).GetListRecentlyUploaded(dateLastUpload, filter)
+}
+
+// Return the contacts suggested to the calling user when tagging photos
+// This method requires authentication with 'read' permission.
+func GetTaggingSuggestions(client *flickr.FlickrClient, perPage, page int) (*ContactListResponse, error) {
+	return NewService(client).GetTaggingSuggestions(perPage, page)
+}
+
+// Return the contact graph of a user, retrieving all the pages of contacts.
+// Set userId to "" to get all the contacts of the calling user, which requires
+// authentication with 'read' permission, otherwise only public contacts are
+// returned. The ID of the calling user is taken from client.Id, or asked to
+// Flickr when empty.
+func GetGraph(client *flickr.FlickrClient, userId string) (*Graph, error) {
+	return NewService(client).GetGraph(userId)
+}
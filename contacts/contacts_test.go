@@ -0,0 +1,145 @@
+package contacts
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"gopkg.in/masci/flickr.v2"
+)
+
+var listBody = `<?xml version="1.0" encoding="utf-8" ?>
+	<rsp stat="ok">
+		<contacts page="1" pages="1" perpage="1000" total="3">
+			<contact nsid="12037949629@N01" username="Eric" iconserver="1" iconfarm="1" realname="Eric Costello" friend="1" family="0" ignored="1" rev_ignored="0" path_alias="ericco" location="San Francisco" />
+			<contact nsid="12037949631@N01" username="neb" iconserver="1" iconfarm="1" realname="Ben Cerveny" friend="0" family="1" ignored="0" rev_ignored="1" />
+			<contact nsid="41578656547@N01" username="cattitude" iconserver="1" iconfarm="1" realname="Heather" friend="1" family="1" ignored="0" rev_ignored="0" />
+		</contacts>
+	</rsp>`
+
+func TestGetList(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, listBody, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetList(fclient, GetListOptionalArgs{Filter: Friends, Sort: "name", PerPage: 1000, Page: 2})
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, resp.Contacts.Total, 3)
+	flickr.Expect(t, len(resp.Contacts.Contacts), 3)
+	c := resp.Contacts.Contacts[0]
+	flickr.Expect(t, c.NSID, "12037949629@N01")
+	flickr.Expect(t, c.Username, "Eric")
+	flickr.Expect(t, c.RealName, "Eric Costello")
+	flickr.Expect(t, c.PathAlias, "ericco")
+	flickr.Expect(t, c.Location, "San Francisco")
+	flickr.Expect(t, c.Friend, true)
+	flickr.Expect(t, c.Family, false)
+	flickr.Expect(t, c.Ignored, true)
+	flickr.Expect(t, resp.Contacts.Contacts[1].RevIgnored, true)
+	flickr.AssertParamsInBody(t, fclient, []string{"filter", "sort", "per_page", "page", "oauth_signature"})
+}
+
+func TestGetPublicList(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, listBody, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetPublicList(fclient, "12037949754@N01", 0, 0)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, len(resp.Contacts.Contacts), 3)
+	flickr.Expect(t, fclient.Args.Get("per_page"), "")
+	flickr.AssertParamsInBody(t, fclient, []string{"user_id", "api_sig"})
+}
+
+func TestGetListRecentlyUploaded(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	body := `<rsp stat="ok"><contacts>
+		<contact nsid="12037949629@N01" username="Eric" iconserver="1" realname="Eric Costello" friend="1" family="0" ignored="0" photos_uploaded="5" />
+	</contacts></rsp>`
+	server, client := flickr.FlickrMock(200, body, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetListRecentlyUploaded(fclient, "1141841400", Both)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, resp.Contacts.Contacts[0].PhotosUploaded, 5)
+	flickr.AssertParamsInBody(t, fclient, []string{"date_lastupload", "filter"})
+
+	_, err = GetListRecentlyUploaded(fclient, "", NoFilter)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("filter"), "")
+	flickr.Expect(t, fclient.Args.Get("date_lastupload"), "")
+}
+
+func TestGetTaggingSuggestions(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, listBody, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetTaggingSuggestions(fclient, 10, 1)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, len(resp.Contacts.Contacts), 3)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.contacts.getTaggingSuggestions")
+	flickr.AssertParamsInBody(t, fclient, []string{"per_page"})
+}
+
+// Serve pages of contacts, one contact per page, and flickr.test.login
+func contactPagesServer(pages int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("method") == "flickr.test.login" {
+			fmt.Fprint(w, `<rsp stat="ok"><user id="23148015@N00"><username>gopher</username></user></rsp>`)
+			return
+		}
+		page := r.URL.Query().Get("page")
+		if page == "" {
+			page = "1"
+		}
+		fmt.Fprintf(w, `<rsp stat="ok"><contacts page="%s" pages="%d" perpage="1" total="%d">`+
+			`<contact nsid="%s@N01" username="user%s" friend="0" family="0" /></contacts></rsp>`, page, pages, pages, page, page)
+	}))
+}
+
+func TestGetGraph(t *testing.T) {
+	server := contactPagesServer(3)
+	defer server.Close()
+	client := flickr.NewFlickrClient("key", "secret")
+	client.Endpoints.API = server.URL
+
+	graph, err := GetGraph(client, "12037949754@N01")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, graph.UserId, "12037949754@N01")
+	flickr.Expect(t, len(graph.Contacts), 3)
+	flickr.Expect(t, graph.Contacts[2].NSID, "3@N01")
+	flickr.Expect(t, client.Args.Get("method"), "flickr.contacts.getPublicList")
+	flickr.Expect(t, client.Args.Get("per_page"), "1000")
+
+	// the calling user is the one of the client
+	client.Id = "35034348999@N01"
+	graph, err = GetGraph(client, "")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, graph.UserId, "35034348999@N01")
+	flickr.Expect(t, len(graph.Contacts), 3)
+	flickr.Expect(t, client.Args.Get("method"), "flickr.contacts.getList")
+
+	// or asked to Flickr
+	client.Id = ""
+	graph, err = GetGraph(client, "")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, graph.UserId, "23148015@N00")
+	flickr.Expect(t, len(graph.Contacts), 3)
+}
+
+func TestGetGraphError(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<rsp stat="fail"><err code="1" msg="User not found" /></rsp>`, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	graph, err := GetGraph(fclient, "unknown")
+	flickr.Expect(t, err != nil, true)
+	flickr.Expect(t, graph == nil, true)
+}
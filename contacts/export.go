@@ -0,0 +1,52 @@
+package contacts
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+)
+
+// The contacts of a user
+type Graph struct {
+	// Flickr ID of the user
+	UserId   string    `json:"user_id"`
+	Contacts []Contact `json:"contacts"`
+}
+
+// Columns of the CSV export
+var csvHeader = []string{"user_id", "nsid", "username", "realname", "path_alias", "location", "friend", "family", "ignored", "rev_ignored"}
+
+// Write the graph as an indented JSON document
+func (g *Graph) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(g)
+}
+
+// Write the graph as CSV, a header followed by a row per contact
+func (g *Graph) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, c := range g.Contacts {
+		row := []string{
+			g.UserId,
+			c.NSID,
+			c.Username,
+			c.RealName,
+			c.PathAlias,
+			c.Location,
+			strconv.FormatBool(c.Friend),
+			strconv.FormatBool(c.Family),
+			strconv.FormatBool(c.Ignored),
+			strconv.FormatBool(c.RevIgnored),
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,69 @@
+package contacts
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"gopkg.in/masci/flickr.v2"
+)
+
+var graph = &Graph{
+	UserId: "12037949754@N01",
+	Contacts: []Contact{
+		{NSID: "12037949629@N01", Username: "Eric", RealName: "Eric Costello", Friend: true},
+		{NSID: "12037949631@N01", Username: "neb", RealName: "Ben, \"neb\" Cerveny", Family: true, Ignored: true},
+	},
+}
+
+func TestWriteJSON(t *testing.T) {
+	buf := &bytes.Buffer{}
+	err := graph.WriteJSON(buf)
+	flickr.Expect(t, err, nil)
+
+	decoded := &Graph{}
+	err = json.Unmarshal(buf.Bytes(), decoded)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, decoded.UserId, "12037949754@N01")
+	flickr.Expect(t, len(decoded.Contacts), 2)
+	flickr.Expect(t, decoded.Contacts[1], graph.Contacts[1])
+	flickr.Expect(t, bytes.Contains(buf.Bytes(), []byte(`"friend": true`)), true)
+	flickr.Expect(t, bytes.Contains(buf.Bytes(), []byte(`path_alias`)), false)
+}
+
+func TestWriteCSV(t *testing.T) {
+	buf := &bytes.Buffer{}
+	err := graph.WriteCSV(buf)
+	flickr.Expect(t, err, nil)
+
+	expected := "user_id,nsid,username,realname,path_alias,location,friend,family,ignored,rev_ignored\n" +
+		"12037949754@N01,12037949629@N01,Eric,Eric Costello,,,true,false,false,false\n" +
+		"12037949754@N01,12037949631@N01,neb,\"Ben, \"\"neb\"\" Cerveny\",,,false,true,true,false\n"
+	flickr.Expect(t, buf.String(), expected)
+
+	buf.Reset()
+	err = (&Graph{}).WriteCSV(buf)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, buf.String(), "user_id,nsid,username,realname,path_alias,location,friend,family,ignored,rev_ignored\n")
+}
+
+// Rows of the calling user's graph carry their ID
+func TestWriteCSVCallingUser(t *testing.T) {
+	server := contactPagesServer(2)
+	defer server.Close()
+	client := flickr.NewFlickrClient("key", "secret")
+	client.Endpoints.API = server.URL
+	client.Id = "35034348999@N01"
+
+	g, err := GetGraph(client, "")
+	flickr.Expect(t, err, nil)
+	buf := &bytes.Buffer{}
+	err = g.WriteCSV(buf)
+	flickr.Expect(t, err, nil)
+
+	rows := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	flickr.Expect(t, len(rows), 3)
+	flickr.Expect(t, strings.HasPrefix(rows[1], "35034348999@N01,1@N01,"), true)
+	flickr.Expect(t, strings.HasPrefix(rows[2], "35034348999@N01,2@N01,"), true)
+}
@@ -0,0 +1,141 @@
+package contacts
+
+import (
+	"strconv"
+
+	"gopkg.in/masci/flickr.v2"
+	"gopkg.in/masci/flickr.v2/test"
+)
+
+// Contacts per page requested by GetGraph, the maximum allowed by Flickr
+const graphPerPage = 1000
+
+// Methods of the flickr.contacts namespace
+type Service interface {
+	// Return the contacts of the calling user
+	GetList(opts GetListOptionalArgs) (*ContactListResponse, error)
+	// Return the public contacts of a user
+	GetPublicList(userId string, perPage, page int) (*ContactListResponse, error)
+	// Return the contacts of the calling user who recently uploaded photos
+	GetListRecentlyUploaded(dateLastUpload, filter string) (*ContactListResponse, error)
+	// Return the contacts suggested to the calling user when tagging photos
+	GetTaggingSuggestions(perPage, page int) (*ContactListResponse, error)
+	// Return the contact graph of a user
+	GetGraph(userId string) (*Graph, error)
+}
+
+// Return a Service listing contacts with client
+func NewService(client *flickr.FlickrClient) Service {
+	return &service{client}
+}
+
+type service struct {
+	client *flickr.FlickrClient
+}
+
+func (s *service) GetList(opts GetListOptionalArgs) (*ContactListResponse, error) {
+	client := s.client
+	client.Init()
+	client.Args.Set("method", "flickr.contacts.getList")
+	if opts.Filter != NoFilter {
+		client.Args.Set("filter", opts.Filter)
+	}
+	if opts.Sort != "" {
+		client.Args.Set("sort", opts.Sort)
+	}
+	setPage(client, opts.PerPage, opts.Page)
+
+	client.OAuthSign()
+
+	response := &ContactListResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+func (s *service) GetPublicList(userId string, perPage, page int) (*ContactListResponse, error) {
+	client := s.client
+	client.Init()
+	client.Args.Set("method", "flickr.contacts.getPublicList")
+	client.Args.Set("user_id", userId)
+	setPage(client, perPage, page)
+
+	client.ApiSign()
+
+	response := &ContactListResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+func (s *service) GetListRecentlyUploaded(dateLastUpload, filter string) (*ContactListResponse, error) {
+	client := s.client
+	client.Init()
+	client.Args.Set("method", "flickr.contacts.getListRecentlyUploaded")
+	if dateLastUpload != "" {
+		client.Args.Set("date_lastupload", dateLastUpload)
+	}
+	if filter != NoFilter {
+		client.Args.Set("filter", filter)
+	}
+
+	client.OAuthSign()
+
+	response := &ContactListResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+func (s *service) GetTaggingSuggestions(perPage, page int) (*ContactListResponse, error) {
+	client := s.client
+	client.Init()
+	client.Args.Set("method", "flickr.contacts.getTaggingSuggestions")
+	setPage(client, perPage, page)
+
+	client.OAuthSign()
+
+	response := &ContactListResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+func (s *service) GetGraph(userId string) (*Graph, error) {
+	graph := &Graph{UserId: userId}
+	if userId == "" {
+		graph.UserId = s.client.Id
+	}
+	if graph.UserId == "" {
+		// the client doesn't know who the calling user is
+		login, err := test.NewService(s.client).Login()
+		if err != nil {
+			return nil, err
+		}
+		graph.UserId = login.User.ID
+	}
+
+	for page, pages := 1, 1; page <= pages; page++ {
+		var (
+			resp *ContactListResponse
+			err  error
+		)
+		if userId == "" {
+			resp, err = s.GetList(GetListOptionalArgs{PerPage: graphPerPage, Page: page})
+		} else {
+			resp, err = s.GetPublicList(userId, graphPerPage, page)
+		}
+		if err != nil {
+			return nil, err
+		}
+		pages = resp.Contacts.Pages
+		graph.Contacts = append(graph.Contacts, resp.Contacts.Contacts...)
+	}
+	return graph, nil
+}
+
+// Set the pagination arguments, if not provided flickr defaults page to 1
+func setPage(client *flickr.FlickrClient, perPage, page int) {
+	if perPage > 0 {
+		client.Args.Set("per_page", strconv.Itoa(perPage))
+	}
+	if page > 1 {
+		client.Args.Set("page", strconv.Itoa(page))
+	}
+}
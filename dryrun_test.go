@@ -0,0 +1,64 @@
+package flickr
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestIsWriteRequest(t *testing.T) {
+	Expect(t, isWriteRequest("flickr.photos.delete", "POST"), true)
+	Expect(t, isWriteRequest("flickr.photosets.editPhotos", "GET"), true)
+	Expect(t, isWriteRequest("flickr.photos.getInfo", "POST"), false)
+	Expect(t, isWriteRequest("flickr.auth.oauth.getAccessToken", "GET"), true)
+	Expect(t, isWriteRequest("flickr.photos.recentlyUpdated", "GET"), false)
+	Expect(t, isWriteRequest("flickr.groups.browse", "GET"), false)
+	Expect(t, isWriteRequest("flickr.photos.comments.getRecentForContacts", "GET"), false)
+	// uploads
+	Expect(t, isWriteRequest("", "POST"), true)
+	// OAuth token requests
+	Expect(t, isWriteRequest("", "GET"), false)
+}
+
+func TestDryRun(t *testing.T) {
+	server, requests := callMock(`<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"><photoid>1234</photoid></rsp>`)
+	defer server.Close()
+
+	buf := &bytes.Buffer{}
+	client := NewFlickrClient("key", "secret")
+	client.Endpoints = Endpoints{API: server.URL, Upload: server.URL}
+	client.OAuthToken = "token"
+	client.DryRun = true
+	client.DryRunLogger = log.New(buf, "", 0)
+
+	params := url.Values{}
+	params.Set("photo_id", "123")
+	resp := &BasicResponse{}
+	err := client.Call(context.Background(), "flickr.photos.delete", params, resp)
+	Expect(t, err, nil)
+	Expect(t, resp.HasErrors(), false)
+	Expect(t, len(*requests), 0)
+
+	logged := buf.String()
+	Expect(t, strings.HasPrefix(logged, "flickr: dry run, not sending POST "+server.URL+" "), true)
+	Expect(t, strings.Contains(logged, "method=flickr.photos.delete"), true)
+	Expect(t, strings.Contains(logged, "photo_id=123"), true)
+	Expect(t, strings.Contains(logged, "oauth_signature=REDACTED"), true)
+	Expect(t, strings.Contains(logged, "oauth_token=token"), false)
+
+	// uploads are not sent either
+	up, err := UploadReader(client, strings.NewReader("not really a jpeg"), "gopher.jpg", nil)
+	Expect(t, err, nil)
+	Expect(t, up.ID, "")
+	Expect(t, len(*requests), 0)
+
+	// read methods are
+	info := &BasicResponse{}
+	err = client.Call(context.Background(), "flickr.photos.getInfo", params, info)
+	Expect(t, err, nil)
+	Expect(t, len(*requests), 1)
+	Expect(t, strings.Count(buf.String(), "\n"), 2)
+}
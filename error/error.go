@@ -1,6 +1,13 @@
 // Flickr.go error system
 package error
 
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
 // here we define ONLY errors from the library NOT from flickr
 // error from flickr have already a code and a message that are returned
 // along with the HTTP Response
@@ -9,17 +16,40 @@ const (
 	ApiError          = 10
 	RequestTokenError = 20
 	OAuthTokenError   = 30
+	// The response has a non-2xx HTTP status and no API error, like the
+	// pages of gateways and proxies in front of Flickr
+	HTTPError = 40
+	// Flickr refused an OAuth signed request, see Error.OAuthProblem
+	OAuthProblemError = 50
+	// The response is neither a REST response nor an OAuth problem
+	UnexpectedResponseError = 60
+	// The request got no response, see Error.Err
+	TransportError = 70
 )
 
 var errors = map[int]string{
-	ApiError:          "Flickr API returned an error: ",
-	RequestTokenError: "An error occurred during token request: ",
-	OAuthTokenError:   "An error occurred while getting the OAuth token: ",
+	ApiError:                "Flickr API returned an error: ",
+	RequestTokenError:       "An error occurred during token request: ",
+	OAuthTokenError:         "An error occurred while getting the OAuth token: ",
+	HTTPError:               "Flickr returned an HTTP error: ",
+	OAuthProblemError:       "Flickr refused the OAuth request: ",
+	UnexpectedResponseError: "Flickr returned an unexpected response: ",
+	TransportError:          "An error occurred while sending the request: ",
 }
 
 type Error struct {
 	ErrorCode int
 	Message   string
+	// HTTP status of the response, 0 if there was none
+	StatusCode int
+	// Error code reported by Flickr for ApiError errors
+	ApiCode int
+	// OAuth problem reported by Flickr, like "timestamp_refused"
+	OAuthProblem string
+	// All the fields of the OAuth problem, like oauth_acceptable_timestamps
+	OAuthParams url.Values
+	// The error causing a TransportError
+	Err error
 }
 
 // Implement error interface
@@ -27,9 +57,59 @@ func (e Error) Error() string {
 	return e.Message
 }
 
+// Return the error causing a TransportError
+func (e Error) Unwrap() error {
+	return e.Err
+}
+
+// Return whether the error comes from a gateway in front of Flickr
+func (e Error) IsGatewayError() bool {
+	switch e.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// Return whether the request could succeed if sent again: transport errors,
+// gateway errors, rate limiting and refused OAuth timestamps. Requests must be
+// signed again before being retried.
+func (e Error) Retryable() bool {
+	return e.ErrorCode == TransportError ||
+		e.IsGatewayError() ||
+		e.StatusCode == http.StatusTooManyRequests ||
+		e.OAuthProblem == "timestamp_refused"
+}
+
 func NewError(errorCode int, message string) *Error {
 	return &Error{
 		ErrorCode: errorCode,
 		Message:   errors[errorCode] + message,
 	}
 }
+
+// Create the error of a response with a non-2xx HTTP status
+func NewHTTPError(statusCode int) *Error {
+	e := NewError(HTTPError, fmt.Sprintf("%d %s", statusCode, http.StatusText(statusCode)))
+	e.StatusCode = statusCode
+	return e
+}
+
+// Create an error of the given kind from an OAuth problem body, something
+// like "oauth_problem=timestamp_refused&oauth_acceptable_timestamps=...".
+// errorCode is OAuthProblemError for REST requests.
+func NewOAuthProblem(errorCode int, statusCode int, body string) *Error {
+	params, _ := url.ParseQuery(strings.TrimSpace(body))
+	e := NewError(errorCode, params.Get("oauth_problem"))
+	e.StatusCode = statusCode
+	e.OAuthProblem = params.Get("oauth_problem")
+	e.OAuthParams = params
+	return e
+}
+
+// Create the error of a request that got no response
+func NewTransportError(err error) *Error {
+	e := NewError(TransportError, err.Error())
+	e.Err = err
+	return e
+}
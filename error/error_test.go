@@ -1,6 +1,7 @@
 package error
 
 import (
+	"fmt"
 	"testing"
 )
 
@@ -12,3 +13,41 @@ func TestError(t *testing.T) {
 
 	}
 }
+
+func TestHTTPError(t *testing.T) {
+	e := NewHTTPError(502)
+	if e.Error() != errors[HTTPError]+"502 Bad Gateway" {
+		t.Errorf("Unexpected message %s", e.Error())
+	}
+	if !e.IsGatewayError() || !e.Retryable() {
+		t.Error("502 errors are retryable gateway errors")
+	}
+	e = NewHTTPError(404)
+	if e.IsGatewayError() || e.Retryable() {
+		t.Error("404 errors are not retryable")
+	}
+	if !NewHTTPError(429).Retryable() {
+		t.Error("429 errors are retryable")
+	}
+}
+
+func TestOAuthProblem(t *testing.T) {
+	e := NewOAuthProblem(OAuthProblemError, 401, "oauth_problem=timestamp_refused&oauth_acceptable_timestamps=1-2\n")
+	if e.OAuthProblem != "timestamp_refused" || e.OAuthParams.Get("oauth_acceptable_timestamps") != "1-2" {
+		t.Errorf("Unexpected OAuth fields %+v", e)
+	}
+	if e.StatusCode != 401 || !e.Retryable() {
+		t.Errorf("Unexpected error %+v", e)
+	}
+	if NewOAuthProblem(OAuthProblemError, 401, "oauth_problem=signature_invalid").Retryable() {
+		t.Error("invalid signatures are not retryable")
+	}
+}
+
+func TestTransportError(t *testing.T) {
+	cause := fmt.Errorf("connection refused")
+	e := NewTransportError(cause)
+	if e.Unwrap() != cause || !e.Retryable() {
+		t.Errorf("Unexpected error %+v", e)
+	}
+}
@@ -0,0 +1,91 @@
+// Package implementing methods: flickr.favorites.*
+package favorites
+
+import (
+	"gopkg.in/masci/flickr.v2"
+	"gopkg.in/masci/flickr.v2/people"
+)
+
+// A favorite photo, with the same fields as the photos of people.GetPhotos
+type Photo struct {
+	people.Photo
+	// When the photo was added to the favorites, unix timestamp
+	DateFaved string `xml:"date_faved,attr"`
+}
+
+type PhotoList struct {
+	Page    int     `xml:"page,attr"`
+	Pages   int     `xml:"pages,attr"`
+	PerPage int     `xml:"perpage,attr"`
+	Total   int     `xml:"total,attr"`
+	Photos  []Photo `xml:"photo"`
+}
+
+type PhotoListResponse struct {
+	flickr.BasicResponse
+	Photos PhotoList `xml:"photos"`
+}
+
+// A photo next to another one in the favorites of a user
+type ContextPhoto struct {
+	Id        string `xml:"id,attr"`
+	Owner     string `xml:"owner,attr"`
+	Secret    string `xml:"secret,attr"`
+	Server    string `xml:"server,attr"`
+	Farm      string `xml:"farm,attr"`
+	Title     string `xml:"title,attr"`
+	Url       string `xml:"url,attr"`
+	Thumb     string `xml:"thumb,attr"`
+	License   string `xml:"license,attr"`
+	Media     string `xml:"media,attr"`
+	DateFaved string `xml:"date_faved,attr"`
+}
+
+type ContextResponse struct {
+	flickr.BasicResponse
+	// Number of favorites of the user
+	Count int `xml:"count"`
+	// Photos faved before the given one, the closest first
+	Prev []ContextPhoto `xml:"prevphoto"`
+	// Photos faved after the given one, the closest first
+	Next []ContextPhoto `xml:"nextphoto"`
+}
+
+type GetListOptionalArgs struct {
+	MinFaveDate string // optional, set to "" to ignore. unix timestamp
+	MaxFaveDate string // optional, set to "" to ignore. unix timestamp
+	Extras      string // optional, set to "" to ignore. comma separated string.
+	PerPage     int    // 0 to ignore
+	Page        int    // 0 to ignore
+}
+
+// Add a photo to the favorites of the calling user
+// This method requires authentication with 'write' permission.
+func Add(client *flickr.FlickrClient, photoId string) (*flickr.BasicResponse, error) {
+	return NewService(client).Add(photoId)
+}
+
+// Remove a photo from the favorites of the calling user
+// This method requires authentication with 'write' permission.
+func Remove(client *flickr.FlickrClient, photoId string) (*flickr.BasicResponse, error) {
+	return NewService(client).Remove(photoId)
+}
+
+// Return the favorites of the user with userId, including private photos the
+// caller can see. If userId is empty it defaults to the caller user.
+// This method requires authentication with 'read' permission.
+func GetList(client *flickr.FlickrClient, userId string, opts GetListOptionalArgs) (*PhotoListResponse, error) {
+	return NewService(client).GetList(userId, opts)
+}
+
+// Return the public favorites of the user with userId
+func GetPublicList(client *flickr.FlickrClient, userId string, opts GetListOptionalArgs) (*PhotoListResponse, error) {
+	return NewService(client).GetPublicList(userId, opts)
+}
+
+// Return the photos faved before and after photoId by the user with userId.
+// numPrev and numNext set how many photos to return on each side, 0 lets
+// Flickr return one. extras is a comma separated string, "" to ignore.
+func GetContext(client *flickr.FlickrClient, photoId, userId string, numPrev, numNext int, extras string) (*ContextResponse, error) {
+	return NewService(client).GetContext(photoId, userId, numPrev, numNext, extras)
+}
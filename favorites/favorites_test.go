@@ -0,0 +1,117 @@
+package favorites
+
+import (
+	"testing"
+
+	"gopkg.in/masci/flickr.v2"
+	flickErr "gopkg.in/masci/flickr.v2/error"
+)
+
+var (
+	listBody = `<?xml version="1.0" encoding="utf-8" ?>
+		<rsp stat="ok">
+			<photos page="2" pages="3" perpage="2" total="6">
+				<photo id="2636" owner="47058503995@N01" secret="a123456" server="2" farm="1" title="test_04" ispublic="1" isfriend="0" isfamily="0" date_faved="1166907640" url_sq="https://live.staticflickr.com/2/2636_a123456_s.jpg" />
+				<photo id="2635" owner="47058503995@N01" secret="b123456" server="2" farm="1" title="test_03" ispublic="0" isfriend="1" isfamily="1" date_faved="1166907600" />
+			</photos>
+		</rsp>`
+
+	contextBody = `<?xml version="1.0" encoding="utf-8" ?>
+		<rsp stat="ok">
+			<count>6</count>
+			<prevphoto id="2980" owner="12037949754@N01" secret="973da1e5a7" server="1" farm="1" title="Gopher" url="/photos/bees/2980/" thumb="https://farm1.staticflickr.com/1/2980_973da1e5a7_s.jpg" license="4" media="photo" date_faved="1166907700" />
+			<nextphoto id="2985" owner="12037949754@N01" secret="059b664012" server="1" farm="1" title="Gordon" url="/photos/bees/2985/" thumb="https://farm1.staticflickr.com/1/2985_059b664012_s.jpg" license="0" media="photo" date_faved="1166907500" />
+			<nextphoto id="2990" owner="12037949754@N01" secret="079b664012" server="1" farm="1" title="Glenda" url="/photos/bees/2990/" thumb="https://farm1.staticflickr.com/1/2990_079b664012_s.jpg" license="0" media="photo" date_faved="1166907400" />
+		</rsp>`
+)
+
+func TestAdd(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<rsp stat="ok"></rsp>`, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	_, err := Add(fclient, "123")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.HTTPVerb, "POST")
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.favorites.add")
+	flickr.AssertParamsInBody(t, fclient, []string{"photo_id", "oauth_signature"})
+
+	server, client = flickr.FlickrMock(200, `<rsp stat="fail"><err code="3" msg="Photo is already in favorites" /></rsp>`, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+	resp, err := Add(fclient, "123")
+	_, ok := err.(*flickErr.Error)
+	flickr.Expect(t, ok, true)
+	flickr.Expect(t, resp.ErrorCode(), 3)
+}
+
+func TestRemove(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<rsp stat="ok"></rsp>`, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	_, err := Remove(fclient, "123")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.favorites.remove")
+	flickr.AssertParamsInBody(t, fclient, []string{"photo_id", "oauth_signature"})
+}
+
+func TestGetList(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, listBody, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	opts := GetListOptionalArgs{MinFaveDate: "1166900000", MaxFaveDate: "1167000000", Extras: "url_sq", PerPage: 2, Page: 2}
+	resp, err := GetList(fclient, "", opts)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, resp.Photos.Page, 2)
+	flickr.Expect(t, resp.Photos.Pages, 3)
+	flickr.Expect(t, resp.Photos.PerPage, 2)
+	flickr.Expect(t, resp.Photos.Total, 6)
+	flickr.Expect(t, len(resp.Photos.Photos), 2)
+	photo := resp.Photos.Photos[0]
+	flickr.Expect(t, photo.Id, "2636")
+	flickr.Expect(t, photo.Title, "test_04")
+	flickr.Expect(t, photo.IsPublic, true)
+	flickr.Expect(t, photo.DateFaved, "1166907640")
+	flickr.Expect(t, photo.UrlSq, "https://live.staticflickr.com/2/2636_a123456_s.jpg")
+
+	flickr.Expect(t, fclient.Args.Get("user_id"), "")
+	flickr.Expect(t, fclient.Args.Get("oauth_signature") != "", true)
+	flickr.AssertParamsInBody(t, fclient, []string{"min_fave_date", "max_fave_date", "extras", "per_page", "page"})
+}
+
+func TestGetPublicList(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, listBody, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetPublicList(fclient, "47058503995@N01", GetListOptionalArgs{})
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, len(resp.Photos.Photos), 2)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.favorites.getPublicList")
+	flickr.Expect(t, fclient.Args.Get("api_sig") != "", true)
+	flickr.Expect(t, fclient.Args.Get("page"), "")
+	flickr.AssertParamsInBody(t, fclient, []string{"user_id"})
+}
+
+func TestGetContext(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, contextBody, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetContext(fclient, "2983", "12037949754@N01", 1, 2, "")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, resp.Count, 6)
+	flickr.Expect(t, len(resp.Prev), 1)
+	flickr.Expect(t, resp.Prev[0].Id, "2980")
+	flickr.Expect(t, resp.Prev[0].DateFaved, "1166907700")
+	flickr.Expect(t, len(resp.Next), 2)
+	flickr.Expect(t, resp.Next[1].Title, "Glenda")
+	flickr.AssertParamsInBody(t, fclient, []string{"photo_id", "user_id", "num_prev", "num_next"})
+}
@@ -0,0 +1,130 @@
+package favorites
+
+import (
+	"strconv"
+
+	"gopkg.in/masci/flickr.v2"
+)
+
+// Methods of the flickr.favorites namespace
+type Service interface {
+	// Add a photo to the favorites of the calling user
+	Add(photoId string) (*flickr.BasicResponse, error)
+	// Remove a photo from the favorites of the calling user
+	Remove(photoId string) (*flickr.BasicResponse, error)
+	// Return the favorites of a user, private photos included
+	GetList(userId string, opts GetListOptionalArgs) (*PhotoListResponse, error)
+	// Return the public favorites of a user
+	GetPublicList(userId string, opts GetListOptionalArgs) (*PhotoListResponse, error)
+	// Return the photos faved before and after a photo
+	GetContext(photoId, userId string, numPrev, numNext int, extras string) (*ContextResponse, error)
+}
+
+// Return a Service managing favorites with client
+func NewService(client *flickr.FlickrClient) Service {
+	return &service{client}
+}
+
+type service struct {
+	client *flickr.FlickrClient
+}
+
+func (s *service) Add(photoId string) (*flickr.BasicResponse, error) {
+	client := s.client
+	client.Init()
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.favorites.add")
+	client.Args.Set("photo_id", photoId)
+
+	client.OAuthSign()
+
+	response := &flickr.BasicResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+func (s *service) Remove(photoId string) (*flickr.BasicResponse, error) {
+	client := s.client
+	client.Init()
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.favorites.remove")
+	client.Args.Set("photo_id", photoId)
+
+	client.OAuthSign()
+
+	response := &flickr.BasicResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+func (s *service) GetList(userId string, opts GetListOptionalArgs) (*PhotoListResponse, error) {
+	client := s.client
+	client.Init()
+	client.Args.Set("method", "flickr.favorites.getList")
+	if userId != "" {
+		client.Args.Set("user_id", userId)
+	}
+	setListArgs(client, opts)
+
+	client.OAuthSign()
+
+	response := &PhotoListResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+func (s *service) GetPublicList(userId string, opts GetListOptionalArgs) (*PhotoListResponse, error) {
+	client := s.client
+	client.Init()
+	client.Args.Set("method", "flickr.favorites.getPublicList")
+	client.Args.Set("user_id", userId)
+	setListArgs(client, opts)
+
+	client.ApiSign()
+
+	response := &PhotoListResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+func (s *service) GetContext(photoId, userId string, numPrev, numNext int, extras string) (*ContextResponse, error) {
+	client := s.client
+	client.Init()
+	client.Args.Set("method", "flickr.favorites.getContext")
+	client.Args.Set("photo_id", photoId)
+	client.Args.Set("user_id", userId)
+	if numPrev != 0 {
+		client.Args.Set("num_prev", strconv.Itoa(numPrev))
+	}
+	if numNext != 0 {
+		client.Args.Set("num_next", strconv.Itoa(numNext))
+	}
+	if extras != "" {
+		client.Args.Set("extras", extras)
+	}
+
+	client.ApiSign()
+
+	response := &ContextResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+// Set the optional args of the list methods
+func setListArgs(client *flickr.FlickrClient, opts GetListOptionalArgs) {
+	if opts.MinFaveDate != "" {
+		client.Args.Set("min_fave_date", opts.MinFaveDate)
+	}
+	if opts.MaxFaveDate != "" {
+		client.Args.Set("max_fave_date", opts.MaxFaveDate)
+	}
+	if opts.Extras != "" {
+		client.Args.Set("extras", opts.Extras)
+	}
+	if opts.PerPage != 0 {
+		client.Args.Set("per_page", strconv.Itoa(opts.PerPage))
+	}
+	if opts.Page != 0 {
+		client.Args.Set("page", strconv.Itoa(opts.Page))
+	}
+}
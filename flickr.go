@@ -3,7 +3,9 @@ package flickr
 
 import (
 	"bytes"
+	"context"
 	"mime/multipart"
+	"net/http"
 )
 
 const (
@@ -14,33 +16,102 @@ const (
 	ACCESS_TOKEN_URL  = "https://www.flickr.com/services/oauth/access_token"
 )
 
+// Base URLs of the endpoints a FlickrClient talks to. Set them to point a
+// client at a proxy or a local stand-in of Flickr, empty fields default to
+// the Flickr ones.
+type Endpoints struct {
+	// REST API, defaults to API_ENDPOINT
+	API string
+	// Photo uploads, defaults to UPLOAD_ENDPOINT
+	Upload string
+	// OAuth authorization page, defaults to AUTHORIZE_URL
+	Authorize string
+	// OAuth request tokens, defaults to REQUEST_TOKEN_URL
+	RequestToken string
+	// OAuth access tokens, defaults to ACCESS_TOKEN_URL
+	AccessToken string
+}
+
+// The Flickr endpoints
+var DefaultEndpoints = Endpoints{
+	API:          API_ENDPOINT,
+	Upload:       UPLOAD_ENDPOINT,
+	Authorize:    AUTHORIZE_URL,
+	RequestToken: REQUEST_TOKEN_URL,
+	AccessToken:  ACCESS_TOKEN_URL,
+}
+
+// Return a copy of the endpoints with empty fields set to the Flickr ones
+func (e Endpoints) withDefaults() Endpoints {
+	def := func(val, fallback string) string {
+		if val == "" {
+			return fallback
+		}
+		return val
+	}
+	return Endpoints{
+		API:          def(e.API, DefaultEndpoints.API),
+		Upload:       def(e.Upload, DefaultEndpoints.Upload),
+		Authorize:    def(e.Authorize, DefaultEndpoints.Authorize),
+		RequestToken: def(e.RequestToken, DefaultEndpoints.RequestToken),
+		AccessToken:  def(e.AccessToken, DefaultEndpoints.AccessToken),
+	}
+}
+
 // Perform a GET request to the Flickr API with the configured FlickrClient passed as first
 // parameter. Results will be unmarshalled to fill in a FlickrResponse struct passed as
 // second parameter.
+// If Flickr refuses the OAuth timestamp because of a clock skew, the request
+// is signed again with a corrected timestamp and retried once.
 func DoGet(client *FlickrClient, r FlickrResponse) error {
-	res, err := client.HTTPClient.Get(client.GetUrl())
-	if err != nil {
-		return err
-	}
-
-	return parseApiResponse(res, r)
+	return doWithRetry(context.Background(), client, "GET", r)
 }
 
 // Perform a POST request to the Flickr API with the configured FlickrClient, the
 // request body and the body content type. Results will be unmarshalled in a FlickrResponse
 // struct.
 func DoPostBody(client *FlickrClient, body *bytes.Buffer, bodyType string, r FlickrResponse) error {
-	res, err := client.HTTPClient.Post(client.EndpointUrl, bodyType, body)
+	req, err := http.NewRequest("POST", client.EndpointUrl, body)
 	if err != nil {
 		return err
 	}
+	req.Header.Set("Content-Type", bodyType)
 
-	return parseApiResponse(res, r)
+	return doRequest(context.Background(), client, req, r)
 }
 
 // Perform a POST request to the Flickr API with the configured FlickrClient,
-// dumping client Args into the request Body.
+// dumping client Args into the request Body. As with DoGet, the request is
+// retried once if Flickr refuses the OAuth timestamp.
 func DoPost(client *FlickrClient, r FlickrResponse) error {
+	return doWithRetry(context.Background(), client, "POST", r)
+}
+
+// Perform a request with the client Args, retrying once if Flickr refuses the
+// OAuth timestamp
+func doWithRetry(ctx context.Context, client *FlickrClient, verb string, r FlickrResponse) error {
+	err := do(ctx, client, verb, r)
+	if retryOnTimestampRefused(client, r, err) {
+		err = do(ctx, client, verb, r)
+	}
+	return err
+}
+
+func do(ctx context.Context, client *FlickrClient, verb string, r FlickrResponse) error {
+	req, err := newRequest(client, verb)
+	if err != nil {
+		return err
+	}
+	return doRequest(ctx, client, req, r)
+}
+
+// Build a request to client.EndpointUrl carrying the client Args, in the query
+// string for GET requests or in a multipart body for POST requests
+func newRequest(client *FlickrClient, verb string) (*http.Request, error) {
+	if verb != "POST" {
+		return http.NewRequest(verb, client.GetUrl(), nil)
+	}
+
 	// instance an empty request body
 	body := &bytes.Buffer{}
 	// multipart writer to fill the body
@@ -51,10 +122,40 @@ func DoPost(client *FlickrClient, r FlickrResponse) error {
 	}
 	err := writer.Close()
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", client.EndpointUrl, body)
+	if err != nil {
+		return nil, err
 	}
 	// evaluate the content type and the boundary
-	contentType := writer.FormDataContentType()
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req, nil
+}
+
+// Perform a request and unmarshal the response in r
+func doRequest(ctx context.Context, client *FlickrClient, req *http.Request, r FlickrResponse) error {
+	res, err := client.send(ctx, req, client.HTTPClient)
+	if err != nil {
+		return err
+	}
+
+	return parseApiResponse(res, r, client.KeepRawBody)
+}
+
+// Check whether Flickr refused the OAuth timestamp of the last request and, if
+// so, sign the request again and reset the response so that it can be retried.
+func retryOnTimestampRefused(client *FlickrClient, r FlickrResponse, err error) bool {
+	if !timestampRefused(err) {
+		return false
+	}
+	if !client.resignOAuth() {
+		return false
+	}
 
-	return DoPostBody(client, body, contentType, r)
+	r.SetErrorStatus(false)
+	r.SetErrorCode(0)
+	r.SetErrorMsg("")
+	return true
 }
@@ -2,7 +2,15 @@ package flickr
 
 import (
 	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
 	"testing"
+	"time"
+
+	flickErr "gopkg.in/masci/flickr.v2/error"
 )
 
 func TestDoGet(t *testing.T) {
@@ -36,3 +44,74 @@ func TestDoPost(t *testing.T) {
 	params := []string{"fooArg"}
 	AssertParamsInBody(t, fclient, params)
 }
+
+// Mock a Flickr server refusing the first OAuth timestamp it receives and
+// reporting a clock one hour ahead of the local one
+func timestampRefusedMock(body string) (*httptest.Server, *http.Client, *[]string) {
+	var timestamps []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseMultipartForm(1 << 20)
+		timestamps = append(timestamps, r.FormValue("oauth_timestamp"))
+		w.Header().Set("Date", time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+		if len(timestamps) == 1 {
+			fmt.Fprint(w, "oauth_problem=timestamp_refused&oauth_acceptable_timestamps=1-2")
+			return
+		}
+		fmt.Fprint(w, body)
+	}))
+
+	u, _ := url.Parse(server.URL)
+	return server, &http.Client{Transport: RewriteTransport{URL: u}}, &timestamps
+}
+
+func TestDoGetTimestampRefused(t *testing.T) {
+	server, client, timestamps := timestampRefusedMock(`<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"><foo>Foo!</foo></rsp>`)
+	defer server.Close()
+
+	fclient := NewFlickrClient("apikey", "apisecret")
+	fclient.HTTPClient = client
+	fclient.Init()
+	fclient.OAuthSign()
+
+	resp := &FooResponse{}
+	err := DoGet(fclient, resp)
+	Expect(t, err, nil)
+	Expect(t, resp.HasErrors(), false)
+	Expect(t, resp.ErrorCode(), 0)
+	Expect(t, resp.Foo, "Foo!")
+	Expect(t, len(*timestamps), 2)
+
+	ts, _ := strconv.ParseInt((*timestamps)[1], 10, 64)
+	Expect(t, ts >= time.Now().Add(59*time.Minute).Unix(), true)
+}
+
+func TestDoPostTimestampRefused(t *testing.T) {
+	server, client, timestamps := timestampRefusedMock(`<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"></rsp>`)
+	defer server.Close()
+
+	fclient := NewFlickrClient("apikey", "apisecret")
+	fclient.HTTPClient = client
+	fclient.Init()
+	fclient.HTTPVerb = "POST"
+	fclient.OAuthSign()
+
+	err := DoPost(fclient, &FooResponse{})
+	Expect(t, err, nil)
+	Expect(t, len(*timestamps), 2)
+	Expect(t, (*timestamps)[0] != (*timestamps)[1], true)
+}
+
+func TestDoGetTimestampRefusedNotSigned(t *testing.T) {
+	server, client, timestamps := timestampRefusedMock(`<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"></rsp>`)
+	defer server.Close()
+
+	fclient := NewFlickrClient("apikey", "apisecret")
+	fclient.HTTPClient = client
+	fclient.Init()
+	fclient.ApiSign()
+
+	err := DoGet(fclient, &FooResponse{})
+	_, ok := err.(*flickErr.Error)
+	Expect(t, ok, true)
+	Expect(t, len(*timestamps), 1)
+}
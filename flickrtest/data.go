@@ -0,0 +1,168 @@
+package flickrtest
+
+// A Flickr user known by the server
+type User struct {
+	// Flickr ID, generated by the server when empty
+	NSID     string
+	Username string
+	Fullname string
+
+	// sets in the order returned by flickr.photosets.getList
+	sets []string
+}
+
+// A photo or video stored by the server
+type Photo struct {
+	// Photo ID, generated by the server when empty
+	ID string
+	// Flickr ID of the owner
+	Owner    string
+	Secret   string
+	Server   string
+	Farm     string
+	Title    string
+	Tags     []string
+	IsPublic bool
+	IsFriend bool
+	IsFamily bool
+	// Unix timestamp, defaults to the time the photo was added
+	DatePosted int64
+	// MySQL datetime, defaults to DatePosted
+	DateTaken      string
+	Description    string
+	License        string
+	OriginalFormat string
+	Views          int
+	// Either "photo" or "video", defaults to "photo"
+	Media string
+	// Upload options, same values as flickr.UploadParams
+	ContentType int
+	Hidden      int
+	SafetyLevel int
+	// File name and contents for uploaded photos
+	FileName string
+	Content  []byte
+	// Where the photo was taken, nil when it has no location
+	Location *Location
+}
+
+// The location of a photo
+type Location struct {
+	Latitude  float64
+	Longitude float64
+	// From 1 (world) to 16 (street), defaults to 16
+	Accuracy int
+	// Flickr place ID and WOE ID of the place containing the location
+	PlaceID string
+	WoeID   string
+}
+
+// A photoset stored by the server
+type Photoset struct {
+	// Photoset ID, generated by the server when empty
+	ID string
+	// Flickr ID of the owner
+	Owner       string
+	Title       string
+	Description string
+	// ID of the primary photo, defaults to the first photo of the set
+	Primary string
+	// IDs of the photos in the set
+	Photos []string
+	// Unix timestamps, default to the time the set was added
+	DateCreate int64
+	DateUpdate int64
+}
+
+// A group stored by the server
+type Group struct {
+	// Group ID, generated by the server when empty
+	ID   string
+	Name string
+	// Privacy as returned by flickr.groups.pools.getGroups (1 private, 2 invite only public, 3 public)
+	Privacy string
+	// Flickr IDs of the members, only members can add photos to the pool
+	Members []string
+	// Flickr IDs of the admins
+	Admins []string
+	// IDs of the photos in the pool
+	Photos []string
+	// Throttling of pool additions, "none" when empty
+	ThrottleMode  string
+	ThrottleCount int
+	// Additions left before hitting the throttle limit
+	ThrottleRemaining int
+}
+
+// An OAuth token and its grants
+type token struct {
+	secret string
+	nsid   string
+	perms  string
+}
+
+// An OAuth request token waiting to be exchanged for an access token
+type requestToken struct {
+	secret   string
+	verifier string
+	nsid     string
+	perms    string
+}
+
+// Flickr permissions, each level includes the previous ones
+var permLevels = map[string]int{
+	"":       0,
+	"none":   0,
+	"read":   1,
+	"write":  2,
+	"delete": 3,
+}
+
+// Whether granted permissions satisfy the required ones
+func permits(granted, required string) bool {
+	return permLevels[granted] >= permLevels[required]
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+func remove(list []string, s string) []string {
+	ret := list[:0]
+	for _, item := range list {
+		if item != s {
+			ret = append(ret, item)
+		}
+	}
+	return ret
+}
+
+func copyPhoto(p *Photo) Photo {
+	ret := *p
+	ret.Tags = append([]string(nil), p.Tags...)
+	ret.Content = append([]byte(nil), p.Content...)
+	if p.Location != nil {
+		loc := *p.Location
+		ret.Location = &loc
+	}
+	return ret
+}
+
+func copyPhotoset(ps *Photoset) Photoset {
+	ret := *ps
+	ret.Photos = append([]string(nil), ps.Photos...)
+	return ret
+}
+
+func copyGroup(g *Group) Group {
+	ret := *g
+	ret.Members = append([]string(nil), g.Members...)
+	ret.Admins = append([]string(nil), g.Admins...)
+	ret.Photos = append([]string(nil), g.Photos...)
+	return ret
+}
@@ -0,0 +1,884 @@
+package flickrtest
+
+import (
+	"net/url"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// A REST method implemented by the server
+type method struct {
+	// permissions required to call the method, "" when no authentication is needed
+	perms string
+	// return the elements to write in the response, or an error
+	handler func(s *Server, c *call) ([]interface{}, *apiError)
+}
+
+// Implemented methods, handlers are called holding the server lock
+var methods = map[string]method{
+	"flickr.test.echo":  {"", testEcho},
+	"flickr.test.login": {"read", testLogin},
+	"flickr.test.null":  {"read", testNull},
+
+	"flickr.auth.oauth.checkToken":     {"", authCheckToken},
+	"flickr.auth.oauth.getAccessToken": {"", authGetAccessToken},
+
+	"flickr.photos.addTags":  {"write", photosAddTags},
+	"flickr.photos.delete":   {"delete", photosDelete},
+	"flickr.photos.getInfo":  {"", photosGetInfo},
+	"flickr.photos.getSizes": {"", photosGetSizes},
+	"flickr.photos.search":   {"", photosSearch},
+	"flickr.photos.setDates": {"write", photosSetDates},
+	"flickr.photos.setPerms": {"write", photosSetPerms},
+
+	"flickr.photosets.addPhoto":        {"write", photosetsAddPhoto},
+	"flickr.photosets.create":          {"write", photosetsCreate},
+	"flickr.photosets.delete":          {"write", photosetsDelete},
+	"flickr.photosets.editMeta":        {"write", photosetsEditMeta},
+	"flickr.photosets.editPhotos":      {"write", photosetsEditPhotos},
+	"flickr.photosets.getInfo":         {"", photosetsGetInfo},
+	"flickr.photosets.getList":         {"", photosetsGetList},
+	"flickr.photosets.getPhotos":       {"", photosetsGetPhotos},
+	"flickr.photosets.orderSets":       {"write", photosetsOrderSets},
+	"flickr.photosets.removePhoto":     {"write", photosetsRemovePhoto},
+	"flickr.photosets.removePhotos":    {"write", photosetsRemovePhotos},
+	"flickr.photosets.reorderPhotos":   {"write", photosetsReorderPhotos},
+	"flickr.photosets.setPrimaryPhoto": {"write", photosetsSetPrimaryPhoto},
+
+	"flickr.people.getPhotos": {"", peopleGetPhotos},
+
+	"flickr.groups.getInfo":         {"", groupsGetInfo},
+	"flickr.groups.pools.add":       {"write", groupsPoolsAdd},
+	"flickr.groups.pools.getGroups": {"read", groupsPoolsGetGroups},
+}
+
+// Compute pagination for a list of total items. Return the page, the number
+// of items per page, the number of pages and the bounds of the page slice.
+func paginate(params url.Values, total, defaultPerPage, maxPerPage int) (page, perPage, pages, start, end int) {
+	perPage, _ = strconv.Atoi(params.Get("per_page"))
+	if perPage <= 0 {
+		perPage = defaultPerPage
+	}
+	if perPage > maxPerPage {
+		perPage = maxPerPage
+	}
+	page, _ = strconv.Atoi(params.Get("page"))
+	if page < 1 {
+		page = 1
+	}
+	pages = (total + perPage - 1) / perPage
+
+	start = (page - 1) * perPage
+	if start > total {
+		start = total
+	}
+	end = start + perPage
+	if end > total {
+		end = total
+	}
+	return
+}
+
+// Split tags the way Flickr does: comma separated, or space separated with
+// double quotes around tags containing spaces
+func parseTags(tags string) []string {
+	var ret []string
+	if strings.Contains(tags, ",") {
+		for _, tag := range strings.Split(tags, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				ret = append(ret, tag)
+			}
+		}
+		return ret
+	}
+
+	quoted := false
+	current := ""
+	for _, r := range tags {
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case r == ' ' && !quoted:
+			if current != "" {
+				ret = append(ret, current)
+			}
+			current = ""
+		default:
+			current += string(r)
+		}
+	}
+	if current != "" {
+		ret = append(ret, current)
+	}
+	return ret
+}
+
+// Whether the caller can see a photo. Contacts are not modeled, so only
+// owners can see private photos.
+func (c *call) canSee(p *Photo) bool {
+	return p.IsPublic || c.isUser(p.Owner)
+}
+
+// Return a photo visible to the caller, or the Flickr error for unknown photos
+func (s *Server) visiblePhoto(c *call, id string) (*Photo, *apiError) {
+	p := s.photos[id]
+	if p == nil || !c.canSee(p) {
+		return nil, newAPIError(1, "Photo \"%s\" not found (invalid ID)", id)
+	}
+	return p, nil
+}
+
+// Return a photo owned by the caller, or the Flickr error for unknown photos
+func (s *Server) ownPhoto(c *call, id string, code int) (*Photo, *apiError) {
+	p := s.photos[id]
+	if p == nil || !c.isUser(p.Owner) {
+		return nil, newAPIError(code, "Photo \"%s\" not found (invalid ID)", id)
+	}
+	return p, nil
+}
+
+// Return a photoset owned by the caller, or the Flickr error for unknown sets
+func (s *Server) ownPhotoset(c *call) (*Photoset, *apiError) {
+	id := c.params.Get("photoset_id")
+	ps := s.photosets[id]
+	if ps == nil || !c.isUser(ps.Owner) {
+		return nil, newAPIError(1, "Photoset not found")
+	}
+	return ps, nil
+}
+
+// Remove photos from a set fixing the primary photo, sets left empty are deleted
+func (s *Server) removeFromSet(ps *Photoset, ids ...string) {
+	for _, id := range ids {
+		ps.Photos = remove(ps.Photos, id)
+	}
+	ps.DateUpdate = s.now().Unix()
+	if len(ps.Photos) == 0 {
+		s.deletePhotoset(ps)
+		return
+	}
+	if !contains(ps.Photos, ps.Primary) {
+		ps.Primary = ps.Photos[0]
+	}
+}
+
+func (s *Server) deletePhotoset(ps *Photoset) {
+	delete(s.photosets, ps.ID)
+	if owner := s.users[ps.Owner]; owner != nil {
+		owner.sets = remove(owner.sets, ps.ID)
+	}
+}
+
+// Return the user a list method refers to, the caller if user_id is missing or "me"
+func (s *Server) targetUser(c *call) (*User, *apiError) {
+	nsid := c.params.Get("user_id")
+	if nsid == "" || nsid == "me" {
+		if c.user == nil {
+			return nil, newAPIError(2, "Unknown user")
+		}
+		return c.user, nil
+	}
+	u := s.users[nsid]
+	if u == nil {
+		return nil, newAPIError(1, "User not found")
+	}
+	return u, nil
+}
+
+func testEcho(s *Server, c *call) ([]interface{}, *apiError) {
+	keys := make([]string, 0, len(c.params))
+	for k := range c.params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var ret []interface{}
+	for _, k := range keys {
+		x := echoXML{Value: c.params.Get(k)}
+		x.XMLName.Local = k
+		ret = append(ret, x)
+	}
+	return ret, nil
+}
+
+func testLogin(s *Server, c *call) ([]interface{}, *apiError) {
+	return []interface{}{loginXML{ID: c.user.NSID, Username: c.user.Username}}, nil
+}
+
+func testNull(s *Server, c *call) ([]interface{}, *apiError) {
+	return nil, nil
+}
+
+func authCheckToken(s *Server, c *call) ([]interface{}, *apiError) {
+	tok := c.params.Get("oauth_token")
+	t := s.tokens[tok]
+	if t == nil || s.users[t.nsid] == nil {
+		return nil, errInvalidToken
+	}
+
+	user := s.users[t.nsid]
+	x := checkTokenXML{Token: tok, Perms: t.perms}
+	x.User.NSID = user.NSID
+	x.User.Username = user.Username
+	x.User.Fullname = user.Fullname
+	return []interface{}{x}, nil
+}
+
+func authGetAccessToken(s *Server, c *call) ([]interface{}, *apiError) {
+	if c.params.Get("api_sig") == "" {
+		return nil, errInvalidSignature
+	}
+	legacy := c.params.Get("auth_token")
+	t := s.legacyTokens[legacy]
+	if t == nil {
+		return nil, errInvalidToken
+	}
+	delete(s.legacyTokens, legacy)
+
+	x := accessTokenXML{}
+	x.AccessToken.Token = s.newID() + "-" + s.newSecret()
+	x.AccessToken.TokenSecret = s.newSecret()
+	s.tokens[x.AccessToken.Token] = &token{secret: x.AccessToken.TokenSecret, nsid: t.nsid, perms: t.perms}
+	return []interface{}{x}, nil
+}
+
+func photosAddTags(s *Server, c *call) ([]interface{}, *apiError) {
+	p, err := s.ownPhoto(c, c.params.Get("photo_id"), 1)
+	if err != nil {
+		return nil, err
+	}
+	for _, tag := range parseTags(c.params.Get("tags")) {
+		if !contains(p.Tags, tag) {
+			p.Tags = append(p.Tags, tag)
+		}
+	}
+	return nil, nil
+}
+
+func photosDelete(s *Server, c *call) ([]interface{}, *apiError) {
+	p, err := s.ownPhoto(c, c.params.Get("photo_id"), 1)
+	if err != nil {
+		return nil, err
+	}
+	delete(s.photos, p.ID)
+	for _, ps := range s.photosets {
+		if contains(ps.Photos, p.ID) {
+			s.removeFromSet(ps, p.ID)
+		}
+	}
+	for _, g := range s.groups {
+		g.Photos = remove(g.Photos, p.ID)
+	}
+	return nil, nil
+}
+
+func photosGetInfo(s *Server, c *call) ([]interface{}, *apiError) {
+	p, err := s.visiblePhoto(c, c.params.Get("photo_id"))
+	if err != nil {
+		return nil, err
+	}
+	if secret := c.params.Get("secret"); secret != "" && secret != p.Secret {
+		return nil, newAPIError(2, "Invalid secret")
+	}
+	return []interface{}{newPhotoInfoXML(p, s.users[p.Owner])}, nil
+}
+
+func photosGetSizes(s *Server, c *call) ([]interface{}, *apiError) {
+	p, err := s.visiblePhoto(c, c.params.Get("photo_id"))
+	if err != nil {
+		return nil, err
+	}
+	return []interface{}{newSizesXML(p, s.users[p.Owner])}, nil
+}
+
+// Search params supported by flickr.photos.search, at least one is required
+var searchParams = []string{
+	"user_id", "text", "tags", "bbox", "place_id", "woe_id", "has_geo",
+	"min_upload_date", "max_upload_date", "min_taken_date", "max_taken_date",
+}
+
+// Whether a photo has any of the tags, or all of them when all is true
+func matchTags(p *Photo, tags []string, all bool) bool {
+	for _, tag := range tags {
+		found := false
+		for _, t := range p.Tags {
+			if normalizeTag(t) == normalizeTag(tag) {
+				found = true
+				break
+			}
+		}
+		if found != all {
+			return found
+		}
+	}
+	return all
+}
+
+// Whether the title, description or tags of a photo contain text
+func matchText(p *Photo, text string) bool {
+	text = strings.ToLower(text)
+	fields := append([]string{p.Title, p.Description}, p.Tags...)
+	for _, f := range fields {
+		if strings.Contains(strings.ToLower(f), text) {
+			return true
+		}
+	}
+	return false
+}
+
+// Parse a "min_lon,min_lat,max_lon,max_lat" bounding box
+func parseBBox(value string) ([4]float64, bool) {
+	var box [4]float64
+	parts := strings.Split(value, ",")
+	if len(parts) != 4 {
+		return box, false
+	}
+	for i, part := range parts {
+		v, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return box, false
+		}
+		box[i] = v
+	}
+	return box, true
+}
+
+// Whether a photo matches the geo params of a search
+func matchGeo(p *Photo, params url.Values, box *[4]float64) bool {
+	loc := p.Location
+	if params.Get("has_geo") == "1" && loc == nil {
+		return false
+	}
+	if box != nil {
+		if loc == nil || loc.Longitude < box[0] || loc.Latitude < box[1] ||
+			loc.Longitude > box[2] || loc.Latitude > box[3] {
+			return false
+		}
+		if acc, err := strconv.Atoi(params.Get("accuracy")); err == nil && loc.Accuracy < acc {
+			return false
+		}
+	}
+	if id := params.Get("place_id"); id != "" && (loc == nil || loc.PlaceID != id) {
+		return false
+	}
+	if id := params.Get("woe_id"); id != "" && (loc == nil || loc.WoeID != id) {
+		return false
+	}
+	return true
+}
+
+func photosSearch(s *Server, c *call) ([]interface{}, *apiError) {
+	parameterless := true
+	for _, name := range searchParams {
+		if c.params.Get(name) != "" {
+			parameterless = false
+		}
+	}
+	if parameterless {
+		return nil, newAPIError(3, "Parameterless searches have been disabled. Please use flickr.photos.getRecent instead.")
+	}
+
+	var owner *User
+	if c.params.Get("user_id") != "" {
+		var err *apiError
+		if owner, err = s.targetUser(c); err != nil {
+			return nil, err
+		}
+	}
+	var box *[4]float64
+	if value := c.params.Get("bbox"); value != "" {
+		b, ok := parseBBox(value)
+		if !ok {
+			return nil, newAPIError(4, "Not a valid bbox")
+		}
+		box = &b
+	}
+	tags := parseTags(c.params.Get("tags"))
+	all := c.params.Get("tag_mode") == "all"
+	text := c.params.Get("text")
+
+	var found []*Photo
+	for _, p := range s.photos {
+		if !c.canSee(p) || (owner != nil && p.Owner != owner.NSID) {
+			continue
+		}
+		if len(tags) > 0 && !matchTags(p, tags, all) {
+			continue
+		}
+		if text != "" && !matchText(p, text) {
+			continue
+		}
+		if !matchGeo(p, c.params, box) || !matchDates(p, c.params) {
+			continue
+		}
+		found = append(found, p)
+	}
+	sortPhotos(found)
+
+	extras := splitIDs(c.params.Get("extras"))
+	page, perPage, pages, start, end := paginate(c.params, len(found), 100, 500)
+	x := photoListXML{Page: page, Pages: pages, PerPage: perPage, Total: len(found)}
+	for _, p := range found[start:end] {
+		x.Photos = append(x.Photos, newListPhotoXML(p, s.users[p.Owner], extras))
+	}
+	return []interface{}{x}, nil
+}
+
+func photosSetDates(s *Server, c *call) ([]interface{}, *apiError) {
+	p, err := s.ownPhoto(c, c.params.Get("photo_id"), 1)
+	if err != nil {
+		return nil, err
+	}
+	if posted := c.params.Get("date_posted"); posted != "" {
+		ts, convErr := strconv.ParseInt(posted, 10, 64)
+		if convErr != nil {
+			return nil, newAPIError(2, "Not a valid date string")
+		}
+		p.DatePosted = ts
+	}
+	if taken := c.params.Get("date_taken"); taken != "" {
+		if _, parseErr := time.Parse("2006-01-02 15:04:05", taken); parseErr != nil {
+			return nil, newAPIError(2, "Not a valid date string")
+		}
+		p.DateTaken = taken
+	}
+	return nil, nil
+}
+
+func photosSetPerms(s *Server, c *call) ([]interface{}, *apiError) {
+	p, err := s.ownPhoto(c, c.params.Get("photo_id"), 1)
+	if err != nil {
+		return nil, err
+	}
+	for _, param := range []string{"is_public", "is_friend", "is_family"} {
+		if c.params.Get(param) == "" {
+			return nil, newAPIError(2, "Required arguments missing")
+		}
+	}
+	p.IsPublic = c.params.Get("is_public") == "1"
+	p.IsFriend = c.params.Get("is_friend") == "1"
+	p.IsFamily = c.params.Get("is_family") == "1"
+	return nil, nil
+}
+
+func photosetsAddPhoto(s *Server, c *call) ([]interface{}, *apiError) {
+	ps, err := s.ownPhotoset(c)
+	if err != nil {
+		return nil, err
+	}
+	p, err := s.ownPhoto(c, c.params.Get("photo_id"), 2)
+	if err != nil {
+		return nil, err
+	}
+	if contains(ps.Photos, p.ID) {
+		return nil, newAPIError(3, "Photo already in set")
+	}
+	ps.Photos = append(ps.Photos, p.ID)
+	ps.DateUpdate = s.now().Unix()
+	return nil, nil
+}
+
+func photosetsCreate(s *Server, c *call) ([]interface{}, *apiError) {
+	title := c.params.Get("title")
+	if title == "" {
+		return nil, newAPIError(2, "No title specified")
+	}
+	p, err := s.ownPhoto(c, c.params.Get("primary_photo_id"), 3)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now().Unix()
+	ps := &Photoset{
+		ID:          s.newID(),
+		Owner:       c.user.NSID,
+		Title:       title,
+		Description: c.params.Get("description"),
+		Primary:     p.ID,
+		Photos:      []string{p.ID},
+		DateCreate:  now,
+		DateUpdate:  now,
+	}
+	s.photosets[ps.ID] = ps
+	c.user.sets = append([]string{ps.ID}, c.user.sets...)
+
+	x := createdPhotosetXML{
+		ID:  ps.ID,
+		URL: "https://www.flickr.com/photos/" + c.user.NSID + "/sets/" + ps.ID + "/",
+	}
+	return []interface{}{x}, nil
+}
+
+func photosetsDelete(s *Server, c *call) ([]interface{}, *apiError) {
+	ps, err := s.ownPhotoset(c)
+	if err != nil {
+		return nil, err
+	}
+	s.deletePhotoset(ps)
+	return nil, nil
+}
+
+func photosetsEditMeta(s *Server, c *call) ([]interface{}, *apiError) {
+	ps, err := s.ownPhotoset(c)
+	if err != nil {
+		return nil, err
+	}
+	title := c.params.Get("title")
+	if title == "" {
+		return nil, newAPIError(2, "No title specified")
+	}
+	ps.Title = title
+	if _, ok := c.params["description"]; ok {
+		ps.Description = c.params.Get("description")
+	}
+	ps.DateUpdate = s.now().Unix()
+	return nil, nil
+}
+
+func photosetsEditPhotos(s *Server, c *call) ([]interface{}, *apiError) {
+	ps, err := s.ownPhotoset(c)
+	if err != nil {
+		return nil, err
+	}
+	ids := splitIDs(c.params.Get("photo_ids"))
+	for _, id := range ids {
+		if _, err := s.ownPhoto(c, id, 2); err != nil {
+			return nil, err
+		}
+	}
+	primary := c.params.Get("primary_photo_id")
+	if !contains(ids, primary) {
+		return nil, newAPIError(3, "Primary photo not in list")
+	}
+	ps.Photos = ids
+	ps.Primary = primary
+	ps.DateUpdate = s.now().Unix()
+	return nil, nil
+}
+
+func photosetsGetInfo(s *Server, c *call) ([]interface{}, *apiError) {
+	ps := s.photosets[c.params.Get("photoset_id")]
+	if ps == nil {
+		return nil, newAPIError(1, "Photoset not found")
+	}
+	return []interface{}{newPhotosetXML(ps, s.users[ps.Owner], s.photos)}, nil
+}
+
+func photosetsGetList(s *Server, c *call) ([]interface{}, *apiError) {
+	user, err := s.targetUser(c)
+	if err != nil {
+		return nil, err
+	}
+
+	page, perPage, pages, start, end := paginate(c.params, len(user.sets), 500, 500)
+	x := photosetListXML{Page: page, Pages: pages, PerPage: perPage, Total: len(user.sets)}
+	for _, id := range user.sets[start:end] {
+		x.Photosets = append(x.Photosets, newPhotosetXML(s.photosets[id], user, s.photos))
+	}
+	return []interface{}{x}, nil
+}
+
+func photosetsGetPhotos(s *Server, c *call) ([]interface{}, *apiError) {
+	ps := s.photosets[c.params.Get("photoset_id")]
+	if ps == nil {
+		return nil, newAPIError(1, "Photoset not found")
+	}
+	owner := s.users[ps.Owner]
+
+	var visible []*Photo
+	for _, id := range ps.Photos {
+		if p := s.photos[id]; p != nil && c.canSee(p) {
+			visible = append(visible, p)
+		}
+	}
+
+	extras := splitIDs(c.params.Get("extras"))
+	page, perPage, pages, start, end := paginate(c.params, len(visible), 500, 500)
+	x := photosetPhotosXML{
+		ID:        ps.ID,
+		Primary:   ps.Primary,
+		Owner:     owner.NSID,
+		OwnerName: owner.Username,
+		Title:     ps.Title,
+		Page:      page,
+		PerPage:   perPage,
+		Pages:     pages,
+		Total:     len(visible),
+	}
+	for _, p := range visible[start:end] {
+		item := newListPhotoXML(p, owner, extras)
+		// photos in sets don't report their owner, it's the set one
+		item.Owner = ""
+		isPrimary := flag(p.ID == ps.Primary)
+		item.IsPrimary = &isPrimary
+		x.Photos = append(x.Photos, item)
+	}
+	return []interface{}{x}, nil
+}
+
+func photosetsOrderSets(s *Server, c *call) ([]interface{}, *apiError) {
+	ids := splitIDs(c.params.Get("photoset_ids"))
+	for _, id := range ids {
+		if ps := s.photosets[id]; ps == nil || ps.Owner != c.user.NSID {
+			return nil, newAPIError(1, "Set not found")
+		}
+	}
+
+	// sets not in the list go at the end, ordered by ID
+	var rest []string
+	for _, id := range c.user.sets {
+		if !contains(ids, id) {
+			rest = append(rest, id)
+		}
+	}
+	sort.Strings(rest)
+	c.user.sets = append(ids, rest...)
+	return nil, nil
+}
+
+func photosetsRemovePhoto(s *Server, c *call) ([]interface{}, *apiError) {
+	ps, err := s.ownPhotoset(c)
+	if err != nil {
+		return nil, err
+	}
+	p, err := s.ownPhoto(c, c.params.Get("photo_id"), 2)
+	if err != nil {
+		return nil, err
+	}
+	if !contains(ps.Photos, p.ID) {
+		return nil, newAPIError(3, "Photo not in set")
+	}
+	s.removeFromSet(ps, p.ID)
+	return nil, nil
+}
+
+func photosetsRemovePhotos(s *Server, c *call) ([]interface{}, *apiError) {
+	ps, err := s.ownPhotoset(c)
+	if err != nil {
+		return nil, err
+	}
+	ids := splitIDs(c.params.Get("photo_ids"))
+	for _, id := range ids {
+		if _, err := s.ownPhoto(c, id, 2); err != nil {
+			return nil, err
+		}
+		if !contains(ps.Photos, id) {
+			return nil, newAPIError(3, "Photo not in set")
+		}
+	}
+	s.removeFromSet(ps, ids...)
+	return nil, nil
+}
+
+func photosetsReorderPhotos(s *Server, c *call) ([]interface{}, *apiError) {
+	ps, err := s.ownPhotoset(c)
+	if err != nil {
+		return nil, err
+	}
+	ids := splitIDs(c.params.Get("photo_ids"))
+	for _, id := range ids {
+		if !contains(ps.Photos, id) {
+			return nil, newAPIError(2, "Photo not in set")
+		}
+	}
+	// photos not in the list keep their relative order at the end
+	var rest []string
+	for _, id := range ps.Photos {
+		if !contains(ids, id) {
+			rest = append(rest, id)
+		}
+	}
+	ps.Photos = append(ids, rest...)
+	ps.DateUpdate = s.now().Unix()
+	return nil, nil
+}
+
+func photosetsSetPrimaryPhoto(s *Server, c *call) ([]interface{}, *apiError) {
+	ps, err := s.ownPhotoset(c)
+	if err != nil {
+		return nil, err
+	}
+	id := c.params.Get("photo_id")
+	if !contains(ps.Photos, id) {
+		return nil, newAPIError(2, "Photo not found")
+	}
+	ps.Primary = id
+	ps.DateUpdate = s.now().Unix()
+	return nil, nil
+}
+
+// Privacy filters of flickr.people.getPhotos
+func matchPrivacy(p *Photo, filter string) bool {
+	switch filter {
+	case "1":
+		return p.IsPublic
+	case "2":
+		return !p.IsPublic && p.IsFriend && !p.IsFamily
+	case "3":
+		return !p.IsPublic && !p.IsFriend && p.IsFamily
+	case "4":
+		return !p.IsPublic && p.IsFriend && p.IsFamily
+	case "5":
+		return !p.IsPublic && !p.IsFriend && !p.IsFamily
+	}
+	return true
+}
+
+// Parse a date param which can be either a unix timestamp or a MySQL datetime
+func parseDate(value string) (time.Time, bool) {
+	if value == "" {
+		return time.Time{}, false
+	}
+	if ts, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(ts, 0), true
+	}
+	t, err := time.Parse("2006-01-02 15:04:05", value)
+	return t, err == nil
+}
+
+// Whether a photo matches the upload and taken date ranges of a search
+func matchDates(p *Photo, params url.Values) bool {
+	posted := time.Unix(p.DatePosted, 0)
+	taken, _ := time.Parse("2006-01-02 15:04:05", p.DateTaken)
+	if min, ok := parseDate(params.Get("min_upload_date")); ok && posted.Before(min) {
+		return false
+	}
+	if max, ok := parseDate(params.Get("max_upload_date")); ok && posted.After(max) {
+		return false
+	}
+	if min, ok := parseDate(params.Get("min_taken_date")); ok && taken.Before(min) {
+		return false
+	}
+	if max, ok := parseDate(params.Get("max_taken_date")); ok && taken.After(max) {
+		return false
+	}
+	return true
+}
+
+// Sort photos by upload date, most recent first
+func sortPhotos(photos []*Photo) {
+	sort.Slice(photos, func(i, j int) bool {
+		if photos[i].DatePosted != photos[j].DatePosted {
+			return photos[i].DatePosted > photos[j].DatePosted
+		}
+		return photos[i].ID > photos[j].ID
+	})
+}
+
+func peopleGetPhotos(s *Server, c *call) ([]interface{}, *apiError) {
+	user, err := s.targetUser(c)
+	if err != nil {
+		return nil, err
+	}
+
+	var found []*Photo
+	for _, p := range s.photos {
+		if p.Owner != user.NSID || !c.canSee(p) {
+			continue
+		}
+		if !matchPrivacy(p, c.params.Get("privacy_filter")) || !matchDates(p, c.params) {
+			continue
+		}
+		found = append(found, p)
+	}
+	sortPhotos(found)
+
+	extras := splitIDs(c.params.Get("extras"))
+	page, perPage, pages, start, end := paginate(c.params, len(found), 100, 500)
+	x := photoListXML{Page: page, Pages: pages, PerPage: perPage, Total: len(found)}
+	for _, p := range found[start:end] {
+		x.Photos = append(x.Photos, newListPhotoXML(p, user, extras))
+	}
+	return []interface{}{x}, nil
+}
+
+func groupsGetInfo(s *Server, c *call) ([]interface{}, *apiError) {
+	g := s.groups[c.params.Get("group_id")]
+	if g == nil {
+		return nil, newAPIError(1, "Group not found")
+	}
+	return []interface{}{newGroupInfoXML(g)}, nil
+}
+
+func groupsPoolsAdd(s *Server, c *call) ([]interface{}, *apiError) {
+	p, err := s.ownPhoto(c, c.params.Get("photo_id"), 1)
+	if err != nil {
+		return nil, err
+	}
+	g := s.groups[c.params.Get("group_id")]
+	if g == nil || !contains(g.Members, c.user.NSID) {
+		return nil, newAPIError(2, "Group not found")
+	}
+	if contains(g.Photos, p.ID) {
+		return nil, newAPIError(3, "Photo already in pool")
+	}
+	if g.ThrottleMode != "none" {
+		if g.ThrottleRemaining <= 0 {
+			return nil, newAPIError(5, "Photo limit reached")
+		}
+		g.ThrottleRemaining--
+	}
+	g.Photos = append(g.Photos, p.ID)
+	return nil, nil
+}
+
+func groupsPoolsGetGroups(s *Server, c *call) ([]interface{}, *apiError) {
+	var found []*Group
+	for _, g := range s.groups {
+		if contains(g.Members, c.user.NSID) {
+			found = append(found, g)
+		}
+	}
+	sort.Slice(found, func(i, j int) bool { return found[i].Name < found[j].Name })
+
+	page, perPage, pages, start, end := paginate(c.params, len(found), 400, 400)
+	x := poolGroupsXML{Page: page, Pages: pages, PerPage: perPage, Total: len(found)}
+	for _, g := range found[start:end] {
+		x.Groups = append(x.Groups, poolGroupXML{
+			NSID:        g.ID,
+			ID:          g.ID,
+			Name:        g.Name,
+			Member:      1,
+			Admin:       flag(contains(g.Admins, c.user.NSID)),
+			Privacy:     g.Privacy,
+			Photos:      len(g.Photos),
+			IconServer:  "0",
+			IconFarm:    "0",
+			MemberCount: len(g.Members),
+			PoolCount:   len(g.Photos),
+		})
+	}
+	return []interface{}{x}, nil
+}
+
+// Store an uploaded photo, see https://www.flickr.com/services/api/upload.api.html
+func upload(s *Server, c *call) ([]interface{}, *apiError) {
+	p := &Photo{
+		Owner:       c.user.NSID,
+		Title:       c.params.Get("title"),
+		Description: c.params.Get("description"),
+		Tags:        parseTags(c.params.Get("tags")),
+		FileName:    c.fileName,
+		Content:     c.file,
+	}
+	// Flickr defaults titles to the file name
+	if p.Title == "" {
+		p.Title = strings.TrimSuffix(c.fileName, filepath.Ext(c.fileName))
+	}
+	if ext := strings.TrimPrefix(filepath.Ext(c.fileName), "."); ext != "" {
+		p.OriginalFormat = strings.ToLower(ext)
+	}
+	// privacy defaults to public when not specified
+	p.IsPublic = c.params.Get("is_public") != "0"
+	p.IsFriend = c.params.Get("is_friend") == "1"
+	p.IsFamily = c.params.Get("is_family") == "1"
+	p.ContentType, _ = strconv.Atoi(c.params.Get("content_type"))
+	p.Hidden, _ = strconv.Atoi(c.params.Get("hidden"))
+	p.SafetyLevel, _ = strconv.Atoi(c.params.Get("safety_level"))
+
+	s.addPhoto(p)
+	return []interface{}{photoIDXML{ID: p.ID}}, nil
+}
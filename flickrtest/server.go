@@ -0,0 +1,650 @@
+// Package flickrtest provides a stateful, in-memory Flickr server to run
+// integration tests offline.
+//
+// The server keeps users, photos, photosets and groups, dispatches REST calls
+// on the "method" param and implements the methods of the auth/oauth, photos,
+// photosets and test packages, flickr.people.getPhotos and the groups methods,
+// along with multipart photo uploads and the OAuth token
+// endpoints. Use Server.Client to get a FlickrClient pointed at it.
+//
+// Other methods, like the ones of the comments, contacts, favorites or
+// galleries packages, are not implemented and fail with error 112, "Method
+// not found": test them against recorded responses instead.
+package flickrtest
+
+import (
+	"crypto/md5"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/masci/flickr.v2"
+)
+
+// Paths served, matching the ones of the real Flickr endpoints
+const (
+	restPath         = "/services/rest"
+	uploadPath       = "/services/upload"
+	requestTokenPath = "/services/oauth/request_token"
+	authorizePath    = "/services/oauth/authorize"
+	accessTokenPath  = "/services/oauth/access_token"
+)
+
+// An in-memory Flickr server
+type Server struct {
+	// Base URL of the server, like http://127.0.0.1:4242
+	URL string
+	// Credentials of the application allowed to call the server
+	APIKey    string
+	APISecret string
+	// Return the server time, used to check OAuth timestamps and reported in
+	// the Date header of responses. Defaults to time.Now when nil, set it to
+	// simulate a clock skew between clients and Flickr.
+	Clock func() time.Time
+
+	srv      *httptest.Server
+	verifier *flickr.OAuthVerifier
+
+	mu            sync.Mutex
+	lastID        int64
+	users         map[string]*User
+	photos        map[string]*Photo
+	photosets     map[string]*Photoset
+	groups        map[string]*Group
+	tokens        map[string]*token
+	requestTokens map[string]*requestToken
+	legacyTokens  map[string]*token
+}
+
+// Start a new server, callers should call Close when finished
+func NewServer() *Server {
+	s := &Server{
+		APIKey:        "flickrtest-api-key",
+		APISecret:     "flickrtest-api-secret",
+		lastID:        10000000000,
+		users:         map[string]*User{},
+		photos:        map[string]*Photo{},
+		photosets:     map[string]*Photoset{},
+		groups:        map[string]*Group{},
+		tokens:        map[string]*token{},
+		requestTokens: map[string]*requestToken{},
+		legacyTokens:  map[string]*token{},
+	}
+	s.verifier = &flickr.OAuthVerifier{
+		TokenSecret: s.tokenSecret,
+		Clock:       s.now,
+	}
+	s.srv = httptest.NewServer(s)
+	s.URL = s.srv.URL
+	return s
+}
+
+func (s *Server) now() time.Time {
+	if s.Clock != nil {
+		return s.Clock()
+	}
+	return time.Now()
+}
+
+// Return the secret of an access or request token, caller must hold the lock
+func (s *Server) tokenSecret(tok string) (string, bool) {
+	if t := s.tokens[tok]; t != nil {
+		return t.secret, true
+	}
+	if t := s.requestTokens[tok]; t != nil {
+		return t.secret, true
+	}
+	return "", false
+}
+
+// Shut down the server
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// Return the endpoints served by the server, to set as FlickrClient.Endpoints
+func (s *Server) Endpoints() flickr.Endpoints {
+	return flickr.Endpoints{
+		API:          s.URL + restPath,
+		Upload:       s.URL + uploadPath + "/",
+		Authorize:    s.URL + authorizePath,
+		RequestToken: s.URL + requestTokenPath,
+		AccessToken:  s.URL + accessTokenPath,
+	}
+}
+
+// Return an HTTP client to perform requests against the server
+func (s *Server) HTTPClient() *http.Client {
+	return s.srv.Client()
+}
+
+// Return a FlickrClient with the server application credentials, performing
+// requests against the server
+func (s *Server) Client() *flickr.FlickrClient {
+	client := flickr.NewFlickrClient(s.APIKey, s.APISecret)
+	client.HTTPClient = s.HTTPClient()
+	client.Endpoints = s.Endpoints()
+	return client
+}
+
+// Return a FlickrClient authenticated as the user with the given Flickr ID
+// and permissions ("read", "write" or "delete")
+func (s *Server) UserClient(nsid, perms string) *flickr.FlickrClient {
+	client := s.Client()
+	client.OAuthToken, client.OAuthTokenSecret = s.IssueToken(nsid, perms)
+	client.Id = nsid
+	return client
+}
+
+// Generate a new numeric ID, caller must hold the lock
+func (s *Server) newID() string {
+	s.lastID++
+	return strconv.FormatInt(s.lastID, 10)
+}
+
+// Generate a random looking hex string, caller must hold the lock
+func (s *Server) newSecret() string {
+	return fmt.Sprintf("%x", md5.Sum([]byte(s.newID()+s.APISecret)))[:16]
+}
+
+// Add a user to the server and return it
+func (s *Server) AddUser(u User) User {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if u.NSID == "" {
+		u.NSID = s.newID() + "@N00"
+	}
+	if u.Username == "" {
+		u.Username = u.NSID
+	}
+	u.sets = nil
+	s.users[u.NSID] = &u
+	return u
+}
+
+// Add a photo to the server and return it. The owner must exist.
+func (s *Server) AddPhoto(p Photo) (Photo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.users[p.Owner] == nil {
+		return Photo{}, fmt.Errorf("flickrtest: unknown user %q", p.Owner)
+	}
+	s.addPhoto(&p)
+	return copyPhoto(&p), nil
+}
+
+// Store a photo filling in defaults, caller must hold the lock
+func (s *Server) addPhoto(p *Photo) {
+	if p.ID == "" {
+		p.ID = s.newID()
+	}
+	if p.Secret == "" {
+		p.Secret = s.newSecret()[:10]
+	}
+	if p.Server == "" {
+		p.Server = "65535"
+	}
+	if p.Farm == "" {
+		p.Farm = "66"
+	}
+	if p.DatePosted == 0 {
+		p.DatePosted = s.now().Unix()
+	}
+	if p.DateTaken == "" {
+		p.DateTaken = time.Unix(p.DatePosted, 0).UTC().Format("2006-01-02 15:04:05")
+	}
+	if p.Media == "" {
+		p.Media = "photo"
+	}
+	if p.Location != nil && p.Location.Accuracy == 0 {
+		p.Location.Accuracy = 16
+	}
+	if p.OriginalFormat == "" {
+		p.OriginalFormat = "jpg"
+	}
+	if p.License == "" {
+		p.License = "0"
+	}
+	s.photos[p.ID] = p
+}
+
+// Return a copy of the photo with the given ID
+func (s *Server) Photo(id string) (Photo, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, ok := s.photos[id]
+	if !ok {
+		return Photo{}, false
+	}
+	return copyPhoto(p), true
+}
+
+// Add a photoset to the server and return it. The owner and the photos must exist.
+func (s *Server) AddPhotoset(ps Photoset) (Photoset, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	owner := s.users[ps.Owner]
+	if owner == nil {
+		return Photoset{}, fmt.Errorf("flickrtest: unknown user %q", ps.Owner)
+	}
+	for _, id := range ps.Photos {
+		if s.photos[id] == nil {
+			return Photoset{}, fmt.Errorf("flickrtest: unknown photo %q", id)
+		}
+	}
+	if ps.ID == "" {
+		ps.ID = s.newID()
+	}
+	if ps.Primary == "" && len(ps.Photos) > 0 {
+		ps.Primary = ps.Photos[0]
+	}
+	if ps.DateCreate == 0 {
+		ps.DateCreate = s.now().Unix()
+	}
+	if ps.DateUpdate == 0 {
+		ps.DateUpdate = ps.DateCreate
+	}
+	ps.Photos = append([]string(nil), ps.Photos...)
+	s.photosets[ps.ID] = &ps
+	owner.sets = append(owner.sets, ps.ID)
+	return copyPhotoset(&ps), nil
+}
+
+// Return a copy of the photoset with the given ID
+func (s *Server) Photoset(id string) (Photoset, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ps, ok := s.photosets[id]
+	if !ok {
+		return Photoset{}, false
+	}
+	return copyPhotoset(ps), true
+}
+
+// Add a group to the server and return it
+func (s *Server) AddGroup(g Group) Group {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if g.ID == "" {
+		g.ID = s.newID() + "@N01"
+	}
+	if g.Privacy == "" {
+		g.Privacy = "3"
+	}
+	if g.ThrottleMode == "" {
+		g.ThrottleMode = "none"
+	}
+	g.Members = append([]string(nil), g.Members...)
+	g.Admins = append([]string(nil), g.Admins...)
+	g.Photos = append([]string(nil), g.Photos...)
+	s.groups[g.ID] = &g
+	return copyGroup(&g)
+}
+
+// Return a copy of the group with the given ID
+func (s *Server) Group(id string) (Group, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	g, ok := s.groups[id]
+	if !ok {
+		return Group{}, false
+	}
+	return copyGroup(g), true
+}
+
+// Issue an OAuth access token for the user with the given Flickr ID and
+// permissions, return the token and its secret
+func (s *Server) IssueToken(nsid, perms string) (string, string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tok := s.newID() + "-" + s.newSecret()
+	secret := s.newSecret()
+	s.tokens[tok] = &token{secret: secret, nsid: nsid, perms: perms}
+	return tok, secret
+}
+
+// Issue a token of the old Authentication API, that can be exchanged with
+// flickr.auth.oauth.getAccessToken
+func (s *Server) IssueLegacyToken(nsid, perms string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tok := s.newID() + "-" + s.newSecret()
+	s.legacyTokens[tok] = &token{nsid: nsid, perms: perms}
+	return tok
+}
+
+// Simulate the user with the given Flickr ID granting permissions to the
+// application that obtained the request token. Return the OAuth verifier
+// to exchange for an access token.
+func (s *Server) Authorize(requestToken, nsid, perms string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	req := s.requestTokens[requestToken]
+	if req == nil {
+		return "", fmt.Errorf("flickrtest: unknown request token %q", requestToken)
+	}
+	if s.users[nsid] == nil {
+		return "", fmt.Errorf("flickrtest: unknown user %q", nsid)
+	}
+	req.nsid = nsid
+	req.perms = perms
+	req.verifier = s.newSecret()
+	return req.verifier, nil
+}
+
+// Implement http.Handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// params might come from the query string, an urlencoded or a multipart body
+	if err := r.ParseMultipartForm(32 << 20); err != nil && err != http.ErrNotMultipart {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	w.Header().Set("Date", s.now().UTC().Format(http.TimeFormat))
+
+	// like Flickr, OAuth problems are reported as plain text whatever the endpoint
+	if r.Form.Get("oauth_signature") != "" {
+		s.verifier.ConsumerKey = s.APIKey
+		s.verifier.ConsumerSecret = s.APISecret
+		err := s.verifier.Verify(r)
+		if verr, ok := err.(*flickr.VerificationError); ok && path.Clean(r.URL.Path) == restPath &&
+			(verr.Problem == flickr.ProblemConsumerKeyUnknown || verr.Problem == flickr.ProblemTokenRejected) {
+			// the REST API reports unknown keys and tokens with its own error codes
+			err = nil
+		}
+		if err != nil {
+			w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, err.(*flickr.VerificationError).Encode())
+			return
+		}
+	}
+
+	switch path.Clean(r.URL.Path) {
+	case restPath:
+		s.serveREST(w, r)
+	case uploadPath:
+		s.servePhoto(w, r, upload)
+	case requestTokenPath:
+		s.serveRequestToken(w, r)
+	case accessTokenPath:
+		s.serveAccessToken(w, r)
+	case authorizePath:
+		fmt.Fprintln(w, "Use Server.Authorize to grant permissions")
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// An error returned by the REST API
+type apiError struct {
+	Code    int    `xml:"code,attr"`
+	Message string `xml:"msg,attr"`
+}
+
+var (
+	errInvalidSignature = &apiError{96, "Invalid signature"}
+	errInvalidToken     = &apiError{98, "Invalid auth token"}
+	errPermissions      = &apiError{99, "Insufficient permissions. Method requires %s privileges; %s granted."}
+	errInvalidKey       = &apiError{100, "Invalid API Key (Key not found)"}
+	errMethodNotFound   = &apiError{112, "Method \"%s\" not found"}
+)
+
+func newAPIError(code int, format string, args ...interface{}) *apiError {
+	return &apiError{code, fmt.Sprintf(format, args...)}
+}
+
+// Envelope of every REST response
+type restResponse struct {
+	XMLName xml.Name      `xml:"rsp"`
+	Stat    string        `xml:"stat,attr"`
+	Err     *apiError     `xml:"err,omitempty"`
+	Payload []interface{} `xml:",omitempty"`
+}
+
+// Write a REST response, payload items become children of the <rsp> element
+func writeResponse(w http.ResponseWriter, err *apiError, payload ...interface{}) {
+	rsp := restResponse{Stat: "ok", Payload: payload}
+	if err != nil {
+		rsp = restResponse{Stat: "fail", Err: err}
+	}
+
+	out, marshalErr := xml.MarshalIndent(rsp, "", "  ")
+	if marshalErr != nil {
+		http.Error(w, marshalErr.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/xml; charset=utf-8")
+	fmt.Fprint(w, xml.Header)
+	w.Write(out)
+}
+
+// Write a raw OAuth response
+func writeOAuth(w http.ResponseWriter, values url.Values) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	fmt.Fprint(w, values.Encode())
+}
+
+// Write an OAuth problem the same way Flickr does, as plain text
+func writeOAuthProblem(w http.ResponseWriter, problem string) {
+	w.WriteHeader(http.StatusUnauthorized)
+	writeOAuth(w, url.Values{"oauth_problem": {problem}})
+}
+
+// Context of a REST call
+type call struct {
+	params url.Values
+	// authenticated user, nil for anonymous calls
+	user *User
+	// permissions granted to the caller
+	perms string
+	// uploaded file, only set for uploads
+	file     []byte
+	fileName string
+}
+
+// Whether the call was performed by the user with the given Flickr ID
+func (c *call) isUser(nsid string) bool {
+	return c.user != nil && c.user.NSID == nsid
+}
+
+// Return the error for calls lacking the required permissions
+func (c *call) permissionError(required string) *apiError {
+	granted := c.perms
+	if granted == "" {
+		granted = "none"
+	}
+	return newAPIError(errPermissions.Code, errPermissions.Message, required, granted)
+}
+
+// Authenticate a request checking application key, signature and token.
+// Caller must hold the lock.
+func (s *Server) authenticate(r *http.Request) (*call, *apiError) {
+	c := &call{params: r.Form}
+
+	key := c.params.Get("api_key")
+	if key == "" {
+		key = c.params.Get("oauth_consumer_key")
+	}
+	if key != s.APIKey {
+		return nil, errInvalidKey
+	}
+
+	if sig := c.params.Get("api_sig"); sig != "" && sig != s.apiSignature(c.params) {
+		return nil, errInvalidSignature
+	}
+
+	// signatures were verified already, the token is only needed to identify the caller
+	if tok := c.params.Get("oauth_token"); tok != "" && c.params.Get("oauth_signature") != "" {
+		t := s.tokens[tok]
+		if t == nil || s.users[t.nsid] == nil {
+			return nil, errInvalidToken
+		}
+		c.user = s.users[t.nsid]
+		c.perms = t.perms
+	}
+
+	return c, nil
+}
+
+// Compute the signature of an api-signed request, see FlickrClient.ApiSign
+func (s *Server) apiSignature(params url.Values) string {
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		if k != "api_sig" {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	base := s.APISecret
+	for _, k := range keys {
+		base += k + params.Get(k)
+	}
+	return fmt.Sprintf("%x", md5.Sum([]byte(base)))
+}
+
+func (s *Server) serveREST(w http.ResponseWriter, r *http.Request) {
+	c, err := s.authenticate(r)
+	if err != nil {
+		writeResponse(w, err)
+		return
+	}
+
+	name := c.params.Get("method")
+	m, ok := methods[name]
+	if !ok {
+		writeResponse(w, newAPIError(errMethodNotFound.Code, errMethodNotFound.Message, name))
+		return
+	}
+	if !permits(c.perms, m.perms) {
+		writeResponse(w, c.permissionError(m.perms))
+		return
+	}
+
+	payload, err := m.handler(s, c)
+	if err != nil {
+		writeResponse(w, err)
+		return
+	}
+	writeResponse(w, nil, payload...)
+}
+
+// Serve photo uploads, handler is called with the file read
+func (s *Server) servePhoto(w http.ResponseWriter, r *http.Request, handler func(s *Server, c *call) ([]interface{}, *apiError)) {
+	c, err := s.authenticate(r)
+	if err != nil {
+		writeResponse(w, err)
+		return
+	}
+	if !permits(c.perms, "write") {
+		writeResponse(w, c.permissionError("write"))
+		return
+	}
+
+	if r.MultipartForm == nil || len(r.MultipartForm.File["photo"]) == 0 {
+		writeResponse(w, newAPIError(2, "No photo specified"))
+		return
+	}
+	header := r.MultipartForm.File["photo"][0]
+	file, openErr := header.Open()
+	if openErr != nil {
+		writeResponse(w, newAPIError(3, "General upload failure"))
+		return
+	}
+	defer file.Close()
+	c.file, _ = ioutil.ReadAll(file)
+	c.fileName = header.Filename
+
+	payload, err := handler(s, c)
+	if err != nil {
+		writeResponse(w, err)
+		return
+	}
+	writeResponse(w, nil, payload...)
+}
+
+func (s *Server) serveRequestToken(w http.ResponseWriter, r *http.Request) {
+	if r.Form.Get("oauth_consumer_key") != s.APIKey {
+		writeOAuthProblem(w, "consumer_key_unknown")
+		return
+	}
+	if r.Form.Get("oauth_signature") == "" {
+		writeOAuth(w, url.Values{"oauth_problem": {"parameter_absent"}, "oauth_parameters_absent": {"oauth_signature"}})
+		return
+	}
+
+	tok := s.newID() + "-" + s.newSecret()
+	secret := s.newSecret()
+	s.requestTokens[tok] = &requestToken{secret: secret}
+
+	writeOAuth(w, url.Values{
+		"oauth_callback_confirmed": {"true"},
+		"oauth_token":              {tok},
+		"oauth_token_secret":       {secret},
+	})
+}
+
+func (s *Server) serveAccessToken(w http.ResponseWriter, r *http.Request) {
+	if r.Form.Get("oauth_consumer_key") != s.APIKey {
+		writeOAuthProblem(w, "consumer_key_unknown")
+		return
+	}
+
+	req := s.requestTokens[r.Form.Get("oauth_token")]
+	if req == nil {
+		writeOAuthProblem(w, "token_rejected")
+		return
+	}
+	if req.verifier == "" || req.verifier != r.Form.Get("oauth_verifier") {
+		writeOAuthProblem(w, "verifier_invalid")
+		return
+	}
+	delete(s.requestTokens, r.Form.Get("oauth_token"))
+
+	user := s.users[req.nsid]
+	tok := s.newID() + "-" + s.newSecret()
+	secret := s.newSecret()
+	s.tokens[tok] = &token{secret: secret, nsid: user.NSID, perms: req.perms}
+
+	writeOAuth(w, url.Values{
+		"fullname":           {user.Fullname},
+		"oauth_token":        {tok},
+		"oauth_token_secret": {secret},
+		"user_nsid":          {user.NSID},
+		"username":           {user.Username},
+	})
+}
+
+// Split a comma separated list of IDs
+func splitIDs(list string) []string {
+	var ret []string
+	for _, id := range strings.Split(list, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ret = append(ret, id)
+		}
+	}
+	return ret
+}
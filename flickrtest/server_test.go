@@ -0,0 +1,349 @@
+package flickrtest
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/masci/flickr.v2"
+	"gopkg.in/masci/flickr.v2/auth/oauth"
+	flickErr "gopkg.in/masci/flickr.v2/error"
+	"gopkg.in/masci/flickr.v2/favorites"
+	"gopkg.in/masci/flickr.v2/groups"
+	"gopkg.in/masci/flickr.v2/people"
+	"gopkg.in/masci/flickr.v2/photos"
+	"gopkg.in/masci/flickr.v2/photosets"
+	"gopkg.in/masci/flickr.v2/test"
+)
+
+// Start a server with a user owning a public and a private photo
+func newTestServer(t *testing.T) (*Server, User, Photo, Photo) {
+	s := NewServer()
+	u := s.AddUser(User{Username: "gopher", Fullname: "Go Pher"})
+	public, err := s.AddPhoto(Photo{Owner: u.NSID, Title: "Public", IsPublic: true, Tags: []string{"go"}})
+	flickr.Expect(t, err, nil)
+	private, err := s.AddPhoto(Photo{Owner: u.NSID, Title: "Private"})
+	flickr.Expect(t, err, nil)
+	return s, u, public, private
+}
+
+func expectAPIError(t *testing.T, err error, resp flickr.FlickrResponse, code int) {
+	_, ok := err.(*flickErr.Error)
+	flickr.Expect(t, ok, true)
+	flickr.Expect(t, resp.HasErrors(), true)
+	flickr.Expect(t, resp.ErrorCode(), code)
+}
+
+func TestOAuthFlow(t *testing.T) {
+	s, u, _, _ := newTestServer(t)
+	defer s.Close()
+
+	client := s.Client()
+	reqTok, err := flickr.GetRequestToken(client)
+	flickr.Expect(t, err, nil)
+
+	verifier, err := s.Authorize(reqTok.OauthToken, u.NSID, "write")
+	flickr.Expect(t, err, nil)
+
+	client = s.Client()
+	accessTok, err := flickr.GetAccessToken(client, reqTok, verifier)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, accessTok.UserNsid, u.NSID)
+	flickr.Expect(t, accessTok.Username, "gopher")
+	flickr.Expect(t, accessTok.Fullname, "Go Pher")
+
+	login, err := test.Login(client)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, login.User.ID, u.NSID)
+	flickr.Expect(t, login.User.Username, "gopher")
+
+	check, err := oauth.CheckToken(s.Client(), accessTok.OAuthToken)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, check.OAuth.Perms, "write")
+	flickr.Expect(t, check.OAuth.User.ID, u.NSID)
+
+	// verifiers can't be reused
+	_, err = flickr.GetAccessToken(s.Client(), reqTok, verifier)
+	_, ok := err.(*flickErr.Error)
+	flickr.Expect(t, ok, true)
+}
+
+func TestLegacyTokenExchange(t *testing.T) {
+	s, u, _, _ := newTestServer(t)
+	defer s.Close()
+
+	legacy := s.IssueLegacyToken(u.NSID, "delete")
+	tok, err := oauth.GetAccessToken(s.Client(), legacy)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, tok.UserNsid, u.NSID)
+
+	_, err = oauth.GetAccessToken(s.Client(), legacy)
+	_, ok := err.(*flickErr.Error)
+	flickr.Expect(t, ok, true)
+}
+
+func TestAuthenticationErrors(t *testing.T) {
+	s, u, _, _ := newTestServer(t)
+	defer s.Close()
+
+	client := s.Client()
+	client.ApiKey = "unknown"
+	resp, err := test.Null(client)
+	expectAPIError(t, err, resp, 100)
+
+	resp, err = test.Null(s.Client())
+	expectAPIError(t, err, resp, 99)
+
+	resp, err = test.Null(s.UserClient(u.NSID, "read"))
+	flickr.Expect(t, err, nil)
+
+	client = s.UserClient(u.NSID, "read")
+	client.OAuthToken = "unknown"
+	resp, err = test.Null(client)
+	expectAPIError(t, err, resp, 98)
+
+	client = s.Client()
+	client.Init()
+	client.Args.Set("method", "flickr.foo.bar")
+	client.ApiSign()
+	resp = &flickr.BasicResponse{}
+	err = flickr.DoGet(client, resp)
+	expectAPIError(t, err, resp, 112)
+}
+
+// Methods outside of the original set are not implemented
+func TestUnimplementedMethod(t *testing.T) {
+	s, u, public, _ := newTestServer(t)
+	defer s.Close()
+
+	resp, err := favorites.Add(s.UserClient(u.NSID, "write"), public.ID)
+	expectAPIError(t, err, resp, 112)
+}
+
+func TestPhotos(t *testing.T) {
+	s, u, public, private := newTestServer(t)
+	defer s.Close()
+
+	info, err := photos.GetInfo(s.Client(), public.ID, "")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, info.Photo.Id, public.ID)
+	flickr.Expect(t, info.Photo.Title, "Public")
+	flickr.Expect(t, info.Photo.Visibility.IsPublic, true)
+	flickr.Expect(t, len(info.Photo.Tags), 1)
+
+	// private photos are only visible to their owner
+	info, err = photos.GetInfo(s.Client(), private.ID, "")
+	expectAPIError(t, err, info, 1)
+	owner := s.UserClient(u.NSID, "delete")
+	info, err = photos.GetInfo(owner, private.ID, "")
+	flickr.Expect(t, err, nil)
+
+	sizes, err := photos.GetSizes(s.Client(), public.ID)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, len(sizes.Sizes), 7)
+	flickr.Expect(t, sizes.Sizes[len(sizes.Sizes)-1].Label, "Original")
+
+	_, err = photos.SetPerms(s.UserClient(u.NSID, "read"), private.ID, 1, 0, 0)
+	_, ok := err.(*flickErr.Error)
+	flickr.Expect(t, ok, true)
+	_, err = photos.SetPerms(owner, private.ID, 1, 0, 1)
+	flickr.Expect(t, err, nil)
+	p, _ := s.Photo(private.ID)
+	flickr.Expect(t, p.IsPublic, true)
+	flickr.Expect(t, p.IsFamily, true)
+
+	_, err = photos.SetDates(owner, private.ID, "1500000000", "2017-07-14 02:40:00")
+	flickr.Expect(t, err, nil)
+	p, _ = s.Photo(private.ID)
+	flickr.Expect(t, p.DatePosted, int64(1500000000))
+	flickr.Expect(t, p.DateTaken, "2017-07-14 02:40:00")
+
+	err = photos.AddTags(owner, private.ID, []string{"golang", "new york"})
+	flickr.Expect(t, err, nil)
+	p, _ = s.Photo(private.ID)
+	flickr.Expect(t, len(p.Tags), 2)
+	flickr.Expect(t, p.Tags[1], "new york")
+
+	_, err = photos.Delete(s.UserClient(u.NSID, "write"), private.ID)
+	_, ok = err.(*flickErr.Error)
+	flickr.Expect(t, ok, true)
+	_, err = photos.Delete(owner, private.ID)
+	flickr.Expect(t, err, nil)
+	_, found := s.Photo(private.ID)
+	flickr.Expect(t, found, false)
+}
+
+func TestPhotosets(t *testing.T) {
+	s, u, public, private := newTestServer(t)
+	defer s.Close()
+	owner := s.UserClient(u.NSID, "write")
+
+	created, err := photosets.Create(owner, "Holidays", "Summer 2016", public.ID)
+	flickr.Expect(t, err, nil)
+	setID := created.Set.Id
+	flickr.Expect(t, setID != "", true)
+
+	_, err = photosets.AddPhoto(owner, setID, private.ID)
+	flickr.Expect(t, err, nil)
+	resp, err := photosets.AddPhoto(owner, setID, private.ID)
+	expectAPIError(t, err, resp, 3)
+
+	info, err := photosets.GetInfo(s.Client(), false, setID, "")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, info.Set.Title, "Holidays")
+	flickr.Expect(t, info.Set.Photos, 2)
+
+	// anonymous callers only see public photos
+	list, err := photosets.GetPhotos(s.Client(), false, setID, u.NSID, 1)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, list.Photoset.Total, 1)
+	list, err = photosets.GetPhotos(owner, true, setID, u.NSID, 1)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, list.Photoset.Total, 2)
+
+	_, err = photosets.EditMeta(owner, setID, "Winter", "")
+	flickr.Expect(t, err, nil)
+	_, err = photosets.EditPhotos(owner, setID, private.ID, []string{private.ID, public.ID})
+	flickr.Expect(t, err, nil)
+	ps, _ := s.Photoset(setID)
+	flickr.Expect(t, ps.Title, "Winter")
+	flickr.Expect(t, ps.Description, "Summer 2016")
+	flickr.Expect(t, ps.Primary, private.ID)
+	flickr.Expect(t, ps.Photos[0], private.ID)
+
+	_, err = photosets.SetPrimaryPhoto(owner, setID, public.ID)
+	flickr.Expect(t, err, nil)
+	_, err = photosets.RemovePhoto(owner, setID, public.ID)
+	flickr.Expect(t, err, nil)
+	ps, _ = s.Photoset(setID)
+	flickr.Expect(t, ps.Primary, private.ID)
+
+	other, err := s.AddPhotoset(Photoset{Owner: u.NSID, Title: "Other", Photos: []string{public.ID}})
+	flickr.Expect(t, err, nil)
+	_, err = photosets.OrderSets(owner, []string{other.ID})
+	flickr.Expect(t, err, nil)
+	sets, err := photosets.GetList(s.Client(), false, u.NSID, 1)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, len(sets.Photosets.Items), 2)
+	flickr.Expect(t, sets.Photosets.Items[0].Id, other.ID)
+
+	_, err = photosets.RemovePhotos(owner, other.ID, []string{public.ID})
+	flickr.Expect(t, err, nil)
+	_, found := s.Photoset(other.ID)
+	flickr.Expect(t, found, false)
+
+	_, err = photosets.Delete(owner, setID)
+	flickr.Expect(t, err, nil)
+	del, err := photosets.Delete(owner, setID)
+	expectAPIError(t, err, del, 1)
+}
+
+func TestPeopleGetPhotos(t *testing.T) {
+	s, u, _, _ := newTestServer(t)
+	defer s.Close()
+
+	resp, err := people.GetPhotos(s.Client(), u.NSID, people.GetPhotosOptionalArgs{})
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, resp.Photos.Total, 1)
+
+	resp, err = people.GetPhotos(s.UserClient(u.NSID, "read"), "me", people.GetPhotosOptionalArgs{PerPage: 1})
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, resp.Photos.Total, 2)
+	flickr.Expect(t, resp.Photos.Pages, 2)
+
+	resp, err = people.GetPhotos(s.Client(), "unknown", people.GetPhotosOptionalArgs{})
+	expectAPIError(t, err, resp, 1)
+}
+
+func TestGroups(t *testing.T) {
+	s, u, public, _ := newTestServer(t)
+	defer s.Close()
+	g := s.AddGroup(Group{Name: "Gophers", Members: []string{u.NSID}, ThrottleMode: "day", ThrottleCount: 1, ThrottleRemaining: 1})
+	s.AddGroup(Group{Name: "Others"})
+	owner := s.UserClient(u.NSID, "write")
+
+	info, err := groups.GetInfo(owner, g.ID)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, info.CanAddPhotos(), true)
+
+	list, err := groups.GetGroups(owner, 0, 0)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, len(list.Groups), 1)
+	flickr.Expect(t, list.Groups[0].Name, "Gophers")
+
+	_, err = groups.AddPhoto(owner, g.ID, public.ID)
+	flickr.Expect(t, err, nil)
+	g, _ = s.Group(g.ID)
+	flickr.Expect(t, len(g.Photos), 1)
+
+	info, err = groups.GetInfo(owner, g.ID)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, info.CanAddPhotos(), false)
+}
+
+func TestUpload(t *testing.T) {
+	s, u, _, _ := newTestServer(t)
+	defer s.Close()
+
+	params := flickr.NewUploadParams()
+	params.Title = "Gopher"
+	params.Tags = []string{"go", `"cute gopher"`}
+	params.IsPublic = true
+	content := []byte("not really a jpeg")
+	resp, err := flickr.UploadReader(s.UserClient(u.NSID, "write"), bytes.NewReader(content), "gopher.jpg", params)
+	flickr.Expect(t, err, nil)
+
+	p, found := s.Photo(resp.ID)
+	flickr.Expect(t, found, true)
+	flickr.Expect(t, p.Owner, u.NSID)
+	flickr.Expect(t, p.Title, "Gopher")
+	flickr.Expect(t, p.IsPublic, true)
+	flickr.Expect(t, p.FileName, "gopher.jpg")
+	flickr.Expect(t, string(p.Content), string(content))
+	flickr.Expect(t, len(p.Tags), 2)
+	flickr.Expect(t, p.Tags[1], "cute gopher")
+
+	resp, err = flickr.UploadReaderWithClient(s.UserClient(u.NSID, "read"), bytes.NewReader(content), "gopher.jpg", nil, s.HTTPClient())
+	expectAPIError(t, err, resp, 99)
+	flickr.Expect(t, resp.ErrorMsg(), "Insufficient permissions. Method requires write privileges; read granted.")
+
+	resp, err = flickr.UploadReaderWithClient(s.Client(), bytes.NewReader(content), "gopher.jpg", nil, s.HTTPClient())
+	expectAPIError(t, err, resp, 99)
+	flickr.Expect(t, resp.ErrorMsg(), "Insufficient permissions. Method requires write privileges; none granted.")
+}
+
+func TestEcho(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	client := s.Client()
+	client.Init()
+	resp, err := test.Echo(client)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, resp.Method, "flickr.test.echo")
+}
+
+func TestSignatureVerification(t *testing.T) {
+	s, u, _, _ := newTestServer(t)
+	defer s.Close()
+
+	client := s.UserClient(u.NSID, "read")
+	client.OAuthTokenSecret = "wrong"
+	resp, err := test.Null(client)
+	_, ok := err.(*flickErr.Error)
+	flickr.Expect(t, ok, true)
+	flickr.Expect(t, strings.Contains(resp.ErrorMsg(), "oauth_problem=signature_invalid"), true)
+}
+
+func TestClockSkew(t *testing.T) {
+	s, u, _, _ := newTestServer(t)
+	defer s.Close()
+	s.Clock = func() time.Time { return time.Now().Add(-2 * time.Hour) }
+
+	// the client learns the server time and signs the request again
+	client := s.UserClient(u.NSID, "read")
+	_, err := test.Login(client)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, client.ClockSkew() < -time.Hour, true)
+}
@@ -0,0 +1,483 @@
+package flickrtest
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// XML representations of the server data, matching the ones returned by Flickr
+
+// Format a boolean the way Flickr does
+func flag(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// A param echoed back by flickr.test.echo
+type echoXML struct {
+	XMLName xml.Name
+	Value   string `xml:",chardata"`
+}
+
+type loginXML struct {
+	XMLName  xml.Name `xml:"user"`
+	ID       string   `xml:"id,attr"`
+	Username string   `xml:"username"`
+}
+
+type checkTokenXML struct {
+	XMLName xml.Name `xml:"oauth"`
+	Token   string   `xml:"token"`
+	Perms   string   `xml:"perms"`
+	User    struct {
+		NSID     string `xml:"nsid,attr"`
+		Username string `xml:"username,attr"`
+		Fullname string `xml:"fullname,attr"`
+	} `xml:"user"`
+}
+
+type accessTokenXML struct {
+	XMLName     xml.Name `xml:"auth"`
+	AccessToken struct {
+		Token       string `xml:"oauth_token,attr"`
+		TokenSecret string `xml:"oauth_token_secret,attr"`
+	} `xml:"access_token"`
+}
+
+type tagXML struct {
+	ID         string `xml:"id,attr"`
+	Author     string `xml:"author,attr"`
+	AuthorName string `xml:"authorname,attr"`
+	Raw        string `xml:"raw,attr"`
+	MachineTag int    `xml:"machine_tag,attr"`
+	Value      string `xml:",chardata"`
+}
+
+type photoInfoXML struct {
+	XMLName        xml.Name `xml:"photo"`
+	ID             string   `xml:"id,attr"`
+	Secret         string   `xml:"secret,attr"`
+	Server         string   `xml:"server,attr"`
+	Farm           string   `xml:"farm,attr"`
+	DateUploaded   int64    `xml:"dateuploaded,attr"`
+	IsFavorite     int      `xml:"isfavorite,attr"`
+	License        string   `xml:"license,attr"`
+	SafetyLevel    int      `xml:"safety_level,attr"`
+	Rotation       int      `xml:"rotation,attr"`
+	OriginalSecret string   `xml:"originalsecret,attr"`
+	OriginalFormat string   `xml:"originalformat,attr"`
+	Views          int      `xml:"views,attr"`
+	Media          string   `xml:"media,attr"`
+	Owner          struct {
+		NSID     string `xml:"nsid,attr"`
+		Username string `xml:"username,attr"`
+		Realname string `xml:"realname,attr"`
+	} `xml:"owner"`
+	Title       string `xml:"title"`
+	Description string `xml:"description"`
+	Visibility  struct {
+		IsPublic int `xml:"ispublic,attr"`
+		IsFriend int `xml:"isfriend,attr"`
+		IsFamily int `xml:"isfamily,attr"`
+	} `xml:"visibility"`
+	Dates struct {
+		Posted           int64  `xml:"posted,attr"`
+		Taken            string `xml:"taken,attr"`
+		TakenGranularity int    `xml:"takengranularity,attr"`
+		TakenUnknown     int    `xml:"takenunknown,attr"`
+		LastUpdate       int64  `xml:"lastupdate,attr"`
+	} `xml:"dates"`
+	Comments int      `xml:"comments"`
+	Tags     []tagXML `xml:"tags>tag"`
+	URLs     []struct {
+		Type  string `xml:"type,attr"`
+		Value string `xml:",chardata"`
+	} `xml:"urls>url"`
+}
+
+func newPhotoInfoXML(p *Photo, owner *User) photoInfoXML {
+	x := photoInfoXML{
+		ID:             p.ID,
+		Secret:         p.Secret,
+		Server:         p.Server,
+		Farm:           p.Farm,
+		DateUploaded:   p.DatePosted,
+		License:        p.License,
+		OriginalSecret: p.Secret,
+		OriginalFormat: p.OriginalFormat,
+		Views:          p.Views,
+		Media:          p.Media,
+		Title:          p.Title,
+		Description:    p.Description,
+	}
+	// see photos.PhotoInfo, safety level is one less than the upload one
+	if p.SafetyLevel > 0 {
+		x.SafetyLevel = p.SafetyLevel - 1
+	}
+	x.Owner.NSID = owner.NSID
+	x.Owner.Username = owner.Username
+	x.Owner.Realname = owner.Fullname
+	x.Visibility.IsPublic = flag(p.IsPublic)
+	x.Visibility.IsFriend = flag(p.IsFriend)
+	x.Visibility.IsFamily = flag(p.IsFamily)
+	x.Dates.Posted = p.DatePosted
+	x.Dates.Taken = p.DateTaken
+	x.Dates.LastUpdate = p.DatePosted
+	for i, raw := range p.Tags {
+		x.Tags = append(x.Tags, tagXML{
+			ID:         fmt.Sprintf("%s-%s-%d", owner.NSID, p.ID, i),
+			Author:     owner.NSID,
+			AuthorName: owner.Username,
+			Raw:        raw,
+			MachineTag: flag(strings.Contains(raw, ":") && strings.Contains(raw, "=")),
+			Value:      normalizeTag(raw),
+		})
+	}
+	x.URLs = append(x.URLs, struct {
+		Type  string `xml:"type,attr"`
+		Value string `xml:",chardata"`
+	}{"photopage", fmt.Sprintf("https://www.flickr.com/photos/%s/%s/", owner.NSID, p.ID)})
+	return x
+}
+
+// Return the normalized form of a tag, lowercase and without spaces or punctuation
+func normalizeTag(raw string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(raw) {
+		if r == ' ' || strings.ContainsRune(`"'!?.,;:=-_/\()[]{}`, r) {
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// Available sizes, the ones with an empty suffix use the original secret
+var sizes = []struct {
+	label  string
+	suffix string
+	extra  string
+	max    int
+}{
+	{"Square", "_s", "sq", 75},
+	{"Large Square", "_q", "q", 150},
+	{"Thumbnail", "_t", "t", 100},
+	{"Small", "_m", "s", 240},
+	{"Medium", "", "m", 500},
+	{"Large", "_b", "l", 1024},
+	{"Original", "_o", "o", 0},
+}
+
+// Fake dimensions of the original photo
+const originalWidth, originalHeight = 2048, 1536
+
+// Return URL and dimensions of a photo at the given size
+func sizeOf(p *Photo, suffix string, max int) (string, int, int) {
+	w, h := originalWidth, originalHeight
+	if max > 0 {
+		if suffix == "_s" || suffix == "_q" {
+			w, h = max, max
+		} else {
+			w, h = max, max*originalHeight/originalWidth
+		}
+	}
+	format := "jpg"
+	if suffix == "_o" {
+		format = p.OriginalFormat
+	}
+	src := fmt.Sprintf("https://live.staticflickr.com/%s/%s_%s%s.%s", p.Server, p.ID, p.Secret, suffix, format)
+	return src, w, h
+}
+
+type sizeXML struct {
+	Label  string `xml:"label,attr"`
+	Width  int    `xml:"width,attr"`
+	Height int    `xml:"height,attr"`
+	Source string `xml:"source,attr"`
+	URL    string `xml:"url,attr"`
+	Media  string `xml:"media,attr"`
+}
+
+type sizesXML struct {
+	XMLName     xml.Name  `xml:"sizes"`
+	CanBlog     int       `xml:"canblog,attr"`
+	CanPrint    int       `xml:"canprint,attr"`
+	CanDownload int       `xml:"candownload,attr"`
+	Sizes       []sizeXML `xml:"size"`
+}
+
+func newSizesXML(p *Photo, owner *User) sizesXML {
+	x := sizesXML{CanBlog: 1, CanPrint: 1, CanDownload: 1}
+	for _, size := range sizes {
+		src, w, h := sizeOf(p, size.suffix, size.max)
+		x.Sizes = append(x.Sizes, sizeXML{
+			Label:  size.label,
+			Width:  w,
+			Height: h,
+			Source: src,
+			URL:    fmt.Sprintf("https://www.flickr.com/photos/%s/%s/sizes/%s/", owner.NSID, p.ID, size.extra),
+			Media:  p.Media,
+		})
+	}
+	return x
+}
+
+// A photo in a list, like the ones returned by flickr.people.getPhotos
+type listPhotoXML struct {
+	XMLName   xml.Name   `xml:"photo"`
+	ID        string     `xml:"id,attr"`
+	Owner     string     `xml:"owner,attr,omitempty"`
+	Secret    string     `xml:"secret,attr"`
+	Server    string     `xml:"server,attr"`
+	Farm      string     `xml:"farm,attr"`
+	Title     string     `xml:"title,attr"`
+	IsPrimary *int       `xml:"isprimary,attr"`
+	IsPublic  int        `xml:"ispublic,attr"`
+	IsFriend  int        `xml:"isfriend,attr"`
+	IsFamily  int        `xml:"isfamily,attr"`
+	Extras    []xml.Attr `xml:",any,attr"`
+}
+
+// Build a photo list item adding the requested extras
+func newListPhotoXML(p *Photo, owner *User, extras []string) listPhotoXML {
+	x := listPhotoXML{
+		ID:       p.ID,
+		Owner:    p.Owner,
+		Secret:   p.Secret,
+		Server:   p.Server,
+		Farm:     p.Farm,
+		Title:    p.Title,
+		IsPublic: flag(p.IsPublic),
+		IsFriend: flag(p.IsFriend),
+		IsFamily: flag(p.IsFamily),
+	}
+	attr := func(name, value string) {
+		x.Extras = append(x.Extras, xml.Attr{Name: xml.Name{Local: name}, Value: value})
+	}
+	for _, extra := range extras {
+		switch extra {
+		case "description":
+			attr("description", p.Description)
+		case "license":
+			attr("license", p.License)
+		case "date_upload":
+			attr("dateupload", strconv.FormatInt(p.DatePosted, 10))
+		case "date_taken":
+			attr("datetaken", p.DateTaken)
+		case "owner_name":
+			attr("ownername", owner.Username)
+		case "original_format":
+			attr("originalformat", p.OriginalFormat)
+		case "last_update":
+			attr("lastupdate", strconv.FormatInt(p.DatePosted, 10))
+		case "tags":
+			var tags []string
+			for _, raw := range p.Tags {
+				tags = append(tags, normalizeTag(raw))
+			}
+			attr("tags", strings.Join(tags, " "))
+		case "views":
+			attr("views", strconv.Itoa(p.Views))
+		case "media":
+			attr("media", p.Media)
+		case "geo":
+			if p.Location != nil {
+				attr("latitude", strconv.FormatFloat(p.Location.Latitude, 'f', -1, 64))
+				attr("longitude", strconv.FormatFloat(p.Location.Longitude, 'f', -1, 64))
+				attr("accuracy", strconv.Itoa(p.Location.Accuracy))
+				attr("place_id", p.Location.PlaceID)
+				attr("woeid", p.Location.WoeID)
+			}
+		default:
+			for _, size := range sizes {
+				if extra != "url_"+size.extra {
+					continue
+				}
+				src, w, h := sizeOf(p, size.suffix, size.max)
+				attr("url_"+size.extra, src)
+				attr("height_"+size.extra, strconv.Itoa(h))
+				attr("width_"+size.extra, strconv.Itoa(w))
+			}
+		}
+	}
+	return x
+}
+
+type photoListXML struct {
+	XMLName xml.Name       `xml:"photos"`
+	Page    int            `xml:"page,attr"`
+	Pages   int            `xml:"pages,attr"`
+	PerPage int            `xml:"perpage,attr"`
+	Total   int            `xml:"total,attr"`
+	Photos  []listPhotoXML `xml:"photo"`
+}
+
+type photosetXML struct {
+	XMLName           xml.Name `xml:"photoset"`
+	ID                string   `xml:"id,attr"`
+	Owner             string   `xml:"owner,attr"`
+	Username          string   `xml:"username,attr"`
+	Primary           string   `xml:"primary,attr"`
+	Secret            string   `xml:"secret,attr"`
+	Server            string   `xml:"server,attr"`
+	Farm              string   `xml:"farm,attr"`
+	Photos            int      `xml:"photos,attr"`
+	Videos            int      `xml:"videos,attr"`
+	CountPhotos       int      `xml:"count_photos,attr"`
+	CountVideos       int      `xml:"count_videos,attr"`
+	NeedsInterstitial int      `xml:"needs_interstitial,attr"`
+	VisCanSeeSet      int      `xml:"visibility_can_see_set,attr"`
+	CountViews        int      `xml:"count_views,attr"`
+	CountComments     int      `xml:"count_comments,attr"`
+	CanComment        int      `xml:"can_comment,attr"`
+	DateCreate        int64    `xml:"date_create,attr"`
+	DateUpdate        int64    `xml:"date_update,attr"`
+	Title             string   `xml:"title"`
+	Description       string   `xml:"description"`
+}
+
+// Build a photoset representation, primary photo data are taken from photos
+func newPhotosetXML(ps *Photoset, owner *User, photos map[string]*Photo) photosetXML {
+	x := photosetXML{
+		ID:           ps.ID,
+		Owner:        owner.NSID,
+		Username:     owner.Username,
+		Primary:      ps.Primary,
+		VisCanSeeSet: 1,
+		CanComment:   1,
+		DateCreate:   ps.DateCreate,
+		DateUpdate:   ps.DateUpdate,
+		Title:        ps.Title,
+		Description:  ps.Description,
+	}
+	if primary := photos[ps.Primary]; primary != nil {
+		x.Secret = primary.Secret
+		x.Server = primary.Server
+		x.Farm = primary.Farm
+	}
+	for _, id := range ps.Photos {
+		if p := photos[id]; p != nil && p.Media == "video" {
+			x.Videos++
+		} else {
+			x.Photos++
+		}
+	}
+	x.CountPhotos = x.Photos
+	x.CountVideos = x.Videos
+	return x
+}
+
+type photosetListXML struct {
+	XMLName   xml.Name      `xml:"photosets"`
+	Page      int           `xml:"page,attr"`
+	Pages     int           `xml:"pages,attr"`
+	PerPage   int           `xml:"perpage,attr"`
+	Total     int           `xml:"total,attr"`
+	Photosets []photosetXML `xml:"photoset"`
+}
+
+type createdPhotosetXML struct {
+	XMLName xml.Name `xml:"photoset"`
+	ID      string   `xml:"id,attr"`
+	URL     string   `xml:"url,attr"`
+}
+
+type photosetPhotosXML struct {
+	XMLName   xml.Name       `xml:"photoset"`
+	ID        string         `xml:"id,attr"`
+	Primary   string         `xml:"primary,attr"`
+	Owner     string         `xml:"owner,attr"`
+	OwnerName string         `xml:"ownername,attr"`
+	Title     string         `xml:"title,attr"`
+	Page      int            `xml:"page,attr"`
+	PerPage   int            `xml:"perpage,attr"`
+	Pages     int            `xml:"pages,attr"`
+	Total     int            `xml:"total,attr"`
+	Photos    []listPhotoXML `xml:"photo"`
+}
+
+type throttleXML struct {
+	Count     int    `xml:"count,attr,omitempty"`
+	Mode      string `xml:"mode,attr"`
+	Remaining *int   `xml:"remaining,attr"`
+}
+
+type restrictionsXML struct {
+	PhotosOk     int `xml:"photos_ok,attr"`
+	VideosOk     int `xml:"videos_ok,attr"`
+	ImagesOk     int `xml:"images_ok,attr"`
+	ScreensOk    int `xml:"screens_ok,attr"`
+	ArtOk        int `xml:"art_ok,attr"`
+	VirtualOk    int `xml:"virtual_ok,attr"`
+	SafeOk       int `xml:"safe_ok,attr"`
+	ModerateOk   int `xml:"moderate_ok,attr"`
+	RestrictedOk int `xml:"restricted_ok,attr"`
+	HasGeo       int `xml:"has_geo,attr"`
+}
+
+type groupInfoXML struct {
+	XMLName      xml.Name        `xml:"group"`
+	ID           string          `xml:"id,attr"`
+	NSID         string          `xml:"nsid,attr"`
+	Name         string          `xml:"name"`
+	Members      int             `xml:"members"`
+	PoolCount    int             `xml:"pool_count"`
+	Privacy      string          `xml:"privacy"`
+	Throttle     throttleXML     `xml:"throttle"`
+	Restrictions restrictionsXML `xml:"restrictions"`
+}
+
+func newGroupInfoXML(g *Group) groupInfoXML {
+	x := groupInfoXML{
+		ID:        g.ID,
+		NSID:      g.ID,
+		Name:      g.Name,
+		Members:   len(g.Members),
+		PoolCount: len(g.Photos),
+		Privacy:   g.Privacy,
+		Throttle:  throttleXML{Mode: g.ThrottleMode},
+		Restrictions: restrictionsXML{
+			PhotosOk: 1, VideosOk: 1, ImagesOk: 1, ScreensOk: 1, ArtOk: 1,
+			VirtualOk: 1, SafeOk: 1, ModerateOk: 1, RestrictedOk: 0, HasGeo: 0,
+		},
+	}
+	if g.ThrottleMode != "none" {
+		remaining := g.ThrottleRemaining
+		x.Throttle.Count = g.ThrottleCount
+		x.Throttle.Remaining = &remaining
+	}
+	return x
+}
+
+type poolGroupXML struct {
+	NSID        string `xml:"nsid,attr"`
+	ID          string `xml:"id,attr"`
+	Name        string `xml:"name,attr"`
+	Member      int    `xml:"member,attr"`
+	Moderator   int    `xml:"moderator,attr"`
+	Admin       int    `xml:"admin,attr"`
+	Privacy     string `xml:"privacy,attr"`
+	Photos      int    `xml:"photos,attr"`
+	IconServer  string `xml:"iconserver,attr"`
+	IconFarm    string `xml:"iconfarm,attr"`
+	MemberCount int    `xml:"member_count,attr"`
+	PoolCount   int    `xml:"pool_count,attr"`
+}
+
+type poolGroupsXML struct {
+	XMLName xml.Name       `xml:"groups"`
+	Page    int            `xml:"page,attr"`
+	Pages   int            `xml:"pages,attr"`
+	PerPage int            `xml:"perpage,attr"`
+	Total   int            `xml:"total,attr"`
+	Groups  []poolGroupXML `xml:"group"`
+}
+
+type photoIDXML struct {
+	XMLName xml.Name `xml:"photoid"`
+	ID      string   `xml:",chardata"`
+}
@@ -0,0 +1,126 @@
+// Package implementing methods: flickr.galleries.*
+package galleries
+
+import (
+	"gopkg.in/masci/flickr.v2"
+	"gopkg.in/masci/flickr.v2/people"
+)
+
+type Gallery struct {
+	Id             string `xml:"id,attr"`
+	Url            string `xml:"url,attr"`
+	Owner          string `xml:"owner,attr"`
+	Username       string `xml:"username,attr"`
+	PrimaryPhotoId string `xml:"primary_photo_id,attr"`
+	// Unix timestamps
+	DateCreate         string `xml:"date_create,attr"`
+	DateUpdate         string `xml:"date_update,attr"`
+	CountPhotos        int    `xml:"count_photos,attr"`
+	CountVideos        int    `xml:"count_videos,attr"`
+	CountViews         int    `xml:"count_views,attr"`
+	CountComments      int    `xml:"count_comments,attr"`
+	PrimaryPhotoServer string `xml:"primary_photo_server,attr"`
+	PrimaryPhotoFarm   string `xml:"primary_photo_farm,attr"`
+	PrimaryPhotoSecret string `xml:"primary_photo_secret,attr"`
+	Title              string `xml:"title"`
+	Description        string `xml:"description"`
+}
+
+type GalleryResponse struct {
+	flickr.BasicResponse
+	Gallery Gallery `xml:"gallery"`
+}
+
+type GalleriesListResponse struct {
+	flickr.BasicResponse
+	Galleries struct {
+		// Set by GetList
+		UserId string `xml:"user_id,attr"`
+		// Set by GetListForPhoto
+		PhotoId   string    `xml:"photo_id,attr"`
+		Page      int       `xml:"page,attr"`
+		Pages     int       `xml:"pages,attr"`
+		Perpage   int       `xml:"per_page,attr"`
+		Total     int       `xml:"total,attr"`
+		Galleries []Gallery `xml:"gallery"`
+	} `xml:"galleries"`
+}
+
+// A photo in a gallery, with the same fields as the photos of people.GetPhotos
+type Photo struct {
+	people.Photo
+	IsPrimary  bool `xml:"is_primary,attr"`
+	HasComment bool `xml:"has_comment,attr"`
+	// The comment of the curator of the gallery
+	Comment string `xml:"comment"`
+}
+
+type PhotosListResponse struct {
+	flickr.BasicResponse
+	Photos struct {
+		Page    int     `xml:"page,attr"`
+		Pages   int     `xml:"pages,attr"`
+		Perpage int     `xml:"perpage,attr"`
+		Total   int     `xml:"total,attr"`
+		Photos  []Photo `xml:"photo"`
+	} `xml:"photos"`
+}
+
+// Create a gallery, primaryPhotoId is optional.
+// This method requires authentication with 'write' permission.
+func Create(client *flickr.FlickrClient, title, description, primaryPhotoId string) (*GalleryResponse, error) {
+	return NewService(client).Create(title, description, primaryPhotoId)
+}
+
+// Edit gallery title and description
+// This method requires authentication with 'write' permission.
+func EditMeta(client *flickr.FlickrClient, galleryId, title, description string) (*flickr.BasicResponse, error) {
+	return NewService(client).EditMeta(galleryId, title, description)
+}
+
+// Add a photo to a gallery, with an optional comment
+// This method requires authentication with 'write' permission.
+func AddPhoto(client *flickr.FlickrClient, galleryId, photoId, comment string) (*flickr.BasicResponse, error) {
+	return NewService(client).AddPhoto(galleryId, photoId, comment)
+}
+
+// Remove a photo from a gallery
+// This method requires authentication with 'write' permission.
+func RemovePhoto(client *flickr.FlickrClient, galleryId, photoId string) (*flickr.BasicResponse, error) {
+	return NewService(client).RemovePhoto(galleryId, photoId)
+}
+
+// Edit the comment of a photo in a gallery
+// This method requires authentication with 'write' permission.
+func EditPhoto(client *flickr.FlickrClient, galleryId, photoId, comment string) (*flickr.BasicResponse, error) {
+	return NewService(client).EditPhoto(galleryId, photoId, comment)
+}
+
+// Modify the photos in a gallery. Use this method to add, remove and re-order photos.
+// This method requires authentication with 'write' permission.
+func EditPhotos(client *flickr.FlickrClient, galleryId, primaryId string, photoIds []string) (*flickr.BasicResponse, error) {
+	return NewService(client).EditPhotos(galleryId, primaryId, photoIds)
+}
+
+// Get information about a gallery
+func GetInfo(client *flickr.FlickrClient, galleryId string) (*GalleryResponse, error) {
+	return NewService(client).GetInfo(galleryId)
+}
+
+// Return the galleries created by the user with userId. primaryPhotoExtras is
+// a comma separated list of extra fields of the primary photos, set perPage and
+// page to 0 to use Flickr defaults.
+func GetList(client *flickr.FlickrClient, userId, primaryPhotoExtras string, perPage, page int) (*GalleriesListResponse, error) {
+	return NewService(client).GetList(userId, primaryPhotoExtras, perPage, page)
+}
+
+// Return the galleries a photo appears in
+func GetListForPhoto(client *flickr.FlickrClient, photoId string, perPage, page int) (*GalleriesListResponse, error) {
+	return NewService(client).GetListForPhoto(photoId, perPage, page)
+}
+
+// Get the photos in a gallery. extras is a comma separated list of extra
+// fields, set perPage and page to 0 to use Flickr defaults.
+func GetPhotos(client *flickr.FlickrClient, galleryId, extras string, perPage, page int) (*PhotosListResponse, error) {
+	return NewService(client).GetPhotos(galleryId, extras, perPage, page)
+}
@@ -0,0 +1,161 @@
+package galleries
+
+import (
+	"testing"
+
+	"gopkg.in/masci/flickr.v2"
+	flickErr "gopkg.in/masci/flickr.v2/error"
+)
+
+var (
+	infoBody = `<?xml version="1.0" encoding="utf-8" ?>
+		<rsp stat="ok">
+			<gallery id="6065-72157617483228192" url="https://www.flickr.com/photos/straup/galleries/72157617483228192" owner="35034348999@N01" primary_photo_id="2935285232" date_create="1241028772" date_update="1270111667" count_photos="17" count_videos="0" primary_photo_server="3282" primary_photo_farm="4" primary_photo_secret="3fb6dc0e62">
+				<title>Cat Pictures I've Sent To Kevin Collins</title>
+				<description>Cats, cats and cats</description>
+			</gallery>
+		</rsp>`
+
+	listBody = `<?xml version="1.0" encoding="utf-8" ?>
+		<rsp stat="ok">
+			<galleries total="9" page="2" pages="5" per_page="2" user_id="34427469121@N01">
+				<gallery id="5704-72157622637971865" url="https://www.flickr.com/photos/george/galleries/72157622637971865/" owner="34427469121@N01" date_create="1257711422" date_update="1260360756" primary_photo_id="107391222" count_photos="18" count_videos="0">
+					<title>I like me some black &amp; white</title>
+					<description></description>
+				</gallery>
+				<gallery id="5704-72157622566655097" url="https://www.flickr.com/photos/george/galleries/72157622566655097/" owner="34427469121@N01" date_create="1256852229" date_update="1260360370" primary_photo_id="2497624237" count_photos="18" count_videos="0">
+					<title>People Sleeping in Libraries</title>
+					<description></description>
+				</gallery>
+			</galleries>
+		</rsp>`
+
+	photosBody = `<?xml version="1.0" encoding="utf-8" ?>
+		<rsp stat="ok">
+			<photos page="1" pages="1" perpage="500" total="2">
+				<photo id="2935285232" owner="12037949754@N01" secret="3fb6dc0e62" server="3282" farm="4" title="Kitty" ispublic="1" isfriend="0" isfamily="0" is_primary="1" has_comment="1" url_m="https://live.staticflickr.com/3282/2935285232_3fb6dc0e62.jpg">
+					<comment>The best cat</comment>
+				</photo>
+				<photo id="3179745564" owner="21399806@N00" secret="b5bf7e2e53" server="3099" farm="4" title="Cat nap" ispublic="1" isfriend="0" isfamily="0" is_primary="0" has_comment="0" />
+			</photos>
+		</rsp>`
+)
+
+func TestCreate(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<rsp stat="ok"><gallery id="50736-72157623680420409" url="https://www.flickr.com/photos/kellan/galleries/72157623680420409" /></rsp>`, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := Create(fclient, "My gallery", "Some cats", "")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, resp.Gallery.Id, "50736-72157623680420409")
+	flickr.Expect(t, resp.Gallery.Url, "https://www.flickr.com/photos/kellan/galleries/72157623680420409")
+	flickr.Expect(t, fclient.Args.Get("primary_photo_id"), "")
+	flickr.AssertParamsInBody(t, fclient, []string{"title", "description", "oauth_signature"})
+
+	server, client = flickr.FlickrMock(200, `<rsp stat="fail"><err code="2" msg="Invalid title or description" /></rsp>`, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+	resp, err = Create(fclient, "", "", "")
+	_, ok := err.(*flickErr.Error)
+	flickr.Expect(t, ok, true)
+	flickr.Expect(t, resp.ErrorCode(), 2)
+}
+
+func TestEdit(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<rsp stat="ok"></rsp>`, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	_, err := EditMeta(fclient, "6065-72157617483228192", "Cats", "")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.galleries.editMeta")
+	flickr.Expect(t, fclient.Args.Get("description"), "")
+	flickr.AssertParamsInBody(t, fclient, []string{"gallery_id", "title"})
+
+	_, err = AddPhoto(fclient, "6065-72157617483228192", "2935285232", "The best cat")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.galleries.addPhoto")
+	flickr.Expect(t, fclient.Args.Get("comment"), "The best cat")
+
+	_, err = EditPhoto(fclient, "6065-72157617483228192", "2935285232", "Still the best cat")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.galleries.editPhoto")
+	flickr.AssertParamsInBody(t, fclient, []string{"gallery_id", "photo_id", "comment"})
+
+	_, err = RemovePhoto(fclient, "6065-72157617483228192", "2935285232")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.galleries.removePhoto")
+
+	_, err = EditPhotos(fclient, "6065-72157617483228192", "2935285232", []string{"2935285232", "3179745564"})
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.galleries.editPhotos")
+	flickr.Expect(t, fclient.Args.Get("photo_ids"), "2935285232,3179745564")
+	flickr.AssertParamsInBody(t, fclient, []string{"gallery_id", "primary_photo_id", "photo_ids"})
+}
+
+func TestGetInfo(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, infoBody, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetInfo(fclient, "6065-72157617483228192")
+	flickr.Expect(t, err, nil)
+	g := resp.Gallery
+	flickr.Expect(t, g.Id, "6065-72157617483228192")
+	flickr.Expect(t, g.Owner, "35034348999@N01")
+	flickr.Expect(t, g.PrimaryPhotoId, "2935285232")
+	flickr.Expect(t, g.CountPhotos, 17)
+	flickr.Expect(t, g.PrimaryPhotoFarm, "4")
+	flickr.Expect(t, g.Title, "Cat Pictures I've Sent To Kevin Collins")
+	flickr.Expect(t, g.Description, "Cats, cats and cats")
+	flickr.AssertParamsInBody(t, fclient, []string{"gallery_id", "api_sig"})
+}
+
+func TestGetList(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, listBody, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetList(fclient, "34427469121@N01", "url_m", 2, 2)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, resp.Galleries.UserId, "34427469121@N01")
+	flickr.Expect(t, resp.Galleries.Page, 2)
+	flickr.Expect(t, resp.Galleries.Perpage, 2)
+	flickr.Expect(t, resp.Galleries.Total, 9)
+	flickr.Expect(t, len(resp.Galleries.Galleries), 2)
+	flickr.Expect(t, resp.Galleries.Galleries[0].Title, "I like me some black & white")
+	flickr.AssertParamsInBody(t, fclient, []string{"user_id", "primary_photo_extras", "per_page", "page"})
+
+	_, err = GetListForPhoto(fclient, "2935285232", 0, 1)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.galleries.getListForPhoto")
+	flickr.Expect(t, fclient.Args.Get("page"), "")
+	flickr.Expect(t, fclient.Args.Get("per_page"), "")
+	flickr.AssertParamsInBody(t, fclient, []string{"photo_id"})
+}
+
+func TestGetPhotos(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, photosBody, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetPhotos(fclient, "6065-72157617483228192", "url_m", 0, 0)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, resp.Photos.Total, 2)
+	flickr.Expect(t, len(resp.Photos.Photos), 2)
+	p := resp.Photos.Photos[0]
+	flickr.Expect(t, p.Id, "2935285232")
+	flickr.Expect(t, p.Title, "Kitty")
+	flickr.Expect(t, p.IsPrimary, true)
+	flickr.Expect(t, p.HasComment, true)
+	flickr.Expect(t, p.Comment, "The best cat")
+	flickr.Expect(t, p.UrlM, "https://live.staticflickr.com/3282/2935285232_3fb6dc0e62.jpg")
+	flickr.Expect(t, resp.Photos.Photos[1].IsPrimary, false)
+	flickr.AssertParamsInBody(t, fclient, []string{"gallery_id", "extras"})
+}
@@ -0,0 +1,214 @@
+package galleries
+
+import (
+	"strconv"
+	"strings"
+
+	"gopkg.in/masci/flickr.v2"
+)
+
+// Methods of the flickr.galleries namespace
+type Service interface {
+	// Create a gallery
+	Create(title, description, primaryPhotoId string) (*GalleryResponse, error)
+	// Edit gallery title and description
+	EditMeta(galleryId, title, description string) (*flickr.BasicResponse, error)
+	// Add a photo to a gallery
+	AddPhoto(galleryId, photoId, comment string) (*flickr.BasicResponse, error)
+	// Remove a photo from a gallery
+	RemovePhoto(galleryId, photoId string) (*flickr.BasicResponse, error)
+	// Edit the comment of a photo in a gallery
+	EditPhoto(galleryId, photoId, comment string) (*flickr.BasicResponse, error)
+	// Modify the photos in a gallery
+	EditPhotos(galleryId, primaryId string, photoIds []string) (*flickr.BasicResponse, error)
+	// Get information about a gallery
+	GetInfo(galleryId string) (*GalleryResponse, error)
+	// Return the galleries created by the user with userId
+	GetList(userId, primaryPhotoExtras string, perPage, page int) (*GalleriesListResponse, error)
+	// Return the galleries a photo appears in
+	GetListForPhoto(photoId string, perPage, page int) (*GalleriesListResponse, error)
+	// Get the photos in a gallery
+	GetPhotos(galleryId, extras string, perPage, page int) (*PhotosListResponse, error)
+}
+
+// Return a Service managing galleries with client
+func NewService(client *flickr.FlickrClient) Service {
+	return &service{client}
+}
+
+type service struct {
+	client *flickr.FlickrClient
+}
+
+func (s *service) Create(title, description, primaryPhotoId string) (*GalleryResponse, error) {
+	client := s.client
+	client.Init()
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.galleries.create")
+	client.Args.Set("title", title)
+	client.Args.Set("description", description)
+	if primaryPhotoId != "" {
+		client.Args.Set("primary_photo_id", primaryPhotoId)
+	}
+
+	client.OAuthSign()
+
+	response := &GalleryResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+func (s *service) EditMeta(galleryId, title, description string) (*flickr.BasicResponse, error) {
+	client := s.client
+	client.Init()
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.galleries.editMeta")
+	client.Args.Set("gallery_id", galleryId)
+	client.Args.Set("title", title)
+	if description != "" {
+		client.Args.Set("description", description)
+	}
+
+	client.OAuthSign()
+
+	response := &flickr.BasicResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+func (s *service) AddPhoto(galleryId, photoId, comment string) (*flickr.BasicResponse, error) {
+	client := s.client
+	client.Init()
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.galleries.addPhoto")
+	client.Args.Set("gallery_id", galleryId)
+	client.Args.Set("photo_id", photoId)
+	if comment != "" {
+		client.Args.Set("comment", comment)
+	}
+
+	client.OAuthSign()
+
+	response := &flickr.BasicResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+func (s *service) RemovePhoto(galleryId, photoId string) (*flickr.BasicResponse, error) {
+	client := s.client
+	client.Init()
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.galleries.removePhoto")
+	client.Args.Set("gallery_id", galleryId)
+	client.Args.Set("photo_id", photoId)
+
+	client.OAuthSign()
+
+	response := &flickr.BasicResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+func (s *service) EditPhoto(galleryId, photoId, comment string) (*flickr.BasicResponse, error) {
+	client := s.client
+	client.Init()
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.galleries.editPhoto")
+	client.Args.Set("gallery_id", galleryId)
+	client.Args.Set("photo_id", photoId)
+	client.Args.Set("comment", comment)
+
+	client.OAuthSign()
+
+	response := &flickr.BasicResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+func (s *service) EditPhotos(galleryId, primaryId string, photoIds []string) (*flickr.BasicResponse, error) {
+	client := s.client
+	client.Init()
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.galleries.editPhotos")
+	client.Args.Set("gallery_id", galleryId)
+	client.Args.Set("primary_photo_id", primaryId)
+	photos := strings.Join(photoIds, ",")
+	client.Args.Set("photo_ids", photos)
+
+	client.OAuthSign()
+
+	response := &flickr.BasicResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+func (s *service) GetInfo(galleryId string) (*GalleryResponse, error) {
+	client := s.client
+	client.Init()
+	client.Args.Set("method", "flickr.galleries.getInfo")
+	client.Args.Set("gallery_id", galleryId)
+
+	client.ApiSign()
+
+	response := &GalleryResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+func (s *service) GetList(userId, primaryPhotoExtras string, perPage, page int) (*GalleriesListResponse, error) {
+	client := s.client
+	client.Init()
+	client.Args.Set("method", "flickr.galleries.getList")
+	client.Args.Set("user_id", userId)
+	if primaryPhotoExtras != "" {
+		client.Args.Set("primary_photo_extras", primaryPhotoExtras)
+	}
+	setPage(client, perPage, page)
+
+	client.ApiSign()
+
+	response := &GalleriesListResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+func (s *service) GetListForPhoto(photoId string, perPage, page int) (*GalleriesListResponse, error) {
+	client := s.client
+	client.Init()
+	client.Args.Set("method", "flickr.galleries.getListForPhoto")
+	client.Args.Set("photo_id", photoId)
+	setPage(client, perPage, page)
+
+	client.ApiSign()
+
+	response := &GalleriesListResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+func (s *service) GetPhotos(galleryId, extras string, perPage, page int) (*PhotosListResponse, error) {
+	client := s.client
+	client.Init()
+	client.Args.Set("method", "flickr.galleries.getPhotos")
+	client.Args.Set("gallery_id", galleryId)
+	if extras != "" {
+		client.Args.Set("extras", extras)
+	}
+	setPage(client, perPage, page)
+
+	client.ApiSign()
+
+	response := &PhotosListResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+// Set the pagination arguments, if not provided flickr defaults page to 1
+func setPage(client *flickr.FlickrClient, perPage, page int) {
+	if perPage > 0 {
+		client.Args.Set("per_page", strconv.Itoa(perPage))
+	}
+	if page > 1 {
+		client.Args.Set("page", strconv.Itoa(page))
+	}
+}
@@ -59,50 +59,17 @@ type GetGroupsResponse struct {
 }
 
 func GetInfo(client *flickr.FlickrClient, groupId string) (*GroupInfoResponse, error) {
-	client.Init()
-	client.EndpointUrl = flickr.API_ENDPOINT
-	client.HTTPVerb = "POST"
-	client.Args.Set("method", "flickr.groups.getInfo")
-	client.Args.Set("group_id", groupId)
-	client.OAuthSign()
-	response := &GroupInfoResponse{}
-	err := flickr.DoPost(client, response)
-	return response, err
-
+	return NewService(client).GetInfo(groupId)
 }
 
 // GetGroups Get all the groups for current user, ,currently it supports only fetching the first 400 groups
 func GetGroups(client *flickr.FlickrClient, page int, perPage int) (*GetGroupsResponse, error) {
-	// TODO impliment pagination
-	client.Init()
-	client.EndpointUrl = flickr.API_ENDPOINT
-	client.HTTPVerb = "POST"
-
-	client.Args.Set("method", "flickr.groups.pools.getGroups")
-	if page > 0 {
-		client.Args.Set("page", strconv.Itoa(page))
-	}
-	if page > 0 {
-		client.Args.Set("per_page", strconv.Itoa(perPage))
-	}
-	client.OAuthSign()
-	response := &GetGroupsResponse{}
-	err := flickr.DoPost(client, response)
-	return response, err
+	return NewService(client).GetGroups(page, perPage)
 }
 
 // AddPhoto  Add a photo to a particular group.
 func AddPhoto(client *flickr.FlickrClient, groupId, photoId string) (*flickr.BasicResponse, error) {
-	client.Init()
-	client.EndpointUrl = flickr.API_ENDPOINT
-	client.HTTPVerb = "POST"
-	client.Args.Set("method", "flickr.groups.pools.add")
-	client.Args.Set("photo_id", photoId)
-	client.Args.Set("group_id", groupId)
-	client.OAuthSign()
-	response := &flickr.BasicResponse{}
-	err := flickr.DoPost(client, response)
-	return response, err
+	return NewService(client).AddPhoto(groupId, photoId)
 }
 
 // CanAddPhotos verify if the use can add more photos to the group or not
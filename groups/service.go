@@ -0,0 +1,70 @@
+package groups
+
+import (
+	"strconv"
+
+	"gopkg.in/masci/flickr.v2"
+)
+
+// Methods of the flickr.groups namespace
+type Service interface {
+	// Get information about a group
+	GetInfo(groupId string) (*GroupInfoResponse, error)
+	// Get the groups the calling user is a member of
+	GetGroups(page int, perPage int) (*GetGroupsResponse, error)
+	// Add a photo to a group pool
+	AddPhoto(groupId, photoId string) (*flickr.BasicResponse, error)
+}
+
+// Return a Service managing groups and their pools with client
+func NewService(client *flickr.FlickrClient) Service {
+	return &service{client}
+}
+
+type service struct {
+	client *flickr.FlickrClient
+}
+
+func (s *service) GetInfo(groupId string) (*GroupInfoResponse, error) {
+	client := s.client
+	client.Init()
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.groups.getInfo")
+	client.Args.Set("group_id", groupId)
+	client.OAuthSign()
+	response := &GroupInfoResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+func (s *service) GetGroups(page int, perPage int) (*GetGroupsResponse, error) {
+	client := s.client
+	// TODO impliment pagination
+	client.Init()
+	client.HTTPVerb = "POST"
+
+	client.Args.Set("method", "flickr.groups.pools.getGroups")
+	if page > 0 {
+		client.Args.Set("page", strconv.Itoa(page))
+	}
+	if page > 0 {
+		client.Args.Set("per_page", strconv.Itoa(perPage))
+	}
+	client.OAuthSign()
+	response := &GetGroupsResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+func (s *service) AddPhoto(groupId, photoId string) (*flickr.BasicResponse, error) {
+	client := s.client
+	client.Init()
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.groups.pools.add")
+	client.Args.Set("photo_id", photoId)
+	client.Args.Set("group_id", groupId)
+	client.OAuthSign()
+	response := &flickr.BasicResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
@@ -0,0 +1,42 @@
+//go:build go1.21
+// +build go1.21
+
+package flickr
+
+import (
+	"context"
+	"log/slog"
+)
+
+// Return an interceptor logging every request to logger, at Info level when
+// successful, Warn level on Flickr errors and Error level on transport errors.
+// Credentials and signatures are redacted from the logged params.
+func LogInterceptor(logger *slog.Logger) Interceptor {
+	return func(ctx context.Context, req *RequestInfo, next Handler) (*ResponseInfo, error) {
+		res, err := next(ctx, req)
+
+		attrs := []slog.Attr{
+			slog.String("method", req.Method),
+			slog.String("endpoint", req.Endpoint),
+			slog.String("params", req.Params.Encode()),
+		}
+		if err != nil {
+			attrs = append(attrs, slog.String("error", err.Error()))
+			logger.LogAttrs(ctx, slog.LevelError, "flickr request failed", attrs...)
+			return res, err
+		}
+
+		attrs = append(attrs,
+			slog.Int("status", res.StatusCode),
+			slog.Int("size", res.Size),
+			slog.Duration("latency", res.Latency),
+		)
+		level := slog.LevelInfo
+		if res.ErrorCode != 0 {
+			level = slog.LevelWarn
+			attrs = append(attrs, slog.Int("error_code", res.ErrorCode), slog.String("error_msg", res.ErrorMsg))
+		}
+		logger.LogAttrs(ctx, level, "flickr request", attrs...)
+		return res, err
+	}
+}
@@ -0,0 +1,35 @@
+//go:build go1.21
+// +build go1.21
+
+package flickr
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestLogInterceptor(t *testing.T) {
+	server, _ := callMock(`<?xml version="1.0" encoding="utf-8" ?><rsp stat="fail"><err code="1" msg="Photo not found" /></rsp>`)
+	defer server.Close()
+
+	buf := &bytes.Buffer{}
+	client := NewFlickrClient("key", "secret")
+	client.Endpoints.API = server.URL
+	client.OAuthToken = "token"
+	client.Interceptors = append(client.Interceptors, LogInterceptor(slog.New(slog.NewTextHandler(buf, nil))))
+
+	err := client.Call(context.Background(), "flickr.photos.getInfo", nil, &BasicResponse{})
+	Expect(t, err != nil, true)
+
+	line := buf.String()
+	Expect(t, strings.Contains(line, "level=WARN"), true)
+	Expect(t, strings.Contains(line, "method=flickr.photos.getInfo"), true)
+	Expect(t, strings.Contains(line, "status=200"), true)
+	Expect(t, strings.Contains(line, "error_code=1"), true)
+	Expect(t, strings.Contains(line, `error_msg="Photo not found"`), true)
+	Expect(t, strings.Contains(line, "oauth_token=REDACTED"), true)
+	Expect(t, strings.Contains(line, "oauth_token=token"), false)
+}
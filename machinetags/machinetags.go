@@ -0,0 +1,115 @@
+// Package implementing methods: flickr.machinetags.*
+// and parsing machine tags, namespace:predicate=value
+package machinetags
+
+import (
+	"gopkg.in/masci/flickr.v2"
+)
+
+type Namespace struct {
+	// Number of uses of the namespace
+	Usage int `xml:"usage,attr"`
+	// Number of predicates in the namespace
+	Predicates int    `xml:"predicates,attr"`
+	Name       string `xml:",chardata"`
+}
+
+type NamespacesResponse struct {
+	flickr.BasicResponse
+	Namespaces struct {
+		Page       int         `xml:"page,attr"`
+		Pages      int         `xml:"pages,attr"`
+		PerPage    int         `xml:"perpage,attr"`
+		Total      int         `xml:"total,attr"`
+		Namespaces []Namespace `xml:"namespace"`
+	} `xml:"namespaces"`
+}
+
+type Predicate struct {
+	Usage int `xml:"usage,attr"`
+	// Number of namespaces the predicate is used in
+	Namespaces int    `xml:"namespaces,attr"`
+	Name       string `xml:",chardata"`
+}
+
+type PredicatesResponse struct {
+	flickr.BasicResponse
+	Predicates struct {
+		Page       int         `xml:"page,attr"`
+		Pages      int         `xml:"pages,attr"`
+		PerPage    int         `xml:"perpage,attr"`
+		Total      int         `xml:"total,attr"`
+		Predicates []Predicate `xml:"predicate"`
+	} `xml:"predicates"`
+}
+
+type Pair struct {
+	Namespace string `xml:"namespace,attr"`
+	Predicate string `xml:"predicate,attr"`
+	Usage     int    `xml:"usage,attr"`
+	// namespace:predicate
+	Name string `xml:",chardata"`
+}
+
+type PairsResponse struct {
+	flickr.BasicResponse
+	Pairs struct {
+		Page    int    `xml:"page,attr"`
+		Pages   int    `xml:"pages,attr"`
+		PerPage int    `xml:"perpage,attr"`
+		Total   int    `xml:"total,attr"`
+		Pairs   []Pair `xml:"pair"`
+	} `xml:"pairs"`
+}
+
+type Value struct {
+	Usage int `xml:"usage,attr"`
+	// Set by GetRecentValues
+	Namespace string `xml:"namespace,attr"`
+	Predicate string `xml:"predicate,attr"`
+	// Unix timestamps, set by GetRecentValues
+	FirstAdded string `xml:"first_added,attr"`
+	LastAdded  string `xml:"last_added,attr"`
+	Value      string `xml:",chardata"`
+}
+
+type ValuesResponse struct {
+	flickr.BasicResponse
+	Values struct {
+		Namespace string  `xml:"namespace,attr"`
+		Predicate string  `xml:"predicate,attr"`
+		Page      int     `xml:"page,attr"`
+		Pages     int     `xml:"pages,attr"`
+		PerPage   int     `xml:"perpage,attr"`
+		Total     int     `xml:"total,attr"`
+		Values    []Value `xml:"value"`
+	} `xml:"values"`
+}
+
+// Return the namespaces in use, only the ones having predicate when not "".
+// Set perPage and page to 0 to use Flickr defaults.
+func GetNamespaces(client *flickr.FlickrClient, predicate string, perPage, page int) (*NamespacesResponse, error) {
+	return NewService(client).GetNamespaces(predicate, perPage, page)
+}
+
+// Return the predicates in use, only the ones of namespace when not ""
+func GetPredicates(client *flickr.FlickrClient, namespace string, perPage, page int) (*PredicatesResponse, error) {
+	return NewService(client).GetPredicates(namespace, perPage, page)
+}
+
+// Return the namespace:predicate pairs in use, filtered by namespace and
+// predicate when not ""
+func GetPairs(client *flickr.FlickrClient, namespace, predicate string, perPage, page int) (*PairsResponse, error) {
+	return NewService(client).GetPairs(namespace, predicate, perPage, page)
+}
+
+// Return the values used with namespace and predicate
+func GetValues(client *flickr.FlickrClient, namespace, predicate string, perPage, page int) (*ValuesResponse, error) {
+	return NewService(client).GetValues(namespace, predicate, perPage, page)
+}
+
+// Return the values added recently, filtered by namespace and predicate when
+// not "". addedSince is a unix timestamp, set it to "" to ignore.
+func GetRecentValues(client *flickr.FlickrClient, namespace, predicate, addedSince string) (*ValuesResponse, error) {
+	return NewService(client).GetRecentValues(namespace, predicate, addedSince)
+}
@@ -0,0 +1,101 @@
+package machinetags
+
+import (
+	"testing"
+
+	"gopkg.in/masci/flickr.v2"
+)
+
+func TestGetNamespaces(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	body := `<rsp stat="ok"><namespaces page="1" total="2" perpage="500" pages="1">
+		<namespace usage="6538" predicates="13">aero</namespace>
+		<namespace usage="9" predicates="3">flakes</namespace>
+	</namespaces></rsp>`
+	server, client := flickr.FlickrMock(200, body, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetNamespaces(fclient, "airline", 500, 0)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, resp.Namespaces.Total, 2)
+	flickr.Expect(t, resp.Namespaces.Namespaces[0].Name, "aero")
+	flickr.Expect(t, resp.Namespaces.Namespaces[0].Usage, 6538)
+	flickr.Expect(t, resp.Namespaces.Namespaces[1].Predicates, 3)
+	flickr.AssertParamsInBody(t, fclient, []string{"predicate", "per_page", "api_sig"})
+}
+
+func TestGetPredicates(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	body := `<rsp stat="ok"><predicates page="1" pages="1" total="1" perpage="500">
+		<predicate usage="20" namespaces="1">chairs</predicate>
+	</predicates></rsp>`
+	server, client := flickr.FlickrMock(200, body, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetPredicates(fclient, "", 0, 0)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, resp.Predicates.Predicates[0].Name, "chairs")
+	flickr.Expect(t, resp.Predicates.Predicates[0].Namespaces, 1)
+	flickr.Expect(t, fclient.Args.Get("namespace"), "")
+}
+
+func TestGetPairs(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	body := `<rsp stat="ok"><pairs page="1" pages="1" total="2" perpage="500">
+		<pair namespace="aero" predicate="airline" usage="1093">aero:airline</pair>
+		<pair namespace="aero" predicate="icao" usage="4">aero:icao</pair>
+	</pairs></rsp>`
+	server, client := flickr.FlickrMock(200, body, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetPairs(fclient, "aero", "", 0, 2)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, len(resp.Pairs.Pairs), 2)
+	p := resp.Pairs.Pairs[0]
+	flickr.Expect(t, p.Namespace, "aero")
+	flickr.Expect(t, p.Predicate, "airline")
+	flickr.Expect(t, p.Usage, 1093)
+	flickr.Expect(t, p.Name, "aero:airline")
+	flickr.AssertParamsInBody(t, fclient, []string{"namespace", "page"})
+}
+
+func TestGetValues(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	body := `<rsp stat="ok"><values namespace="upcoming" predicate="event" page="1" total="1" perpage="500" pages="1">
+		<value usage="3">123</value>
+	</values></rsp>`
+	server, client := flickr.FlickrMock(200, body, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetValues(fclient, "upcoming", "event", 0, 0)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, resp.Values.Namespace, "upcoming")
+	flickr.Expect(t, resp.Values.Predicate, "event")
+	flickr.Expect(t, resp.Values.Values[0].Value, "123")
+	flickr.Expect(t, resp.Values.Values[0].Usage, 3)
+	flickr.AssertParamsInBody(t, fclient, []string{"namespace", "predicate"})
+}
+
+func TestGetRecentValues(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	body := `<rsp stat="ok"><values page="1" total="1" perpage="500" pages="1">
+		<value usage="6" namespace="fakeheroes" predicate="hero" first_added="1244232796" last_added="1244232796">Batman</value>
+	</values></rsp>`
+	server, client := flickr.FlickrMock(200, body, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetRecentValues(fclient, "fakeheroes", "", "1244232700")
+	flickr.Expect(t, err, nil)
+	v := resp.Values.Values[0]
+	flickr.Expect(t, v.Namespace, "fakeheroes")
+	flickr.Expect(t, v.Predicate, "hero")
+	flickr.Expect(t, v.FirstAdded, "1244232796")
+	flickr.Expect(t, v.Value, "Batman")
+	flickr.Expect(t, fclient.Args.Get("predicate"), "")
+	flickr.AssertParamsInBody(t, fclient, []string{"namespace", "added_since"})
+}
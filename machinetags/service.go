@@ -0,0 +1,127 @@
+package machinetags
+
+import (
+	"strconv"
+
+	"gopkg.in/masci/flickr.v2"
+)
+
+// Methods of the flickr.machinetags namespace
+type Service interface {
+	// Return the namespaces in use
+	GetNamespaces(predicate string, perPage, page int) (*NamespacesResponse, error)
+	// Return the predicates in use
+	GetPredicates(namespace string, perPage, page int) (*PredicatesResponse, error)
+	// Return the namespace:predicate pairs in use
+	GetPairs(namespace, predicate string, perPage, page int) (*PairsResponse, error)
+	// Return the values used with a namespace and a predicate
+	GetValues(namespace, predicate string, perPage, page int) (*ValuesResponse, error)
+	// Return the values added recently
+	GetRecentValues(namespace, predicate, addedSince string) (*ValuesResponse, error)
+}
+
+// Return a Service browsing machine tags with client
+func NewService(client *flickr.FlickrClient) Service {
+	return &service{client}
+}
+
+type service struct {
+	client *flickr.FlickrClient
+}
+
+func (s *service) GetNamespaces(predicate string, perPage, page int) (*NamespacesResponse, error) {
+	client := s.client
+	client.Init()
+	client.Args.Set("method", "flickr.machinetags.getNamespaces")
+	if predicate != "" {
+		client.Args.Set("predicate", predicate)
+	}
+	setPage(client, perPage, page)
+
+	client.ApiSign()
+
+	response := &NamespacesResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+func (s *service) GetPredicates(namespace string, perPage, page int) (*PredicatesResponse, error) {
+	client := s.client
+	client.Init()
+	client.Args.Set("method", "flickr.machinetags.getPredicates")
+	if namespace != "" {
+		client.Args.Set("namespace", namespace)
+	}
+	setPage(client, perPage, page)
+
+	client.ApiSign()
+
+	response := &PredicatesResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+func (s *service) GetPairs(namespace, predicate string, perPage, page int) (*PairsResponse, error) {
+	client := s.client
+	client.Init()
+	client.Args.Set("method", "flickr.machinetags.getPairs")
+	if namespace != "" {
+		client.Args.Set("namespace", namespace)
+	}
+	if predicate != "" {
+		client.Args.Set("predicate", predicate)
+	}
+	setPage(client, perPage, page)
+
+	client.ApiSign()
+
+	response := &PairsResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+func (s *service) GetValues(namespace, predicate string, perPage, page int) (*ValuesResponse, error) {
+	client := s.client
+	client.Init()
+	client.Args.Set("method", "flickr.machinetags.getValues")
+	client.Args.Set("namespace", namespace)
+	client.Args.Set("predicate", predicate)
+	setPage(client, perPage, page)
+
+	client.ApiSign()
+
+	response := &ValuesResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+func (s *service) GetRecentValues(namespace, predicate, addedSince string) (*ValuesResponse, error) {
+	client := s.client
+	client.Init()
+	client.Args.Set("method", "flickr.machinetags.getRecentValues")
+	if namespace != "" {
+		client.Args.Set("namespace", namespace)
+	}
+	if predicate != "" {
+		client.Args.Set("predicate", predicate)
+	}
+	if addedSince != "" {
+		client.Args.Set("added_since", addedSince)
+	}
+
+	client.ApiSign()
+
+	response := &ValuesResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+// Set the pagination arguments, if not provided flickr defaults page to 1
+func setPage(client *flickr.FlickrClient, perPage, page int) {
+	if perPage > 0 {
+		client.Args.Set("per_page", strconv.Itoa(perPage))
+	}
+	if page > 1 {
+		client.Args.Set("page", strconv.Itoa(page))
+	}
+}
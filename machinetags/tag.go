@@ -0,0 +1,109 @@
+package machinetags
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/masci/flickr.v2/photos"
+)
+
+// A machine tag, namespace:predicate=value
+type MachineTag struct {
+	Namespace string
+	Predicate string
+	Value     string
+}
+
+// Parse a machine tag. The value may be quoted, with quotes inside it escaped
+// by a backslash, and the whole tag may be quoted as in the raw tags entered
+// on Flickr.
+func Parse(s string) (MachineTag, error) {
+	tag := strings.TrimSpace(s)
+	if len(tag) >= 2 && tag[0] == '"' && tag[len(tag)-1] == '"' && !strings.Contains(tag, "=\"") {
+		tag = tag[1 : len(tag)-1]
+	}
+
+	colon := strings.IndexByte(tag, ':')
+	equal := strings.IndexByte(tag, '=')
+	if colon < 0 || equal < colon {
+		return MachineTag{}, fmt.Errorf("machinetags: invalid machine tag %q", s)
+	}
+	m := MachineTag{
+		Namespace: tag[:colon],
+		Predicate: tag[colon+1 : equal],
+	}
+	if !validName(m.Namespace) || !validName(m.Predicate) {
+		return MachineTag{}, fmt.Errorf("machinetags: invalid machine tag %q", s)
+	}
+
+	value := tag[equal+1:]
+	if strings.HasPrefix(value, `"`) {
+		if len(value) < 2 || !strings.HasSuffix(value, `"`) || strings.HasSuffix(value, `\"`) {
+			return MachineTag{}, fmt.Errorf("machinetags: unterminated value in %q", s)
+		}
+		value = strings.ReplaceAll(value[1:len(value)-1], `\"`, `"`)
+	}
+	if value == "" {
+		return MachineTag{}, fmt.Errorf("machinetags: empty value in %q", s)
+	}
+	m.Value = value
+	return m, nil
+}
+
+// Format the machine tag, quoting the value when it contains spaces, commas
+// or quotes so that it can be passed to photos.AddTags
+func (m MachineTag) String() string {
+	value := m.Value
+	if strings.ContainsAny(value, " \t,\"") {
+		value = `"` + strings.ReplaceAll(value, `"`, `\"`) + `"`
+	}
+	return m.Namespace + ":" + m.Predicate + "=" + value
+}
+
+// Report whether the machine tag is in namespace and has predicate, ignoring
+// case as Flickr does. Empty namespace or predicate match any.
+func (m MachineTag) Match(namespace, predicate string) bool {
+	if namespace != "" && !strings.EqualFold(m.Namespace, namespace) {
+		return false
+	}
+	if predicate != "" && !strings.EqualFold(m.Predicate, predicate) {
+		return false
+	}
+	return true
+}
+
+// Return the machine tags among the tags of a photo matching namespace and
+// predicate, see MachineTag.Match. Tags are parsed from their raw form, so
+// that values keep their case.
+func Filter(tags []photos.Tag, namespace, predicate string) []MachineTag {
+	var found []MachineTag
+	for _, tag := range tags {
+		raw := tag.Raw
+		if raw == "" {
+			raw = tag.Value
+		}
+		m, err := Parse(raw)
+		if err != nil || !m.Match(namespace, predicate) {
+			continue
+		}
+		found = append(found, m)
+	}
+	return found
+}
+
+// Namespaces and predicates start with a letter and contain only letters,
+// digits and underscores
+func validName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z':
+		case i > 0 && (r == '_' || r >= '0' && r <= '9'):
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,76 @@
+package machinetags
+
+import (
+	"testing"
+
+	"gopkg.in/masci/flickr.v2"
+	"gopkg.in/masci/flickr.v2/photos"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in       string
+		expected MachineTag
+	}{
+		{"upcoming:event=81334", MachineTag{"upcoming", "event", "81334"}},
+		{"geo:lat=45.4642", MachineTag{"geo", "lat", "45.4642"}},
+		{"pipeline:state=Needs Review", MachineTag{"pipeline", "state", "Needs Review"}},
+		{`pipeline:state="needs review"`, MachineTag{"pipeline", "state", "needs review"}},
+		{`"pipeline:state=needs review"`, MachineTag{"pipeline", "state", "needs review"}},
+		{`dc:title="a \"quoted\" title"`, MachineTag{"dc", "title", `a "quoted" title`}},
+		{"url:link=http://example.com/?a=b", MachineTag{"url", "link", "http://example.com/?a=b"}},
+		{"my_ns:pred2=x", MachineTag{"my_ns", "pred2", "x"}},
+	}
+	for _, test := range tests {
+		m, err := Parse(test.in)
+		flickr.Expect(t, err, nil)
+		flickr.Expect(t, m, test.expected)
+	}
+
+	for _, in := range []string{"", "tag", "ns=value", "ns:pred", "ns:pred=", ":pred=value", "ns:=value", "1ns:pred=value", "n-s:pred=value", `ns:pred="value`} {
+		_, err := Parse(in)
+		flickr.Expect(t, err != nil, true)
+	}
+}
+
+func TestString(t *testing.T) {
+	tests := []struct {
+		in       MachineTag
+		expected string
+	}{
+		{MachineTag{"upcoming", "event", "81334"}, "upcoming:event=81334"},
+		{MachineTag{"pipeline", "state", "needs review"}, `pipeline:state="needs review"`},
+		{MachineTag{"pipeline", "steps", "a,b"}, `pipeline:steps="a,b"`},
+		{MachineTag{"dc", "title", `a "quoted" title`}, `dc:title="a \"quoted\" title"`},
+	}
+	for _, test := range tests {
+		flickr.Expect(t, test.in.String(), test.expected)
+		// formatted tags parse back to the same value
+		m, err := Parse(test.expected)
+		flickr.Expect(t, err, nil)
+		flickr.Expect(t, m, test.in)
+	}
+}
+
+func TestFilter(t *testing.T) {
+	tags := []photos.Tag{
+		{ID: "1", Raw: "Pipeline:State=Published", Value: "pipeline:state=published"},
+		{ID: "2", Raw: "pipeline:owner=gopher", Value: "pipeline:owner=gopher"},
+		{ID: "3", Raw: "Sunset", Value: "sunset"},
+		{ID: "4", Value: "geo:lat=45.4642"},
+	}
+
+	found := Filter(tags, "pipeline", "state")
+	flickr.Expect(t, len(found), 1)
+	flickr.Expect(t, found[0], MachineTag{"Pipeline", "State", "Published"})
+
+	found = Filter(tags, "pipeline", "")
+	flickr.Expect(t, len(found), 2)
+	flickr.Expect(t, found[1].Value, "gopher")
+
+	found = Filter(tags, "", "")
+	flickr.Expect(t, len(found), 3)
+	flickr.Expect(t, found[2].Namespace, "geo")
+
+	flickr.Expect(t, len(Filter(tags, "dc", "")), 0)
+}
@@ -0,0 +1,213 @@
+package flickr
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	flickErr "gopkg.in/masci/flickr.v2/error"
+)
+
+// Params carrying credentials or signatures, replaced with "REDACTED" in the
+// params given to interceptors
+var redactedParams = []string{
+	"api_sig",
+	"auth_token",
+	"oauth_signature",
+	"oauth_token",
+	"oauth_verifier",
+}
+
+// Reported when an interceptor answers a request without a response nor an error
+var errNoResponse = errors.New("flickr: interceptor returned no response")
+
+// A request about to be sent to Flickr, as seen by interceptors
+type RequestInfo struct {
+	// Flickr method called, empty for uploads and OAuth token requests
+	Method string
+	// Endpoint the request is sent to, without query string
+	Endpoint string
+	// Params of the request with credentials and signatures redacted
+	Params url.Values
+	// Identity of the caller, a hash of the access token or, for requests
+	// without one, of the api key
+	Caller string
+	// The HTTP request, interceptors must not consume its body
+	Request *http.Request
+}
+
+// The outcome of a request, as seen by interceptors
+type ResponseInfo struct {
+	// The HTTP response, its body can be read again
+	Response *http.Response
+	// HTTP status code
+	StatusCode int
+	// Flickr error code, 0 for successful calls and -1 for OAuth errors or
+	// bodies that can't be parsed
+	ErrorCode int
+	// Flickr error message, empty for successful calls
+	ErrorMsg string
+	// Size of the response body in bytes
+	Size int
+	// Time elapsed from sending the request to reading the whole response
+	Latency time.Duration
+}
+
+// Perform a request
+type Handler func(ctx context.Context, req *RequestInfo) (*ResponseInfo, error)
+
+// Wrap a request sent to Flickr, calling next to go on with it. Interceptors
+// can observe requests and their outcome, or answer without calling next.
+// Returned errors are reported to callers as transport errors, as well as
+// answers without a Response.
+type Interceptor func(ctx context.Context, req *RequestInfo, next Handler) (*ResponseInfo, error)
+
+// Receive the outcome of every request, see MetricsInterceptor
+type Metrics interface {
+	// Called once a request completes. res is nil when err is a transport
+	// error, Flickr errors are reported in res.ErrorCode.
+	ObserveRequest(req *RequestInfo, res *ResponseInfo, err error)
+}
+
+// Return an interceptor reporting every request to m
+func MetricsInterceptor(m Metrics) Interceptor {
+	return func(ctx context.Context, req *RequestInfo, next Handler) (*ResponseInfo, error) {
+		res, err := next(ctx, req)
+		m.ObserveRequest(req, res, err)
+		return res, err
+	}
+}
+
+// Send a request with httpClient through the client interceptors
+func (c *FlickrClient) send(ctx context.Context, req *http.Request, httpClient *http.Client) (*http.Response, error) {
+	req = req.WithContext(ctx)
+	if c.DryRun && isWriteRequest(c.Args.Get("method"), req.Method) {
+		return c.dryRun(req)
+	}
+	if len(c.Interceptors) == 0 {
+		res, err := httpClient.Do(req)
+		if err != nil {
+			return nil, flickErr.NewTransportError(err)
+		}
+		c.updateClockSkew(res)
+		return res, nil
+	}
+
+	handler := func(ctx context.Context, info *RequestInfo) (*ResponseInfo, error) {
+		return roundTrip(info.Request.WithContext(ctx), httpClient)
+	}
+	for i := len(c.Interceptors) - 1; i >= 0; i-- {
+		interceptor, next := c.Interceptors[i], handler
+		handler = func(ctx context.Context, info *RequestInfo) (*ResponseInfo, error) {
+			return interceptor(ctx, info, next)
+		}
+	}
+
+	endpoint := *req.URL
+	endpoint.RawQuery = ""
+	info := &RequestInfo{
+		Method:   c.Args.Get("method"),
+		Endpoint: endpoint.String(),
+		Params:   redactParams(c.Args),
+		Caller:   caller(c.Args),
+		Request:  req,
+	}
+	res, err := handler(ctx, info)
+	if err != nil {
+		return nil, flickErr.NewTransportError(err)
+	}
+	if res == nil || res.Response == nil {
+		return nil, flickErr.NewTransportError(errNoResponse)
+	}
+	c.updateClockSkew(res.Response)
+	return res.Response, nil
+}
+
+// Perform a request and read the whole response, so that its size and the
+// Flickr error are known
+func roundTrip(req *http.Request, httpClient *http.Client) (*ResponseInfo, error) {
+	start := time.Now()
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	res.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	code, msg := responseError(body)
+	return &ResponseInfo{
+		Response:   res,
+		StatusCode: res.StatusCode,
+		ErrorCode:  code,
+		ErrorMsg:   msg,
+		Size:       len(body),
+		Latency:    time.Since(start),
+	}, nil
+}
+
+// Return a copy of params with credentials and signatures redacted
+func redactParams(params url.Values) url.Values {
+	ret := url.Values{}
+	for key, val := range params {
+		ret[key] = append([]string(nil), val...)
+	}
+	for _, key := range redactedParams {
+		if ret.Get(key) != "" {
+			ret.Set(key, "REDACTED")
+		}
+	}
+	return ret
+}
+
+// Return a hash identifying the credentials in params
+func caller(params url.Values) string {
+	id := params.Get("oauth_token")
+	if id == "" {
+		id = params.Get("auth_token")
+	}
+	if id == "" {
+		id = params.Get("api_key")
+	}
+	sum := sha256.Sum256([]byte(id))
+	return hex.EncodeToString(sum[:16])
+}
+
+// Return the error code and message reported by a raw XML or JSON response,
+// 0 and an empty string if there are none. Other bodies, like OAuth errors,
+// are reported with code -1.
+func responseError(body []byte) (int, string) {
+	var resp BasicResponse
+	if err := xml.Unmarshal(body, &resp); err == nil {
+		if resp.HasErrors() {
+			return resp.ErrorCode(), resp.ErrorMsg()
+		}
+		return 0, ""
+	}
+
+	var jsonResp struct {
+		Stat    string `json:"stat"`
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(body, &jsonResp); err == nil {
+		if jsonResp.Stat != "ok" {
+			return jsonResp.Code, jsonResp.Message
+		}
+		return 0, ""
+	}
+
+	return -1, strings.TrimSpace(string(body))
+}
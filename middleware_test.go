@@ -0,0 +1,212 @@
+package flickr
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	flickErr "gopkg.in/masci/flickr.v2/error"
+)
+
+type recordedMetrics struct {
+	requests  []*RequestInfo
+	responses []*ResponseInfo
+	errors    []error
+}
+
+func (m *recordedMetrics) ObserveRequest(req *RequestInfo, res *ResponseInfo, err error) {
+	m.requests = append(m.requests, req)
+	m.responses = append(m.responses, res)
+	m.errors = append(m.errors, err)
+}
+
+func TestMetricsInterceptor(t *testing.T) {
+	server, _ := callMock(`<?xml version="1.0" encoding="utf-8" ?><rsp stat="fail"><err code="1" msg="Photo not found" /></rsp>`)
+	defer server.Close()
+
+	m := &recordedMetrics{}
+	client := NewFlickrClient("key", "secret")
+	client.Endpoints.API = server.URL
+	client.OAuthToken = "token"
+	client.Interceptors = append(client.Interceptors, MetricsInterceptor(m))
+
+	resp := &BasicResponse{}
+	err := client.Call(context.Background(), "flickr.photos.getInfo", nil, resp)
+	Expect(t, err != nil, true)
+	// callers still get the response
+	Expect(t, resp.ErrorCode(), 1)
+
+	Expect(t, len(m.requests), 1)
+	req, res := m.requests[0], m.responses[0]
+	Expect(t, m.errors[0], nil)
+	Expect(t, req.Method, "flickr.photos.getInfo")
+	Expect(t, req.Endpoint, server.URL)
+	Expect(t, req.Params.Get("api_key"), "key")
+	Expect(t, req.Params.Get("oauth_token"), "REDACTED")
+	Expect(t, req.Params.Get("oauth_signature"), "REDACTED")
+	Expect(t, len(req.Caller), 32)
+	Expect(t, strings.Contains(req.Caller, "token"), false)
+	// the client Args are not redacted
+	Expect(t, client.Args.Get("oauth_token"), "token")
+	Expect(t, res.StatusCode, 200)
+	Expect(t, res.ErrorCode, 1)
+	Expect(t, res.ErrorMsg, "Photo not found")
+	Expect(t, res.Size > 0, true)
+	Expect(t, res.Latency > 0, true)
+}
+
+func TestMetricsInterceptorTransportError(t *testing.T) {
+	m := &recordedMetrics{}
+	client := NewFlickrClient("key", "secret")
+	client.Endpoints.API = "http://127.0.0.1:0"
+	client.Interceptors = append(client.Interceptors, MetricsInterceptor(m))
+
+	err := client.Call(context.Background(), "flickr.test.null", nil, &BasicResponse{})
+	Expect(t, err != nil, true)
+	Expect(t, len(m.requests), 1)
+	Expect(t, m.responses[0] == nil, true)
+	Expect(t, m.errors[0] != nil, true)
+}
+
+func TestInterceptorsOrder(t *testing.T) {
+	server, requests := callMock(`<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"></rsp>`)
+	defer server.Close()
+
+	var calls []string
+	trace := func(name string) Interceptor {
+		return func(ctx context.Context, req *RequestInfo, next Handler) (*ResponseInfo, error) {
+			calls = append(calls, name+" before")
+			res, err := next(ctx, req)
+			calls = append(calls, name+" after")
+			return res, err
+		}
+	}
+	client := NewFlickrClient("key", "secret")
+	client.Endpoints.API = server.URL
+	client.Interceptors = []Interceptor{trace("outer"), trace("inner")}
+
+	err := client.Call(context.Background(), "flickr.test.null", nil, &BasicResponse{})
+	Expect(t, err, nil)
+	Expect(t, len(*requests), 1)
+	Expect(t, strings.Join(calls, ", "), "outer before, inner before, inner after, outer after")
+}
+
+func TestInterceptorAnswer(t *testing.T) {
+	server, requests := callMock(`<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"></rsp>`)
+	defer server.Close()
+
+	client := NewFlickrClient("key", "secret")
+	client.Endpoints.API = server.URL
+	client.Interceptors = append(client.Interceptors, func(ctx context.Context, req *RequestInfo, next Handler) (*ResponseInfo, error) {
+		if req.Method == "flickr.photos.delete" {
+			return nil, errors.New("deleting photos is not allowed")
+		}
+		body := `<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"><foo>Foo!</foo></rsp>`
+		res := &http.Response{
+			StatusCode: 200,
+			Header:     http.Header{},
+			Body:       ioutil.NopCloser(strings.NewReader(body)),
+		}
+		return &ResponseInfo{Response: res, StatusCode: 200, Size: len(body)}, nil
+	})
+
+	resp := &FooResponse{}
+	err := client.Call(context.Background(), "flickr.test.foo", nil, resp)
+	Expect(t, err, nil)
+	Expect(t, resp.Foo, "Foo!")
+	err = client.Call(context.Background(), "flickr.photos.delete", nil, &BasicResponse{})
+	ferr, ok := err.(*flickErr.Error)
+	Expect(t, ok, true)
+	Expect(t, ferr.ErrorCode, flickErr.TransportError)
+	Expect(t, ferr.Err.Error(), "deleting photos is not allowed")
+	Expect(t, len(*requests), 0)
+}
+
+func TestInterceptorNoResponse(t *testing.T) {
+	server, requests := callMock(`<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"></rsp>`)
+	defer server.Close()
+
+	client := NewFlickrClient("key", "secret")
+	client.Endpoints.API = server.URL
+	client.Interceptors = append(client.Interceptors, func(ctx context.Context, req *RequestInfo, next Handler) (*ResponseInfo, error) {
+		if req.Method == "flickr.test.echo" {
+			return &ResponseInfo{}, nil
+		}
+		return nil, nil
+	})
+
+	err := client.Call(context.Background(), "flickr.test.null", nil, &BasicResponse{})
+	ferr, ok := err.(*flickErr.Error)
+	Expect(t, ok, true)
+	Expect(t, ferr.ErrorCode, flickErr.TransportError)
+	Expect(t, ferr.Err, errNoResponse)
+
+	err = client.Call(context.Background(), "flickr.test.echo", nil, &BasicResponse{})
+	ferr, ok = err.(*flickErr.Error)
+	Expect(t, ok, true)
+	Expect(t, ferr.Err, errNoResponse)
+	Expect(t, len(*requests), 0)
+}
+
+func TestInterceptorsClockSkew(t *testing.T) {
+	body := `<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"></rsp>`
+	server, client, timestamps := timestampRefusedMock(body)
+	defer server.Close()
+
+	m := &recordedMetrics{}
+	fclient := NewFlickrClient("key", "secret")
+	fclient.HTTPClient = client
+	fclient.OAuthToken = "token"
+	fclient.Interceptors = append(fclient.Interceptors, MetricsInterceptor(m))
+
+	err := fclient.Call(context.Background(), "flickr.test.null", nil, &BasicResponse{})
+	Expect(t, err, nil)
+	Expect(t, len(*timestamps), 2)
+	Expect(t, len(m.responses), 2)
+	Expect(t, m.responses[0].ErrorCode, -1)
+	Expect(t, m.responses[1].ErrorCode, 0)
+	Expect(t, fclient.now().After(time.Now().Add(59*time.Minute)), true)
+}
+
+func TestInterceptorsUpload(t *testing.T) {
+	server, _ := FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"><photoid>1234</photoid></rsp>`, "")
+	defer server.Close()
+
+	m := &recordedMetrics{}
+	fclient := GetTestClient()
+	fclient.Endpoints.Upload = server.URL
+	fclient.Interceptors = append(fclient.Interceptors, MetricsInterceptor(m))
+
+	fooFile, err := ioutil.TempFile("", "flickr.go")
+	Expect(t, err, nil)
+	defer os.Remove(fooFile.Name())
+	defer fooFile.Close()
+
+	resp, err := UploadFile(fclient, fooFile.Name(), nil)
+	Expect(t, err, nil)
+	Expect(t, resp.ID, "1234")
+	Expect(t, len(m.requests), 1)
+	Expect(t, m.requests[0].Method, "")
+	Expect(t, m.requests[0].Endpoint, server.URL)
+	Expect(t, m.responses[0].ErrorCode, 0)
+}
+
+func TestResponseError(t *testing.T) {
+	code, msg := responseError([]byte(`<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"></rsp>`))
+	Expect(t, code, 0)
+	Expect(t, msg, "")
+	code, msg = responseError([]byte(`<?xml version="1.0" encoding="utf-8" ?><rsp stat="fail"><err code="98" msg="Invalid auth token" /></rsp>`))
+	Expect(t, code, 98)
+	Expect(t, msg, "Invalid auth token")
+	code, msg = responseError([]byte(`{"stat":"fail","code":1,"message":"Photo not found"}`))
+	Expect(t, code, 1)
+	Expect(t, msg, "Photo not found")
+	code, msg = responseError([]byte("oauth_problem=signature_invalid\n"))
+	Expect(t, code, -1)
+	Expect(t, msg, "oauth_problem=signature_invalid")
+}
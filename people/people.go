@@ -2,7 +2,6 @@ package people
 
 import (
 	"fmt"
-	"strconv"
 
 	"gopkg.in/masci/flickr.v2"
 )
@@ -12,98 +11,115 @@ type PhotoList struct {
 	Pages   int `xml:"pages,attr"`
 	PerPage int `xml:"perpage,attr"`
 	Total   int `xml:"total,attr"`
-	Photo   struct {
-		Id       string `xml:"id,attr"`
-		Owner    string `xml:"owner,attr"`
-		Secret   string `xml:"secret,attr"`
-		Server   string `xml:"server,attr"`
-		Farm     string `xml:"farm,attr"`
-		Title    string `xml:"title,attr"`
-		IsPublic bool   `xml:"ispublic,attr"`
-		IsFriend bool   `xml:"isfriend,attr"`
-		IsFamily bool   `xml:"isfamily,attr"`
-
-		// if extras contains "url_o" these are populated
-		UrlO    string `xml:"url_o,attr"`
-		HeightO int    `xml:"height_o,attr"`
-		WidthO  int    `xml:"width_o,attr"`
-
-		Description    string `xml:"description,attr"`
-		License        string `xml:"license,attr"`
-		DateUpload     string `xml:"date_upload,attr"`
-		DateTaken      string `xml:"date_taken,attr"`
-		OwnerName      string `xml:"owner_name,attr"`
-		IconServer     string `xml:"icon_server,attr"`
-		OriginalFormat string `xml:"original_format",attr`
-		LastUpdate     string `xml:"last_udpate",attr`
-
-		// Geo - these attributes are provided when extras contains "geo"
-		Latitude  string `xml:"latitude,attr"`
-		Longitude string `xml:"longitude,attr"`
-		Accuracy  string `xml:"accuracy,attr"`
-		Context   string `xml:"context,attr"`
-
-		// Tags - contains space-separated lists
-		Tags        string `xml:"tags,attr"`
-		MachineTags string `xml:"machine_tags,attr"`
-
-		// Original Dimensions - these attributes are provided
-		// when extras contains "o_dims"
-		OWidth  int `xml:"o_width,attr"`
-		OHeight int `xml:"o_height,attr"`
-
-		Views     int    `xml:"views,attr"`
-		Media     string `xml:"media,attr"`
-		PathAlias string `xml:"path_alias,attr"`
-
-		// Square Urls - these attributes are provided when
-		// extras contains "url_sq"
-		UrlSq    string `xml:"url_sq,attr"`
-		HeightSq int    `xml:"height_sq,attr"`
-		WidthSq  int    `xml:"width_sq,attr"`
-
-		// Thumbnail Urls - these attributes are provided
-		// when extras contains "url_t"
-		UrlT    string `xml:"url_t,attr"`
-		HeightT int    `xml:"height_t,attr"`
-		WidthT  int    `xml:"width_t,attr"`
-
-		// Q Urls - these attributes are provided when
-		// extras contains "url_s"
-		UrlS    string `xml:"url_s,attr"`
-		HeightS int    `xml:"height_s,attr"`
-		WidthS  int    `xml:"width_s,attr"`
-
-		// M Urls - these attributes are provided when
-		// extras contains "url_m"
-		UrlM    string `xml:"url_m,attr"`
-		HeightM int    `xml:"height_m,attr"`
-		WidthM  int    `xml:"width_m,attr"`
-
-		// N Urls - these attributes are provided when
-		// extras contains "url_n"
-		UrlN    string `xml:"url_n,attr"`
-		HeightN int    `xml:"height_n,attr"`
-		WidthN  int    `xml:"width_n,attr"`
-
-		// Z Urls - these attributes are provided when
-		// extras contains "url_z"
-		UrlZ    string `xml:"url_z,attr"`
-		HeightZ int    `xml:"height_z,attr"`
-		WidthZ  int    `xml:"width_z,attr"`
-
-		// C Urls - these attributes are provided when
-		// extras contains "url_c"
-		UrlC    string `xml:"url_c,attr"`
-		HeightC int    `xml:"height_c,attr"`
-		WidthC  int    `xml:"width_c,attr"`
-
-		// L Urls - these attributes are provided when
-		// extras contains "url_l"
-		UrlL    string `xml:"url_l,attr"`
-		HeightL int    `xml:"height_l,attr"`
-		WidthL  int    `xml:"width_l,attr"`
+	// Set by GetPhotosOf in place of Pages and Total
+	HasNextPage bool    `xml:"has_next_page,attr"`
+	Photos      []Photo `xml:"photo"`
+}
+
+// Return the last photo of the list, the only one the Photo field replaced by
+// Photos used to hold.
+//
+// Deprecated: use Photos.
+func (l *PhotoList) Photo() Photo {
+	if len(l.Photos) == 0 {
+		return Photo{}
 	}
+	return l.Photos[len(l.Photos)-1]
+}
+
+type Photo struct {
+	Id       string `xml:"id,attr"`
+	Owner    string `xml:"owner,attr"`
+	Secret   string `xml:"secret,attr"`
+	Server   string `xml:"server,attr"`
+	Farm     string `xml:"farm,attr"`
+	Title    string `xml:"title,attr"`
+	IsPublic bool   `xml:"ispublic,attr"`
+	IsFriend bool   `xml:"isfriend,attr"`
+	IsFamily bool   `xml:"isfamily,attr"`
+
+	// if extras contains "url_o" these are populated
+	UrlO    string `xml:"url_o,attr"`
+	HeightO int    `xml:"height_o,attr"`
+	WidthO  int    `xml:"width_o,attr"`
+
+	Description    string `xml:"description,attr"`
+	License        string `xml:"license,attr"`
+	DateUpload     string `xml:"dateupload,attr"`
+	DateTaken      string `xml:"datetaken,attr"`
+	OwnerName      string `xml:"ownername,attr"`
+	IconServer     string `xml:"iconserver,attr"`
+	OriginalFormat string `xml:"originalformat,attr"`
+	LastUpdate     string `xml:"lastupdate,attr"`
+
+	// Geo - these attributes are provided when extras contains "geo"
+	Latitude  string `xml:"latitude,attr"`
+	Longitude string `xml:"longitude,attr"`
+	Accuracy  string `xml:"accuracy,attr"`
+	Context   string `xml:"context,attr"`
+	PlaceId   string `xml:"place_id,attr"`
+	WoeId     string `xml:"woeid,attr"`
+
+	// Tags - contains space-separated lists
+	Tags        string `xml:"tags,attr"`
+	MachineTags string `xml:"machine_tags,attr"`
+
+	// Original Dimensions - these attributes are provided
+	// when extras contains "o_dims"
+	OWidth  int `xml:"o_width,attr"`
+	OHeight int `xml:"o_height,attr"`
+
+	Views     int    `xml:"views,attr"`
+	Media     string `xml:"media,attr"`
+	PathAlias string `xml:"path_alias,attr"`
+
+	// Square Urls - these attributes are provided when
+	// extras contains "url_sq"
+	UrlSq    string `xml:"url_sq,attr"`
+	HeightSq int    `xml:"height_sq,attr"`
+	WidthSq  int    `xml:"width_sq,attr"`
+
+	// Thumbnail Urls - these attributes are provided
+	// when extras contains "url_t"
+	UrlT    string `xml:"url_t,attr"`
+	HeightT int    `xml:"height_t,attr"`
+	WidthT  int    `xml:"width_t,attr"`
+
+	// Q Urls - these attributes are provided when
+	// extras contains "url_s"
+	UrlS    string `xml:"url_s,attr"`
+	HeightS int    `xml:"height_s,attr"`
+	WidthS  int    `xml:"width_s,attr"`
+
+	// M Urls - these attributes are provided when
+	// extras contains "url_m"
+	UrlM    string `xml:"url_m,attr"`
+	HeightM int    `xml:"height_m,attr"`
+	WidthM  int    `xml:"width_m,attr"`
+
+	// N Urls - these attributes are provided when
+	// extras contains "url_n"
+	UrlN    string `xml:"url_n,attr"`
+	HeightN int    `xml:"height_n,attr"`
+	WidthN  int    `xml:"width_n,attr"`
+
+	// Z Urls - these attributes are provided when
+	// extras contains "url_z"
+	UrlZ    string `xml:"url_z,attr"`
+	HeightZ int    `xml:"height_z,attr"`
+	WidthZ  int    `xml:"width_z,attr"`
+
+	// C Urls - these attributes are provided when
+	// extras contains "url_c"
+	UrlC    string `xml:"url_c,attr"`
+	HeightC int    `xml:"height_c,attr"`
+	WidthC  int    `xml:"width_c,attr"`
+
+	// L Urls - these attributes are provided when
+	// extras contains "url_l"
+	UrlL    string `xml:"url_l,attr"`
+	HeightL int    `xml:"height_l,attr"`
+	WidthL  int    `xml:"width_l,attr"`
 }
 
 type PhotoListResponse struct {
@@ -157,51 +173,222 @@ type GetPhotosOptionalArgs struct {
 	Page          int               // 0 to ignore
 }
 
-func GetPhotos(client *flickr.FlickrClient,
-	userId string, opts GetPhotosOptionalArgs) (*PhotoListResponse, error) {
-	client.Init()
-	client.EndpointUrl = flickr.API_ENDPOINT
-	client.Args.Set("method", "flickr.people.getPhotos")
-	client.Args.Set("user_id", userId)
-	if opts.SafeSearch != NoSafetySpecified {
-		client.Args.Set("safe_search", strconv.Itoa(int(opts.SafeSearch)))
-	}
-	if opts.MinUploadDate != "" {
-		client.Args.Set("min_upload_date", opts.MinUploadDate)
-	}
-	if opts.MaxUploadDate != "" {
-		client.Args.Set("min_upload_date", opts.MaxUploadDate)
-	}
-	if opts.MinTakenDate != "" {
-		client.Args.Set("min_taken_date", opts.MinTakenDate)
-	}
-	if opts.MaxTakenDate != "" {
-		client.Args.Set("max_taken_date", opts.MaxTakenDate)
-	}
-	if opts.ContentType != NoContentTypeSpecified {
-		client.Args.Set("content_type", strconv.Itoa(int(opts.ContentType)))
-	}
-	if opts.PrivacyFilter != NoPrivacyFilterSpecified {
-		client.Args.Set("privacy_filter", strconv.Itoa(int(opts.PrivacyFilter)))
-	}
-	if opts.PerPage != 0 {
-		client.Args.Set("per_page", strconv.Itoa(opts.PerPage))
-	}
-	if opts.Page != 0 {
-		client.Args.Set("page", strconv.Itoa(opts.Page))
-	}
-	if opts.Extras != "" {
-		client.Args.Set("extras", opts.Extras)
+// Return the photos of a user
+// This method requires authentication to retrieve private photos.
+func GetPhotos(client *flickr.FlickrClient, userId string, opts GetPhotosOptionalArgs) (*PhotoListResponse, error) {
+	return NewService(client).GetPhotos(userId, opts)
+}
+
+// A user as returned by FindByEmail and FindByUsername
+type User struct {
+	Id       string `xml:"id,attr"`
+	NSID     string `xml:"nsid,attr"`
+	Username string `xml:"username"`
+}
+
+type UserResponse struct {
+	flickr.BasicResponse
+	User User `xml:"user"`
+}
+
+type Timezone struct {
+	Label      string `xml:"label,attr"`
+	Offset     string `xml:"offset,attr"`
+	TimezoneId string `xml:"timezone_id,attr"`
+}
+
+// Photo counts of a user
+type PersonPhotos struct {
+	// mysql datetime of the oldest photo taken
+	FirstDateTaken string `xml:"firstdatetaken"`
+	// Unix timestamp of the first upload
+	FirstDate string `xml:"firstdate"`
+	Count     int    `xml:"count"`
+	Views     int    `xml:"views"`
+}
+
+// The profile of a user
+type Person struct {
+	NSID        string `xml:"nsid,attr"`
+	IsPro       bool   `xml:"ispro,attr"`
+	IsDeleted   bool   `xml:"is_deleted,attr"`
+	IconServer  string `xml:"iconserver,attr"`
+	IconFarm    string `xml:"iconfarm,attr"`
+	PathAlias   string `xml:"path_alias,attr"`
+	HasStats    bool   `xml:"has_stats,attr"`
+	Gender      string `xml:"gender,attr"`
+	IgnoredUser bool   `xml:"ignored,attr"`
+	Contact     bool   `xml:"contact,attr"`
+	Friend      bool   `xml:"friend,attr"`
+	Family      bool   `xml:"family,attr"`
+	RevContact  bool   `xml:"revcontact,attr"`
+	RevFriend   bool   `xml:"revfriend,attr"`
+	RevFamily   bool   `xml:"revfamily,attr"`
+
+	Username    string       `xml:"username"`
+	RealName    string       `xml:"realname"`
+	Location    string       `xml:"location"`
+	Description string       `xml:"description"`
+	Timezone    Timezone     `xml:"timezone"`
+	PhotosUrl   string       `xml:"photosurl"`
+	ProfileUrl  string       `xml:"profileurl"`
+	MobileUrl   string       `xml:"mobileurl"`
+	Photos      PersonPhotos `xml:"photos"`
+}
+
+// Return the url of the buddy icon of the person, the default icon when the
+// person has none
+func (p *Person) BuddyIconUrl() string {
+	if p.IconServer == "" || p.IconServer == "0" {
+		return "https://www.flickr.com/images/buddyicon.gif"
 	}
-	client.OAuthSign()
-	fmt.Println("client", client)
-
-	response := &PhotoListResponse{}
-	err := flickr.DoGet(client, response)
-	//	if err == nil {
-	//		fmt.Println("API response:", response.Extra)
-	//	} else {
-	//		fmt.Println("API error:", err)
-	//	}
-	return response, err
+	return fmt.Sprintf("https://farm%s.staticflickr.com/%s/buddyicons/%s.jpg", p.IconFarm, p.IconServer, p.NSID)
+}
+
+type PersonResponse struct {
+	flickr.BasicResponse
+	Person Person `xml:"person"`
+}
+
+type GetPublicPhotosOptionalArgs struct {
+	SafeSearch SafetyLevel // optional, set to NoSafetySpecified to ignore
+	Extras     string      // optional, set to "" to ignore. comma separated string.
+	PerPage    int         // 0 to ignore
+	Page       int         // 0 to ignore
+}
+
+type GetPhotosOfOptionalArgs struct {
+	OwnerId string // optional, set to "" to ignore. only photos owned by this user
+	Extras  string // optional, set to "" to ignore. comma separated string.
+	PerPage int    // 0 to ignore
+	Page    int    // 0 to ignore
+}
+
+// A group a user belongs to
+type Group struct {
+	NSID           string `xml:"nsid,attr"`
+	Name           string `xml:"name,attr"`
+	IconFarm       string `xml:"iconfarm,attr"`
+	IconServer     string `xml:"iconserver,attr"`
+	Admin          bool   `xml:"admin,attr"`
+	EighteenPlus   bool   `xml:"eighteenplus,attr"`
+	InvitationOnly bool   `xml:"invitation_only,attr"`
+	Members        int    `xml:"members,attr"`
+	PoolCount      int    `xml:"pool_count,attr"`
+}
+
+type GroupsResponse struct {
+	flickr.BasicResponse
+	Groups []Group `xml:"groups>group"`
+}
+
+// Upload limits of a user
+type Limits struct {
+	NSID   string `xml:"nsid,attr"`
+	Photos struct {
+		// Largest dimension of the photos displayed, in pixels
+		MaxDisplayPx int `xml:"maxdisplaypx,attr"`
+		// Size of the largest photo that can be uploaded, in bytes
+		MaxUpload int64 `xml:"maxupload,attr"`
+	} `xml:"photos"`
+	Videos struct {
+		// Length of the longest video that can be uploaded, in seconds
+		MaxDuration int `xml:"maxduration,attr"`
+		// Size of the largest video that can be uploaded, in bytes
+		MaxUpload int64 `xml:"maxupload,attr"`
+	} `xml:"videos"`
+}
+
+type LimitsResponse struct {
+	flickr.BasicResponse
+	Person Limits `xml:"person"`
+}
+
+// Upload status of a user
+type UploadStatus struct {
+	Id       string `xml:"id,attr"`
+	IsPro    bool   `xml:"ispro,attr"`
+	Username string `xml:"username"`
+	// Monthly bandwidth
+	Bandwidth struct {
+		MaxBytes       int64 `xml:"maxbytes,attr"`
+		MaxKb          int64 `xml:"maxkb,attr"`
+		UsedBytes      int64 `xml:"usedbytes,attr"`
+		UsedKb         int64 `xml:"usedkb,attr"`
+		RemainingBytes int64 `xml:"remainingbytes,attr"`
+		RemainingKb    int64 `xml:"remainingkb,attr"`
+		Unlimited      bool  `xml:"unlimited,attr"`
+	} `xml:"bandwidth"`
+	// Largest photo that can be uploaded
+	FileSize struct {
+		MaxBytes int64 `xml:"maxbytes,attr"`
+		MaxKb    int64 `xml:"maxkb,attr"`
+		MaxMb    int64 `xml:"maxmb,attr"`
+	} `xml:"filesize"`
+	Sets struct {
+		Created int `xml:"created,attr"`
+		// Number of sets that can still be created, "lots" when unlimited
+		Remaining string `xml:"remaining,attr"`
+	} `xml:"sets"`
+	// Largest video that can be uploaded
+	VideoSize struct {
+		MaxBytes int64 `xml:"maxbytes,attr"`
+		MaxKb    int64 `xml:"maxkb,attr"`
+		MaxMb    int64 `xml:"maxmb,attr"`
+	} `xml:"videosize"`
+	Videos struct {
+		Uploaded int `xml:"uploaded,attr"`
+		// Number of videos that can still be uploaded, "lots" when unlimited
+		Remaining string `xml:"remaining,attr"`
+	} `xml:"videos"`
+}
+
+type UploadStatusResponse struct {
+	flickr.BasicResponse
+	User UploadStatus `xml:"user"`
+}
+
+// Return the user with the given email address
+func FindByEmail(client *flickr.FlickrClient, email string) (*UserResponse, error) {
+	return NewService(client).FindByEmail(email)
+}
+
+// Return the user with the given username
+func FindByUsername(client *flickr.FlickrClient, username string) (*UserResponse, error) {
+	return NewService(client).FindByUsername(username)
+}
+
+// Return the profile of a user
+func GetInfo(client *flickr.FlickrClient, userId string) (*PersonResponse, error) {
+	return NewService(client).GetInfo(userId)
+}
+
+// Return the public photos of a user
+func GetPublicPhotos(client *flickr.FlickrClient, userId string, opts GetPublicPhotosOptionalArgs) (*PhotoListResponse, error) {
+	return NewService(client).GetPublicPhotos(userId, opts)
+}
+
+// Return the photos a user is tagged in
+// This method requires authentication to retrieve private photos.
+func GetPhotosOf(client *flickr.FlickrClient, userId string, opts GetPhotosOfOptionalArgs) (*PhotoListResponse, error) {
+	return NewService(client).GetPhotosOf(userId, opts)
+}
+
+// Return the groups a user is a member of, extras is a comma separated string
+// This method requires authentication with 'read' permission.
+func GetGroups(client *flickr.FlickrClient, userId, extras string) (*GroupsResponse, error) {
+	return NewService(client).GetGroups(userId, extras)
+}
+
+// Return the upload limits of the calling user
+// This method requires authentication with 'read' permission.
+func GetLimits(client *flickr.FlickrClient) (*LimitsResponse, error) {
+	return NewService(client).GetLimits()
+}
+
+// Return the upload status of the calling user, like the bandwidth used this
+// month and the size of the largest file that can be uploaded
+// This method requires authentication with 'read' permission.
+func GetUploadStatus(client *flickr.FlickrClient) (*UploadStatusResponse, error) {
+	return NewService(client).GetUploadStatus()
 }
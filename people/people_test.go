@@ -0,0 +1,291 @@
+package people
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"gopkg.in/masci/flickr.v2"
+	"gopkg.in/masci/flickr.v2/flickrtest"
+)
+
+func TestGetPhotos(t *testing.T) {
+	s := flickrtest.NewServer()
+	defer s.Close()
+	u := s.AddUser(flickrtest.User{Username: "gopher"})
+	for _, title := range []string{"one", "two", "three"} {
+		_, err := s.AddPhoto(flickrtest.Photo{Owner: u.NSID, Title: title, IsPublic: true})
+		flickr.Expect(t, err, nil)
+	}
+	client := s.UserClient(u.NSID, "read")
+
+	resp, err := GetPhotos(client, u.NSID, GetPhotosOptionalArgs{PerPage: 2})
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, resp.Photos.Total, 3)
+	flickr.Expect(t, resp.Photos.Pages, 2)
+	flickr.Expect(t, len(resp.Photos.Photos), 2)
+	flickr.Expect(t, resp.Photos.Photos[0].Owner, u.NSID)
+	flickr.Expect(t, resp.Photos.Photos[0].Title != resp.Photos.Photos[1].Title, true)
+	flickr.Expect(t, resp.Photos.Photo().Id, resp.Photos.Photos[1].Id)
+}
+
+func TestGetPhotosArgs(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	body := `<rsp stat="ok"><photos page="1" pages="1" perpage="100" total="1">
+		<photo id="2636" owner="47058503995@N01" secret="a123456" server="2" farm="1" title="test_04" ispublic="1" isfriend="0" isfamily="0" dateupload="1500000000" datetaken="2017-07-14 02:40:00" ownername="gopher" iconserver="122" originalformat="jpg" lastupdate="1500000001" />
+	</photos></rsp>`
+	server, client := flickr.FlickrMock(200, body, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	opts := GetPhotosOptionalArgs{MinUploadDate: "2017-01-01 00:00:00", MaxUploadDate: "2017-12-31 00:00:00"}
+	resp, err := GetPhotos(fclient, "47058503995@N01", opts)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("min_upload_date"), "2017-01-01 00:00:00")
+	flickr.Expect(t, fclient.Args.Get("max_upload_date"), "2017-12-31 00:00:00")
+
+	p := resp.Photos.Photos[0]
+	flickr.Expect(t, p.DateUpload, "1500000000")
+	flickr.Expect(t, p.DateTaken, "2017-07-14 02:40:00")
+	flickr.Expect(t, p.OwnerName, "gopher")
+	flickr.Expect(t, p.IconServer, "122")
+	flickr.Expect(t, p.OriginalFormat, "jpg")
+	flickr.Expect(t, p.LastUpdate, "1500000001")
+}
+
+func TestFindByUsername(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	body := `<rsp stat="ok"><user id="12037949632@N01" nsid="12037949632@N01"><username>Stewart</username></user></rsp>`
+	server, client := flickr.FlickrMock(200, body, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := FindByUsername(fclient, "Stewart")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, resp.User.NSID, "12037949632@N01")
+	flickr.Expect(t, resp.User.Username, "Stewart")
+	flickr.AssertParamsInBody(t, fclient, []string{"username", "api_sig"})
+
+	resp, err = FindByEmail(fclient, "stewart@example.com")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, resp.User.Id, "12037949632@N01")
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.people.findByEmail")
+	flickr.AssertParamsInBody(t, fclient, []string{"find_email"})
+}
+
+func TestGetInfo(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	body := `<rsp stat="ok">
+		<person nsid="12037949754@N01" ispro="1" is_deleted="0" iconserver="122" iconfarm="1" path_alias="bees" has_stats="1">
+			<username>bees</username>
+			<realname>Cal Henderson</realname>
+			<mbox_sha1sum>eea6cd28e3d0003ab51b0058a684d94980b727ac</mbox_sha1sum>
+			<location>Vancouver, Canada</location>
+			<description>Photos of bees</description>
+			<timezone label="Pacific Time (US &amp; Canada); Tijuana" offset="-08:00" timezone_id="PST8PDT" />
+			<photosurl>http://www.flickr.com/photos/bees/</photosurl>
+			<profileurl>http://www.flickr.com/people/bees/</profileurl>
+			<mobileurl>http://m.flickr.com/photostream.gne?id=6065</mobileurl>
+			<photos>
+				<firstdatetaken>1999-12-31 13:51:59</firstdatetaken>
+				<firstdate>1071510391</firstdate>
+				<count>449</count>
+				<views>12000</views>
+			</photos>
+		</person>
+	</rsp>`
+	server, client := flickr.FlickrMock(200, body, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetInfo(fclient, "12037949754@N01")
+	flickr.Expect(t, err, nil)
+	p := resp.Person
+	flickr.Expect(t, p.NSID, "12037949754@N01")
+	flickr.Expect(t, p.IsPro, true)
+	flickr.Expect(t, p.PathAlias, "bees")
+	flickr.Expect(t, p.RealName, "Cal Henderson")
+	flickr.Expect(t, p.Location, "Vancouver, Canada")
+	flickr.Expect(t, p.Timezone.TimezoneId, "PST8PDT")
+	flickr.Expect(t, p.PhotosUrl, "http://www.flickr.com/photos/bees/")
+	flickr.Expect(t, p.Photos.FirstDateTaken, "1999-12-31 13:51:59")
+	flickr.Expect(t, p.Photos.Count, 449)
+	flickr.Expect(t, p.Photos.Views, 12000)
+	flickr.Expect(t, p.BuddyIconUrl(), "https://farm1.staticflickr.com/122/buddyicons/12037949754@N01.jpg")
+	flickr.AssertParamsInBody(t, fclient, []string{"user_id", "oauth_signature"})
+
+	p.IconServer = "0"
+	flickr.Expect(t, p.BuddyIconUrl(), "https://www.flickr.com/images/buddyicon.gif")
+}
+
+func TestGetPublicPhotos(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	body := `<rsp stat="ok"><photos page="2" pages="89" perpage="10" total="881">
+		<photo id="2636" owner="47058503995@N01" secret="a123456" server="2" farm="1" title="test_04" ispublic="1" isfriend="0" isfamily="0" />
+	</photos></rsp>`
+	server, client := flickr.FlickrMock(200, body, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetPublicPhotos(fclient, "47058503995@N01", GetPublicPhotosOptionalArgs{SafeSearch: Safe, PerPage: 10, Page: 2})
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, resp.Photos.Total, 881)
+	flickr.Expect(t, resp.Photos.Photos[0].Id, "2636")
+	flickr.Expect(t, fclient.Args.Get("safe_search"), "1")
+	flickr.AssertParamsInBody(t, fclient, []string{"user_id", "safe_search", "per_page", "page", "api_sig"})
+}
+
+func TestGetPhotosOf(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	body := `<rsp stat="ok"><photos page="1" has_next_page="1" perpage="1">
+		<photo id="2636" owner="47058503995@N01" secret="a123456" server="2" farm="1" title="test_04" ispublic="1" isfriend="0" isfamily="0" />
+	</photos></rsp>`
+	server, client := flickr.FlickrMock(200, body, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetPhotosOf(fclient, "12037949754@N01", GetPhotosOfOptionalArgs{OwnerId: "47058503995@N01", PerPage: 1})
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, resp.Photos.HasNextPage, true)
+	flickr.Expect(t, len(resp.Photos.Photos), 1)
+	flickr.AssertParamsInBody(t, fclient, []string{"user_id", "owner_id", "per_page"})
+}
+
+func TestGetGroups(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	body := `<rsp stat="ok"><groups>
+		<group nsid="17274427@N00" name="Cream of the Crop - Please read the rules" iconfarm="1" iconserver="1" admin="0" eighteenplus="0" invitation_only="0" members="11935" pool_count="12522" />
+		<group nsid="20083316@N00" name="Apple" iconfarm="1" iconserver="1" admin="1" eighteenplus="0" invitation_only="1" members="11776" pool_count="62438" />
+	</groups></rsp>`
+	server, client := flickr.FlickrMock(200, body, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetGroups(fclient, "12037949754@N01", "privacy")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, len(resp.Groups), 2)
+	flickr.Expect(t, resp.Groups[1].Name, "Apple")
+	flickr.Expect(t, resp.Groups[1].Admin, true)
+	flickr.Expect(t, resp.Groups[1].InvitationOnly, true)
+	flickr.Expect(t, resp.Groups[0].Members, 11935)
+	flickr.Expect(t, resp.Groups[0].PoolCount, 12522)
+	flickr.AssertParamsInBody(t, fclient, []string{"user_id", "extras", "oauth_signature"})
+}
+
+func TestGetLimits(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	body := `<rsp stat="ok"><person nsid="30135021@N05">
+		<photos maxdisplaypx="1024" maxupload="209715200" />
+		<videos maxduration="180" maxupload="1073741824" />
+	</person></rsp>`
+	server, client := flickr.FlickrMock(200, body, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetLimits(fclient)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, resp.Person.NSID, "30135021@N05")
+	flickr.Expect(t, resp.Person.Photos.MaxDisplayPx, 1024)
+	flickr.Expect(t, resp.Person.Photos.MaxUpload, int64(209715200))
+	flickr.Expect(t, resp.Person.Videos.MaxDuration, 180)
+	flickr.Expect(t, resp.Person.Videos.MaxUpload, int64(1073741824))
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.people.getLimits")
+}
+
+func TestGetUploadStatus(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	body := `<rsp stat="ok"><user id="12037949754@N01" ispro="1">
+		<username>Bees</username>
+		<bandwidth maxbytes="2147483648" maxkb="2097152" usedbytes="383724" usedkb="374" remainingbytes="2147099924" remainingkb="2096777" unlimited="1" />
+		<filesize maxbytes="10485760" maxkb="10240" maxmb="10" />
+		<sets created="27" remaining="lots" />
+		<videosize maxbytes="1073741824" maxkb="1048576" maxmb="1024" />
+		<videos uploaded="5" remaining="lots" />
+	</user></rsp>`
+	server, client := flickr.FlickrMock(200, body, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetUploadStatus(fclient)
+	flickr.Expect(t, err, nil)
+	u := resp.User
+	flickr.Expect(t, u.Id, "12037949754@N01")
+	flickr.Expect(t, u.IsPro, true)
+	flickr.Expect(t, u.Username, "Bees")
+	flickr.Expect(t, u.Bandwidth.MaxBytes, int64(2147483648))
+	flickr.Expect(t, u.Bandwidth.RemainingBytes, int64(2147099924))
+	flickr.Expect(t, u.Bandwidth.Unlimited, true)
+	flickr.Expect(t, u.FileSize.MaxMb, int64(10))
+	flickr.Expect(t, u.Sets.Created, 27)
+	flickr.Expect(t, u.Sets.Remaining, "lots")
+	flickr.Expect(t, u.VideoSize.MaxBytes, int64(1073741824))
+	flickr.Expect(t, u.Videos.Uploaded, 5)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.people.getUploadStatus")
+}
+
+// Serve a page of 500 photos with extras, as big as people.getPhotos gets
+func photoPageServer() *httptest.Server {
+	body := &strings.Builder{}
+	body.WriteString(`<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"><photos page="1" pages="10" perpage="500" total="5000">`)
+	for i := 0; i < 500; i++ {
+		fmt.Fprintf(body, `<photo id="%d" owner="23148015@N00" secret="abcdef1234" server="65535" farm="66" title="Photo %d" ispublic="1" isfriend="0" isfamily="0" `+
+			`description="A photo of a gopher, taken on a sunny day" license="4" dateupload="1500000000" datetaken="2017-07-14 02:40:00" ownername="gopher" `+
+			`tags="gopher go golang mascot" latitude="45.4642" longitude="9.1900" accuracy="16" views="42" media="photo" `+
+			`url_sq="https://live.staticflickr.com/65535/%d_abcdef1234_s.jpg" height_sq="75" width_sq="75" `+
+			`url_m="https://live.staticflickr.com/65535/%d_abcdef1234.jpg" height_m="375" width_m="500" `+
+			`url_o="https://live.staticflickr.com/65535/%d_fedcba4321_o.jpg" height_o="3000" width_o="4000" />`, i, i, i, i, i)
+	}
+	body.WriteString(`</photos></rsp>`)
+
+	page := body.String()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, page)
+	}))
+}
+
+func TestGetPhotosExtras(t *testing.T) {
+	server := photoPageServer()
+	defer server.Close()
+	client := flickr.NewFlickrClient("key", "secret")
+	client.Endpoints.API = server.URL
+
+	resp, err := GetPhotos(client, "23148015@N00", GetPhotosOptionalArgs{PerPage: 500})
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, len(resp.Photos.Photos), 500)
+	p := resp.Photos.Photos[499]
+	flickr.Expect(t, p.Id, "499")
+	flickr.Expect(t, p.DateUpload, "1500000000")
+	flickr.Expect(t, p.DateTaken, "2017-07-14 02:40:00")
+	flickr.Expect(t, p.OwnerName, "gopher")
+	flickr.Expect(t, p.HeightO, 3000)
+}
+
+func benchmarkGetPhotos(b *testing.B, keepRawBody bool) {
+	server := photoPageServer()
+	defer server.Close()
+	client := flickr.NewFlickrClient("key", "secret")
+	client.Endpoints.API = server.URL
+	client.KeepRawBody = keepRawBody
+	opts := GetPhotosOptionalArgs{PerPage: 500, Extras: "description,license,date_upload,date_taken,owner_name,tags,geo,views,media,url_sq,url_m,url_o"}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		resp, err := GetPhotos(client, "23148015@N00", opts)
+		if err != nil || len(resp.Photos.Photos) != 500 {
+			b.Fatal(err)
+		}
+	}
+}
+
+// Memory per page of 500 photos, reported as B/op
+func BenchmarkGetPhotos(b *testing.B) {
+	benchmarkGetPhotos(b, false)
+}
+
+// Same as BenchmarkGetPhotos, keeping the raw body in the response
+func BenchmarkGetPhotosKeepRawBody(b *testing.B) {
+	benchmarkGetPhotos(b, true)
+}
@@ -0,0 +1,211 @@
+package people
+
+import (
+	"strconv"
+
+	"gopkg.in/masci/flickr.v2"
+)
+
+// Methods of the flickr.people namespace
+type Service interface {
+	// Get the photos of a user
+	GetPhotos(userId string, opts GetPhotosOptionalArgs) (*PhotoListResponse, error)
+	// Return the user with the given email address
+	FindByEmail(email string) (*UserResponse, error)
+	// Return the user with the given username
+	FindByUsername(username string) (*UserResponse, error)
+	// Return the profile of a user
+	GetInfo(userId string) (*PersonResponse, error)
+	// Return the public photos of a user
+	GetPublicPhotos(userId string, opts GetPublicPhotosOptionalArgs) (*PhotoListResponse, error)
+	// Return the photos a user is tagged in
+	GetPhotosOf(userId string, opts GetPhotosOfOptionalArgs) (*PhotoListResponse, error)
+	// Return the groups a user is a member of
+	GetGroups(userId, extras string) (*GroupsResponse, error)
+	// Return the upload limits of the calling user
+	GetLimits() (*LimitsResponse, error)
+	// Return the upload status of the calling user
+	GetUploadStatus() (*UploadStatusResponse, error)
+}
+
+// Return a Service looking up people and their photos with client
+func NewService(client *flickr.FlickrClient) Service {
+	return &service{client}
+}
+
+type service struct {
+	client *flickr.FlickrClient
+}
+
+func (s *service) GetPhotos(userId string, opts GetPhotosOptionalArgs) (*PhotoListResponse, error) {
+	client := s.client
+	client.Init()
+	client.Args.Set("method", "flickr.people.getPhotos")
+	client.Args.Set("user_id", userId)
+	if opts.SafeSearch != NoSafetySpecified {
+		client.Args.Set("safe_search", strconv.Itoa(int(opts.SafeSearch)))
+	}
+	if opts.MinUploadDate != "" {
+		client.Args.Set("min_upload_date", opts.MinUploadDate)
+	}
+	if opts.MaxUploadDate != "" {
+		client.Args.Set("max_upload_date", opts.MaxUploadDate)
+	}
+	if opts.MinTakenDate != "" {
+		client.Args.Set("min_taken_date", opts.MinTakenDate)
+	}
+	if opts.MaxTakenDate != "" {
+		client.Args.Set("max_taken_date", opts.MaxTakenDate)
+	}
+	if opts.ContentType != NoContentTypeSpecified {
+		client.Args.Set("content_type", strconv.Itoa(int(opts.ContentType)))
+	}
+	if opts.PrivacyFilter != NoPrivacyFilterSpecified {
+		client.Args.Set("privacy_filter", strconv.Itoa(int(opts.PrivacyFilter)))
+	}
+	if opts.PerPage != 0 {
+		client.Args.Set("per_page", strconv.Itoa(opts.PerPage))
+	}
+	if opts.Page != 0 {
+		client.Args.Set("page", strconv.Itoa(opts.Page))
+	}
+	if opts.Extras != "" {
+		client.Args.Set("extras", opts.Extras)
+	}
+	client.OAuthSign()
+
+	response := &PhotoListResponse{}
+	err := flickr.DoGet(client, response)
+	//	if err == nil {
+	//		fmt.Println("API response:", response.Extra)
+	//	} else {
+	//		fmt.Println("API error:", err)
+	//	}
+	return response, err
+}
+
+func (s *service) FindByEmail(email string) (*UserResponse, error) {
+	client := s.client
+	client.Init()
+	client.Args.Set("method", "flickr.people.findByEmail")
+	client.Args.Set("find_email", email)
+
+	client.ApiSign()
+
+	response := &UserResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+func (s *service) FindByUsername(username string) (*UserResponse, error) {
+	client := s.client
+	client.Init()
+	client.Args.Set("method", "flickr.people.findByUsername")
+	client.Args.Set("username", username)
+
+	client.ApiSign()
+
+	response := &UserResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+func (s *service) GetInfo(userId string) (*PersonResponse, error) {
+	client := s.client
+	client.Init()
+	client.Args.Set("method", "flickr.people.getInfo")
+	client.Args.Set("user_id", userId)
+
+	client.OAuthSign()
+
+	response := &PersonResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+func (s *service) GetPublicPhotos(userId string, opts GetPublicPhotosOptionalArgs) (*PhotoListResponse, error) {
+	client := s.client
+	client.Init()
+	client.Args.Set("method", "flickr.people.getPublicPhotos")
+	client.Args.Set("user_id", userId)
+	if opts.SafeSearch != NoSafetySpecified {
+		client.Args.Set("safe_search", strconv.Itoa(int(opts.SafeSearch)))
+	}
+	if opts.PerPage != 0 {
+		client.Args.Set("per_page", strconv.Itoa(opts.PerPage))
+	}
+	if opts.Page != 0 {
+		client.Args.Set("page", strconv.Itoa(opts.Page))
+	}
+	if opts.Extras != "" {
+		client.Args.Set("extras", opts.Extras)
+	}
+	client.ApiSign()
+
+	response := &PhotoListResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+func (s *service) GetPhotosOf(userId string, opts GetPhotosOfOptionalArgs) (*PhotoListResponse, error) {
+	client := s.client
+	client.Init()
+	client.Args.Set("method", "flickr.people.getPhotosOf")
+	client.Args.Set("user_id", userId)
+	if opts.OwnerId != "" {
+		client.Args.Set("owner_id", opts.OwnerId)
+	}
+	if opts.PerPage != 0 {
+		client.Args.Set("per_page", strconv.Itoa(opts.PerPage))
+	}
+	if opts.Page != 0 {
+		client.Args.Set("page", strconv.Itoa(opts.Page))
+	}
+	if opts.Extras != "" {
+		client.Args.Set("extras", opts.Extras)
+	}
+	client.OAuthSign()
+
+	response := &PhotoListResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+func (s *service) GetGroups(userId, extras string) (*GroupsResponse, error) {
+	client := s.client
+	client.Init()
+	client.Args.Set("method", "flickr.people.getGroups")
+	client.Args.Set("user_id", userId)
+	if extras != "" {
+		client.Args.Set("extras", extras)
+	}
+	client.OAuthSign()
+
+	response := &GroupsResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+func (s *service) GetLimits() (*LimitsResponse, error) {
+	client := s.client
+	client.Init()
+	client.Args.Set("method", "flickr.people.getLimits")
+
+	client.OAuthSign()
+
+	response := &LimitsResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+func (s *service) GetUploadStatus() (*UploadStatusResponse, error) {
+	client := s.client
+	client.Init()
+	client.Args.Set("method", "flickr.people.getUploadStatus")
+
+	client.OAuthSign()
+
+	response := &UploadStatusResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
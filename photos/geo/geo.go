@@ -0,0 +1,84 @@
+// Package implementing methods: flickr.photos.geo.*
+package geo
+
+import (
+	"gopkg.in/masci/flickr.v2"
+	"gopkg.in/masci/flickr.v2/people"
+	"gopkg.in/masci/flickr.v2/photos"
+)
+
+// Values of the context of a location
+const (
+	NotDefined = 0
+	Indoors    = 1
+	Outdoors   = 2
+)
+
+type LocationResponse struct {
+	flickr.BasicResponse
+	Photo struct {
+		Id       string          `xml:"id,attr"`
+		Location photos.Location `xml:"location"`
+	} `xml:"photo"`
+}
+
+type PermsResponse struct {
+	flickr.BasicResponse
+	Perms struct {
+		// Photo ID
+		Id string `xml:"id,attr"`
+		photos.GeoPerms
+	} `xml:"perms"`
+}
+
+// A point and the accuracy of its coordinates
+type Point struct {
+	Latitude  float64
+	Longitude float64
+	// From 1 (world) to 16 (street), 0 to use the Flickr default
+	Accuracy int
+}
+
+// Return the location of a photo
+// This method requires authentication to retrieve the location of private photos.
+func GetLocation(client *flickr.FlickrClient, photoId string) (*LocationResponse, error) {
+	return NewService(client).GetLocation(photoId)
+}
+
+// Set the location of a photo, context is one of NotDefined, Indoors and Outdoors
+// This method requires authentication with 'write' permission.
+func SetLocation(client *flickr.FlickrClient, photoId string, point Point, context int) (*flickr.BasicResponse, error) {
+	return NewService(client).SetLocation(photoId, point, context)
+}
+
+// Remove the location of a photo
+// This method requires authentication with 'write' permission.
+func RemoveLocation(client *flickr.FlickrClient, photoId string) (*flickr.BasicResponse, error) {
+	return NewService(client).RemoveLocation(photoId)
+}
+
+// Return who can see the location of a photo
+// This method requires authentication with 'read' permission.
+func GetPerms(client *flickr.FlickrClient, photoId string) (*PermsResponse, error) {
+	return NewService(client).GetPerms(photoId)
+}
+
+// Set who can see the location of a photo
+// This method requires authentication with 'write' permission.
+func SetPerms(client *flickr.FlickrClient, photoId string, perms photos.GeoPerms) (*flickr.BasicResponse, error) {
+	return NewService(client).SetPerms(photoId, perms)
+}
+
+// Correct the place of all the photos of the calling user geotagged at point,
+// setting it to the place with placeId or woeId. Set the other one to "".
+// This method requires authentication with 'write' permission.
+func BatchCorrectLocation(client *flickr.FlickrClient, point Point, placeId, woeId string) (*flickr.BasicResponse, error) {
+	return NewService(client).BatchCorrectLocation(point, placeId, woeId)
+}
+
+// Return the photos of the calling user geotagged at point. extras is a
+// comma separated string, set perPage and page to 0 to use Flickr defaults.
+// This method requires authentication with 'read' permission.
+func PhotosForLocation(client *flickr.FlickrClient, point Point, extras string, perPage, page int) (*people.PhotoListResponse, error) {
+	return NewService(client).PhotosForLocation(point, extras, perPage, page)
+}
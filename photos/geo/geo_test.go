@@ -0,0 +1,119 @@
+package geo
+
+import (
+	"testing"
+
+	"gopkg.in/masci/flickr.v2"
+	flickErr "gopkg.in/masci/flickr.v2/error"
+	"gopkg.in/masci/flickr.v2/photos"
+)
+
+func TestGetLocation(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	body := `<rsp stat="ok"><photo id="123">
+		<location latitude="-17.685895" longitude="-63.36914" accuracy="6" context="0" woeid="2346590">
+			<region place_id="Ha0a1OJSU7m7ss8Q" woeid="2346590">Santa Cruz</region>
+			<country place_id="DnwrsO5TUb5p9UpCuQ" woeid="23424762">Bolivia</country>
+		</location>
+	</photo></rsp>`
+	server, client := flickr.FlickrMock(200, body, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetLocation(fclient, "123")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, resp.Photo.Id, "123")
+	loc := resp.Photo.Location
+	flickr.Expect(t, loc.Latitude, -17.685895)
+	flickr.Expect(t, loc.Longitude, -63.36914)
+	flickr.Expect(t, loc.Accuracy, 6)
+	flickr.Expect(t, loc.Region.Name, "Santa Cruz")
+	flickr.Expect(t, loc.Country.WoeId, "23424762")
+	flickr.AssertParamsInBody(t, fclient, []string{"photo_id", "oauth_signature"})
+
+	server, client = flickr.FlickrMock(200, `<rsp stat="fail"><err code="2" msg="Photo has no location information." /></rsp>`, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+	resp, err = GetLocation(fclient, "123")
+	_, ok := err.(*flickErr.Error)
+	flickr.Expect(t, ok, true)
+	flickr.Expect(t, resp.ErrorCode(), 2)
+}
+
+func TestSetLocation(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<rsp stat="ok"></rsp>`, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	_, err := SetLocation(fclient, "123", Point{Latitude: 45.4642, Longitude: 9.19, Accuracy: 16}, Outdoors)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.HTTPVerb, "POST")
+	flickr.Expect(t, fclient.Args.Get("lat"), "45.4642")
+	flickr.Expect(t, fclient.Args.Get("lon"), "9.19")
+	flickr.Expect(t, fclient.Args.Get("accuracy"), "16")
+	flickr.Expect(t, fclient.Args.Get("context"), "2")
+	flickr.AssertParamsInBody(t, fclient, []string{"photo_id", "lat", "lon", "accuracy", "context"})
+
+	_, err = SetLocation(fclient, "123", Point{Latitude: -17.685895, Longitude: -63.36914}, NotDefined)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("lat"), "-17.685895")
+	flickr.Expect(t, fclient.Args.Get("accuracy"), "")
+	flickr.Expect(t, fclient.Args.Get("context"), "")
+
+	_, err = RemoveLocation(fclient, "123")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photos.geo.removeLocation")
+	flickr.AssertParamsInBody(t, fclient, []string{"photo_id"})
+}
+
+func TestPerms(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<rsp stat="ok"><perms id="10592" ispublic="0" iscontact="0" isfriend="0" isfamily="1" /></rsp>`, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetPerms(fclient, "10592")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, resp.Perms.Id, "10592")
+	flickr.Expect(t, resp.Perms.IsPublic, false)
+	flickr.Expect(t, resp.Perms.IsFamily, true)
+
+	_, err = SetPerms(fclient, "10592", photos.GeoPerms{IsContact: true, IsFamily: true})
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("is_public"), "0")
+	flickr.Expect(t, fclient.Args.Get("is_contact"), "1")
+	flickr.Expect(t, fclient.Args.Get("is_friend"), "0")
+	flickr.Expect(t, fclient.Args.Get("is_family"), "1")
+	flickr.AssertParamsInBody(t, fclient, []string{"photo_id", "is_public", "is_contact", "is_friend", "is_family"})
+}
+
+func TestBatchCorrectLocation(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<rsp stat="ok"></rsp>`, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	_, err := BatchCorrectLocation(fclient, Point{Latitude: 47.574433, Longitude: -122.640611, Accuracy: 11}, "", "2358151")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("place_id"), "")
+	flickr.Expect(t, fclient.Args.Get("woe_id"), "2358151")
+	flickr.AssertParamsInBody(t, fclient, []string{"lat", "lon", "accuracy", "woe_id"})
+}
+
+func TestPhotosForLocation(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	body := `<rsp stat="ok"><photos page="1" pages="1" perpage="100" total="1">
+		<photo id="2636" owner="47058503995@N01" secret="a123456" server="2" farm="1" title="Bainbridge" ispublic="1" isfriend="0" isfamily="0" latitude="47.574433" longitude="-122.640611" accuracy="16" />
+	</photos></rsp>`
+	server, client := flickr.FlickrMock(200, body, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := PhotosForLocation(fclient, Point{Latitude: 47.574433, Longitude: -122.640611}, "geo", 100, 0)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, len(resp.Photos.Photos), 1)
+	flickr.Expect(t, resp.Photos.Photos[0].Latitude, "47.574433")
+	flickr.Expect(t, fclient.HTTPVerb, "GET")
+	flickr.AssertParamsInBody(t, fclient, []string{"lat", "lon", "extras", "per_page"})
+}
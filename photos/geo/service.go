@@ -0,0 +1,170 @@
+package geo
+
+import (
+	"strconv"
+
+	"gopkg.in/masci/flickr.v2"
+	"gopkg.in/masci/flickr.v2/people"
+	"gopkg.in/masci/flickr.v2/photos"
+)
+
+// Methods of the flickr.photos.geo namespace
+type Service interface {
+	// Return the location of a photo
+	GetLocation(photoId string) (*LocationResponse, error)
+	// Set the location of a photo
+	SetLocation(photoId string, point Point, context int) (*flickr.BasicResponse, error)
+	// Remove the location of a photo
+	RemoveLocation(photoId string) (*flickr.BasicResponse, error)
+	// Return who can see the location of a photo
+	GetPerms(photoId string) (*PermsResponse, error)
+	// Set who can see the location of a photo
+	SetPerms(photoId string, perms photos.GeoPerms) (*flickr.BasicResponse, error)
+	// Correct the place of the photos of the calling user geotagged at a point
+	BatchCorrectLocation(point Point, placeId, woeId string) (*flickr.BasicResponse, error)
+	// Return the photos of the calling user geotagged at a point
+	PhotosForLocation(point Point, extras string, perPage, page int) (*people.PhotoListResponse, error)
+}
+
+// Return a Service managing photo locations with client
+func NewService(client *flickr.FlickrClient) Service {
+	return &service{client}
+}
+
+type service struct {
+	client *flickr.FlickrClient
+}
+
+func (s *service) GetLocation(photoId string) (*LocationResponse, error) {
+	client := s.client
+	client.Init()
+	client.Args.Set("method", "flickr.photos.geo.getLocation")
+	client.Args.Set("photo_id", photoId)
+
+	client.OAuthSign()
+
+	response := &LocationResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+func (s *service) SetLocation(photoId string, point Point, context int) (*flickr.BasicResponse, error) {
+	client := s.client
+	client.Init()
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photos.geo.setLocation")
+	client.Args.Set("photo_id", photoId)
+	setPoint(client, point)
+	if context != NotDefined {
+		client.Args.Set("context", strconv.Itoa(context))
+	}
+
+	client.OAuthSign()
+
+	response := &flickr.BasicResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+func (s *service) RemoveLocation(photoId string) (*flickr.BasicResponse, error) {
+	client := s.client
+	client.Init()
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photos.geo.removeLocation")
+	client.Args.Set("photo_id", photoId)
+
+	client.OAuthSign()
+
+	response := &flickr.BasicResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+func (s *service) GetPerms(photoId string) (*PermsResponse, error) {
+	client := s.client
+	client.Init()
+	client.Args.Set("method", "flickr.photos.geo.getPerms")
+	client.Args.Set("photo_id", photoId)
+
+	client.OAuthSign()
+
+	response := &PermsResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+func (s *service) SetPerms(photoId string, perms photos.GeoPerms) (*flickr.BasicResponse, error) {
+	client := s.client
+	client.Init()
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photos.geo.setPerms")
+	client.Args.Set("photo_id", photoId)
+	client.Args.Set("is_public", flag(perms.IsPublic))
+	client.Args.Set("is_contact", flag(perms.IsContact))
+	client.Args.Set("is_friend", flag(perms.IsFriend))
+	client.Args.Set("is_family", flag(perms.IsFamily))
+
+	client.OAuthSign()
+
+	response := &flickr.BasicResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+func (s *service) BatchCorrectLocation(point Point, placeId, woeId string) (*flickr.BasicResponse, error) {
+	client := s.client
+	client.Init()
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photos.geo.batchCorrectLocation")
+	setPoint(client, point)
+	if placeId != "" {
+		client.Args.Set("place_id", placeId)
+	}
+	if woeId != "" {
+		client.Args.Set("woe_id", woeId)
+	}
+
+	client.OAuthSign()
+
+	response := &flickr.BasicResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+func (s *service) PhotosForLocation(point Point, extras string, perPage, page int) (*people.PhotoListResponse, error) {
+	client := s.client
+	client.Init()
+	client.Args.Set("method", "flickr.photos.geo.photosForLocation")
+	setPoint(client, point)
+	if extras != "" {
+		client.Args.Set("extras", extras)
+	}
+	if perPage > 0 {
+		client.Args.Set("per_page", strconv.Itoa(perPage))
+	}
+	if page > 1 {
+		client.Args.Set("page", strconv.Itoa(page))
+	}
+
+	client.OAuthSign()
+
+	response := &people.PhotoListResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+// Set the lat, lon and accuracy arguments
+func setPoint(client *flickr.FlickrClient, point Point) {
+	client.Args.Set("lat", strconv.FormatFloat(point.Latitude, 'f', -1, 64))
+	client.Args.Set("lon", strconv.FormatFloat(point.Longitude, 'f', -1, 64))
+	if point.Accuracy > 0 {
+		client.Args.Set("accuracy", strconv.Itoa(point.Accuracy))
+	}
+}
+
+func flag(b bool) string {
+	if b {
+		return "1"
+	}
+	return "0"
+}
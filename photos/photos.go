@@ -1,10 +1,9 @@
 package photos
 
 import (
-	"strconv"
-	"strings"
-
 	"gopkg.in/masci/flickr.v2"
+	"gopkg.in/masci/flickr.v2/people"
+	"gopkg.in/masci/flickr.v2/places"
 )
 
 type PhotoInfo struct {
@@ -57,6 +56,10 @@ type PhotoInfo struct {
 	} `xml:"usage"`
 	Comments int   `xml:"comments"`
 	Tags     []Tag `xml:"tags>tag"`
+	// nil when the photo is not geotagged
+	Location *Location `xml:"location"`
+	// Who can see the location, nil when the photo is not geotagged
+	GeoPerms *GeoPerms `xml:"geoperms"`
 	// Notes XXX: not handled yet
 	// People XXX: not handled yet
 	// Urls XXX: not handled yet
@@ -67,6 +70,38 @@ type Tag struct {
 	Value string `xml:",chardata"`
 }
 
+// A place in the hierarchy of a location
+type LocationPlace struct {
+	PlaceId string `xml:"place_id,attr"`
+	WoeId   string `xml:"woeid,attr"`
+	Name    string `xml:",chardata"`
+}
+
+// The location of a geotagged photo
+type Location struct {
+	Latitude  float64 `xml:"latitude,attr"`
+	Longitude float64 `xml:"longitude,attr"`
+	// World level is 1, country is ~3, region ~6, city ~11, street ~16
+	Accuracy int `xml:"accuracy,attr"`
+	// 0 not defined, 1 indoors, 2 outdoors
+	Context       int           `xml:"context,attr"`
+	PlaceId       string        `xml:"place_id,attr"`
+	WoeId         string        `xml:"woeid,attr"`
+	Neighbourhood LocationPlace `xml:"neighbourhood"`
+	Locality      LocationPlace `xml:"locality"`
+	County        LocationPlace `xml:"county"`
+	Region        LocationPlace `xml:"region"`
+	Country       LocationPlace `xml:"country"`
+}
+
+// Who can see the location of a photo
+type GeoPerms struct {
+	IsPublic  bool `xml:"ispublic,attr"`
+	IsContact bool `xml:"iscontact,attr"`
+	IsFriend  bool `xml:"isfriend,attr"`
+	IsFamily  bool `xml:"isfamily,attr"`
+}
+
 type PhotoInfoResponse struct {
 	flickr.BasicResponse
 	Photo PhotoInfo `xml:"photo"`
@@ -93,100 +128,76 @@ type PhotoAccessInfo struct {
 
 // GetSizes get all the downloadable link as
 func GetSizes(client *flickr.FlickrClient, photoId string) (*PhotoAccessInfo, error) {
-
-	client.Init()
-	client.EndpointUrl = flickr.API_ENDPOINT
-	client.HTTPVerb = "POST"
-
-	client.Args.Set("method", "flickr.photos.getSizes")
-	client.Args.Set("photo_id", photoId)
-	client.OAuthSign()
-	response := &PhotoAccessInfo{}
-	err := flickr.DoPost(client, response)
-	return response, err
-
+	return NewService(client).GetSizes(photoId)
 }
 
 // Set permission of a photo from flickr
 // this method requires authentica with 'write' permission
 func SetPerms(client *flickr.FlickrClient, id string, isPublic PrivacyType, IsFriend PrivacyType, isFamily PrivacyType) (*flickr.BasicResponse, error) {
-
-	client.Init()
-	client.EndpointUrl = flickr.API_ENDPOINT
-	client.HTTPVerb = "POST"
-	client.Args.Set("method", "flickr.photos.setPerms")
-	client.Args.Set("photo_id", id)
-	client.Args.Set("is_public", strconv.Itoa(int(isPublic)))
-	client.Args.Set("is_friend", strconv.Itoa(int(IsFriend)))
-	client.Args.Set("is_family", strconv.Itoa(int(isFamily)))
-	client.OAuthSign()
-	response := &flickr.BasicResponse{}
-	err := flickr.DoPost(client, response)
-	return response, err
+	return NewService(client).SetPerms(id, isPublic, IsFriend, isFamily)
 }
 
 // Delete a photo from Flickr
 // This method requires authentication with 'delete' permission.
 func Delete(client *flickr.FlickrClient, id string) (*flickr.BasicResponse, error) {
-	client.Init()
-	client.EndpointUrl = flickr.API_ENDPOINT
-	client.HTTPVerb = "POST"
-	client.Args.Set("method", "flickr.photos.delete")
-	client.Args.Set("photo_id", id)
-	client.OAuthSign()
-
-	response := &flickr.BasicResponse{}
-	err := flickr.DoPost(client, response)
-	return response, err
+	return NewService(client).Delete(id)
 }
 
 // Get information about a Flickr photo
 func GetInfo(client *flickr.FlickrClient, id string, secret string) (*PhotoInfoResponse, error) {
-	client.Init()
-	client.EndpointUrl = flickr.API_ENDPOINT
-	client.HTTPVerb = "POST"
-	client.Args.Set("method", "flickr.photos.getInfo")
-	client.Args.Set("photo_id", id)
-	if secret != "" {
-		client.Args.Set("secret", secret)
-	}
-	client.OAuthSign()
-
-	response := &PhotoInfoResponse{}
-	err := flickr.DoPost(client, response)
-	return response, err
+	return NewService(client).GetInfo(id, secret)
 }
 
 // Set date posted and date taken on a Flickr photo
 // datePosted and dateTaken are optional and may be set to ""
 func SetDates(client *flickr.FlickrClient, id string, datePosted string, dateTaken string) (*flickr.BasicResponse, error) {
-	client.Init()
-	client.EndpointUrl = flickr.API_ENDPOINT
-	client.HTTPVerb = "POST"
-	client.Args.Set("method", "flickr.photos.setDates")
-	client.Args.Set("photo_id", id)
-	if datePosted != "" {
-		client.Args.Set("date_posted", datePosted)
-	}
-	if dateTaken != "" {
-		client.Args.Set("date_taken", dateTaken)
-	}
-	client.OAuthSign()
-
-	response := &flickr.BasicResponse{}
-	err := flickr.DoPost(client, response)
-	return response, err
+	return NewService(client).SetDates(id, datePosted, dateTaken)
 }
 
 // AddTags add tags to an existing photo
 func AddTags(client *flickr.FlickrClient, photoId string, tags []string) error {
-	client.Init()
-	client.EndpointUrl = flickr.API_ENDPOINT
-	client.HTTPVerb = "POST"
-	client.Args.Set("method", "flickr.photos.addTags")
-	client.Args.Set("photo_id", photoId)
-	client.Args.Set("tags", strings.Join(tags, ","))
-	client.OAuthSign()
-	response := &flickr.BasicResponse{}
-	return flickr.DoPost(client, response)
+	return NewService(client).AddTags(photoId, tags)
+}
+
+// Tag modes of Search
+const (
+	// Photos with any of the tags
+	AnyTag = "any"
+	// Photos with all the tags
+	AllTags = "all"
+)
+
+// Criteria of Search, zero values are ignored. Flickr refuses searches
+// without any criteria besides Extras and pagination.
+type SearchArgs struct {
+	// Flickr ID of the owner of the photos, "me" for the calling user
+	UserId string
+	// Text to look for in titles, descriptions and tags
+	Text string
+	Tags []string
+	// AnyTag or AllTags, Flickr defaults to AnyTag
+	TagMode string
+	// Photos taken in a box, like the one of a place shape
+	BBox places.BoundingBox
+	// Photos taken in a place, by Flickr place ID or WOE ID
+	PlaceId string
+	WoeId   string
+	// Minimum accuracy of the photo locations, from 1 (world) to 16 (street)
+	Accuracy int
+	// Only photos with a location
+	HasGeo bool
+	// MySQL datetimes or Unix timestamps
+	MinUploadDate string
+	MaxUploadDate string
+	// Comma separated list of extra fields, like "geo,tags"
+	Extras  string
+	PerPage int
+	Page    int
+}
+
+// Return the photos matching args
+// This method requires authentication to include the private photos of the
+// calling user.
+func Search(client *flickr.FlickrClient, authenticate bool, args SearchArgs) (*people.PhotoListResponse, error) {
+	return NewService(client).Search(authenticate, args)
 }
@@ -1,10 +1,15 @@
 package photos
 
 import (
+	"sort"
+	"strings"
 	"testing"
 
 	"gopkg.in/masci/flickr.v2"
 	flickErr "gopkg.in/masci/flickr.v2/error"
+	"gopkg.in/masci/flickr.v2/flickrtest"
+	"gopkg.in/masci/flickr.v2/people"
+	"gopkg.in/masci/flickr.v2/places"
 )
 
 const photoInfo = `<?xml version="1.0" encoding="utf-8" ?>
@@ -89,3 +94,107 @@ func TestGetTags(t *testing.T) {
 	}
 	flickr.Expect(t, resp.HasErrors(), false)
 }
+
+func TestGetInfoLocation(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	body := `<rsp stat="ok">
+		<photo id="2733" secret="123456" server="12" farm="1" dateuploaded="1121221580" isfavorite="0" license="3" safety_level="0" rotation="0" views="6" media="photo">
+			<title>orford_castle_taster</title>
+			<location latitude="47.574433" longitude="-122.640611" accuracy="16" context="2" place_id="2L3e6SJWVrlkzXxQnA" woeid="2358151">
+				<locality place_id="2L3e6SJWVrlkzXxQnA" woeid="2358151">Bainbridge Island</locality>
+				<county place_id="hCca8XSYA5nn0X1Sfw" woeid="12590066">Kitsap</county>
+				<region place_id="hVUWVhqbBZlZSrZU" woeid="2347606">Washington</region>
+				<country place_id="nz.gsghTUb4c2WAecA" woeid="23424977">United States</country>
+			</location>
+			<geoperms ispublic="1" iscontact="0" isfriend="0" isfamily="0" />
+		</photo>
+	</rsp>`
+	server, client := flickr.FlickrMock(200, body, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetInfo(fclient, "2733", "")
+	flickr.Expect(t, err, nil)
+	loc := resp.Photo.Location
+	flickr.Expect(t, loc != nil, true)
+	flickr.Expect(t, loc.Latitude, 47.574433)
+	flickr.Expect(t, loc.Longitude, -122.640611)
+	flickr.Expect(t, loc.Accuracy, 16)
+	flickr.Expect(t, loc.Context, 2)
+	flickr.Expect(t, loc.WoeId, "2358151")
+	flickr.Expect(t, loc.Locality.Name, "Bainbridge Island")
+	flickr.Expect(t, loc.County.Name, "Kitsap")
+	flickr.Expect(t, loc.Region.WoeId, "2347606")
+	flickr.Expect(t, loc.Country.Name, "United States")
+	flickr.Expect(t, loc.Country.PlaceId, "nz.gsghTUb4c2WAecA")
+	flickr.Expect(t, loc.Neighbourhood.Name, "")
+	flickr.Expect(t, resp.Photo.GeoPerms.IsPublic, true)
+	flickr.Expect(t, resp.Photo.GeoPerms.IsFamily, false)
+
+	server, client = flickr.FlickrMock(200, photoInfo, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+	resp, err = GetInfo(fclient, "52435165562", "")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, resp.Photo.Location == nil, true)
+	flickr.Expect(t, resp.Photo.GeoPerms == nil, true)
+}
+
+func TestSearch(t *testing.T) {
+	s := flickrtest.NewServer()
+	defer s.Close()
+	u := s.AddUser(flickrtest.User{Username: "gopher"})
+	add := func(title string, public bool, loc *flickrtest.Location) string {
+		p, err := s.AddPhoto(flickrtest.Photo{Owner: u.NSID, Title: title, IsPublic: public, Location: loc})
+		flickr.Expect(t, err, nil)
+		return p.ID
+	}
+	rome := add("rome", true, &flickrtest.Location{Latitude: 41.9, Longitude: 12.5, PlaceID: "rome", WoeID: "721943"})
+	add("milan", true, &flickrtest.Location{Latitude: 45.46, Longitude: 9.19, PlaceID: "milan", WoeID: "718345"})
+	add("private", false, &flickrtest.Location{Latitude: 41.89, Longitude: 12.49, PlaceID: "rome", WoeID: "721943"})
+	add("nowhere", true, nil)
+
+	ids := func(resp *people.PhotoListResponse) string {
+		var ret []string
+		for _, p := range resp.Photos.Photos {
+			ret = append(ret, p.Title)
+		}
+		sort.Strings(ret)
+		return strings.Join(ret, ",")
+	}
+
+	lazio := places.BoundingBox{MinLatitude: 41, MinLongitude: 11.4, MaxLatitude: 42.8, MaxLongitude: 14}
+	client := s.Client()
+	resp, err := Search(client, false, SearchArgs{BBox: lazio, Extras: "geo"})
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, client.Args.Get("bbox"), "11.4,41,14,42.8")
+	flickr.Expect(t, client.Args.Get("api_sig") != "", true)
+	flickr.Expect(t, ids(resp), "rome")
+	p := resp.Photos.Photos[0]
+	flickr.Expect(t, p.Id, rome)
+	flickr.Expect(t, p.Latitude, "41.9")
+	flickr.Expect(t, p.Longitude, "12.5")
+	flickr.Expect(t, p.Accuracy, "16")
+	flickr.Expect(t, p.PlaceId, "rome")
+	flickr.Expect(t, p.WoeId, "721943")
+
+	resp, err = Search(client, false, SearchArgs{WoeId: "718345"})
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, ids(resp), "milan")
+
+	resp, err = Search(client, false, SearchArgs{HasGeo: true})
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, ids(resp), "milan,rome")
+
+	// the owner also finds their private photos
+	client = s.UserClient(u.NSID, "read")
+	resp, err = Search(client, true, SearchArgs{UserId: "me", PlaceId: "rome"})
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, client.Args.Get("oauth_signature") != "", true)
+	flickr.Expect(t, ids(resp), "private,rome")
+
+	_, err = Search(s.Client(), false, SearchArgs{Extras: "geo"})
+	ferr, ok := err.(*flickErr.Error)
+	flickr.Expect(t, ok, true)
+	flickr.Expect(t, ferr.ApiCode, 3)
+}
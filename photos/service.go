@@ -0,0 +1,170 @@
+package photos
+
+import (
+	"strconv"
+	"strings"
+
+	"gopkg.in/masci/flickr.v2"
+	"gopkg.in/masci/flickr.v2/people"
+	"gopkg.in/masci/flickr.v2/places"
+)
+
+// Methods of the flickr.photos namespace
+type Service interface {
+	// Get the available sizes of a photo, with their download links
+	GetSizes(photoId string) (*PhotoAccessInfo, error)
+	// Set permission of a photo from flickr
+	SetPerms(id string, isPublic PrivacyType, IsFriend PrivacyType, isFamily PrivacyType) (*flickr.BasicResponse, error)
+	// Delete a photo from Flickr
+	Delete(id string) (*flickr.BasicResponse, error)
+	// Get information about a Flickr photo
+	GetInfo(id string, secret string) (*PhotoInfoResponse, error)
+	// Set date posted and date taken on a Flickr photo
+	SetDates(id string, datePosted string, dateTaken string) (*flickr.BasicResponse, error)
+	// AddTags add tags to an existing photo
+	AddTags(photoId string, tags []string) error
+	// Return the photos matching args
+	Search(authenticate bool, args SearchArgs) (*people.PhotoListResponse, error)
+}
+
+// Return a Service managing photos with client
+func NewService(client *flickr.FlickrClient) Service {
+	return &service{client}
+}
+
+type service struct {
+	client *flickr.FlickrClient
+}
+
+func (s *service) GetSizes(photoId string) (*PhotoAccessInfo, error) {
+	client := s.client
+	client.Init()
+	client.HTTPVerb = "POST"
+
+	client.Args.Set("method", "flickr.photos.getSizes")
+	client.Args.Set("photo_id", photoId)
+	client.OAuthSign()
+	response := &PhotoAccessInfo{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+func (s *service) SetPerms(id string, isPublic PrivacyType, IsFriend PrivacyType, isFamily PrivacyType) (*flickr.BasicResponse, error) {
+	client := s.client
+	client.Init()
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photos.setPerms")
+	client.Args.Set("photo_id", id)
+	client.Args.Set("is_public", strconv.Itoa(int(isPublic)))
+	client.Args.Set("is_friend", strconv.Itoa(int(IsFriend)))
+	client.Args.Set("is_family", strconv.Itoa(int(isFamily)))
+	client.OAuthSign()
+	response := &flickr.BasicResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+func (s *service) Delete(id string) (*flickr.BasicResponse, error) {
+	client := s.client
+	client.Init()
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photos.delete")
+	client.Args.Set("photo_id", id)
+	client.OAuthSign()
+
+	response := &flickr.BasicResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+func (s *service) GetInfo(id string, secret string) (*PhotoInfoResponse, error) {
+	client := s.client
+	client.Init()
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photos.getInfo")
+	client.Args.Set("photo_id", id)
+	if secret != "" {
+		client.Args.Set("secret", secret)
+	}
+	client.OAuthSign()
+
+	response := &PhotoInfoResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+func (s *service) SetDates(id string, datePosted string, dateTaken string) (*flickr.BasicResponse, error) {
+	client := s.client
+	client.Init()
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photos.setDates")
+	client.Args.Set("photo_id", id)
+	if datePosted != "" {
+		client.Args.Set("date_posted", datePosted)
+	}
+	if dateTaken != "" {
+		client.Args.Set("date_taken", dateTaken)
+	}
+	client.OAuthSign()
+
+	response := &flickr.BasicResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+func (s *service) AddTags(photoId string, tags []string) error {
+	client := s.client
+	client.Init()
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photos.addTags")
+	client.Args.Set("photo_id", photoId)
+	client.Args.Set("tags", strings.Join(tags, ","))
+	client.OAuthSign()
+	response := &flickr.BasicResponse{}
+	return flickr.DoPost(client, response)
+}
+
+func (s *service) Search(authenticate bool, args SearchArgs) (*people.PhotoListResponse, error) {
+	client := s.client
+	client.Init()
+	client.Args.Set("method", "flickr.photos.search")
+	set := func(name, value string) {
+		if value != "" {
+			client.Args.Set(name, value)
+		}
+	}
+	set("user_id", args.UserId)
+	set("text", args.Text)
+	set("tags", strings.Join(args.Tags, ","))
+	set("tag_mode", args.TagMode)
+	if args.BBox != (places.BoundingBox{}) {
+		client.Args.Set("bbox", args.BBox.String())
+	}
+	set("place_id", args.PlaceId)
+	set("woe_id", args.WoeId)
+	if args.Accuracy > 0 {
+		client.Args.Set("accuracy", strconv.Itoa(args.Accuracy))
+	}
+	if args.HasGeo {
+		client.Args.Set("has_geo", "1")
+	}
+	set("min_upload_date", args.MinUploadDate)
+	set("max_upload_date", args.MaxUploadDate)
+	set("extras", args.Extras)
+	if args.PerPage > 0 {
+		client.Args.Set("per_page", strconv.Itoa(args.PerPage))
+	}
+	// if not provided, flickr defaults this argument to 1
+	if args.Page > 1 {
+		client.Args.Set("page", strconv.Itoa(args.Page))
+	}
+	if authenticate {
+		client.OAuthSign()
+	} else {
+		client.ApiSign()
+	}
+
+	response := &people.PhotoListResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
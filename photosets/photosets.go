@@ -2,9 +2,6 @@
 package photosets
 
 import (
-	"strconv"
-	"strings"
-
 	"gopkg.in/masci/flickr.v2"
 )
 
@@ -65,228 +62,77 @@ type PhotosListResponse struct {
 // If userId is not provided it defaults to the caller user but call needs to be authenticated.
 // This method requires authentication to retrieve private sets.
 func GetList(client *flickr.FlickrClient, authenticate bool, userId string, page int) (*PhotosetsListResponse, error) {
-	client.Init()
-	client.Args.Set("method", "flickr.photosets.getList")
-	if userId != "" {
-		client.Args.Set("user_id", userId)
-	}
-	// if not provided, flickr defaults this argument to 1
-	if page > 1 {
-		client.Args.Set("page", strconv.Itoa(page))
-	}
-	// perform authentication if requested
-	if authenticate {
-		client.OAuthSign()
-	} else {
-		client.ApiSign()
-	}
-
-	response := &PhotosetsListResponse{}
-	err := flickr.DoGet(client, response)
-	return response, err
+	return NewService(client).GetList(authenticate, userId, page)
 }
 
 // Add a photo to a photoset
 // This method requires authentication with 'write' permission.
 func AddPhoto(client *flickr.FlickrClient, photosetId, photoId string) (*flickr.BasicResponse, error) {
-	client.Init()
-	client.HTTPVerb = "POST"
-	client.Args.Set("method", "flickr.photosets.addPhoto")
-	client.Args.Set("photoset_id", photosetId)
-	client.Args.Set("photo_id", photoId)
-
-	client.OAuthSign()
-
-	response := &flickr.BasicResponse{}
-	err := flickr.DoPost(client, response)
-	return response, err
+	return NewService(client).AddPhoto(photosetId, photoId)
 }
 
 // Create a photoset specifying its primary photo
 // This method requires authentication with 'write' permission.
 func Create(client *flickr.FlickrClient, title, description, primaryPhotoId string) (*PhotosetResponse, error) {
-	client.Init()
-	client.HTTPVerb = "POST"
-	client.Args.Set("method", "flickr.photosets.create")
-	client.Args.Set("title", title)
-	client.Args.Set("description", description)
-	client.Args.Set("primary_photo_id", primaryPhotoId)
-
-	client.OAuthSign()
-
-	response := &PhotosetResponse{}
-	err := flickr.DoPost(client, response)
-	return response, err
+	return NewService(client).Create(title, description, primaryPhotoId)
 }
 
 // Delete a photoset
 // This method requires authentication with 'write' permission.
 func Delete(client *flickr.FlickrClient, photosetId string) (*flickr.BasicResponse, error) {
-	client.Init()
-	client.HTTPVerb = "POST"
-	client.Args.Set("method", "flickr.photosets.delete")
-	client.Args.Set("photoset_id", photosetId)
-
-	client.OAuthSign()
-
-	response := &flickr.BasicResponse{}
-	err := flickr.DoPost(client, response)
-	return response, err
+	return NewService(client).Delete(photosetId)
 }
 
 // Remove a photo from a photoset
 // This method requires authentication with 'write' permission.
 func RemovePhoto(client *flickr.FlickrClient, photosetId, photoId string) (*flickr.BasicResponse, error) {
-	client.Init()
-	client.HTTPVerb = "POST"
-	client.Args.Set("method", "flickr.photosets.removePhoto")
-	client.Args.Set("photoset_id", photosetId)
-	client.Args.Set("photo_id", photoId)
-
-	client.OAuthSign()
-
-	response := &flickr.BasicResponse{}
-	err := flickr.DoPost(client, response)
-	return response, err
+	return NewService(client).RemovePhoto(photosetId, photoId)
 }
 
 // Get the photos in a set
 // This method requires authentication to retrieve photos from private sets
 func GetPhotos(client *flickr.FlickrClient, authenticate bool, photosetId, ownerID string, page int) (*PhotosListResponse, error) {
-	client.Init()
-	client.Args.Set("method", "flickr.photosets.getPhotos")
-	client.Args.Set("photoset_id", photosetId)
-	// this argument is optional but increases query performances
-	if ownerID != "" {
-		client.Args.Set("user_id", ownerID)
-	}
-	// if not provided, flickr defaults this argument to 1
-	if page > 1 {
-		client.Args.Set("page", strconv.Itoa(page))
-	}
-	// sign the client for authentication and authorization
-	if authenticate {
-		client.OAuthSign()
-	} else {
-		client.ApiSign()
-	}
-
-	response := &PhotosListResponse{}
-	err := flickr.DoGet(client, response)
-	return response, err
+	return NewService(client).GetPhotos(authenticate, photosetId, ownerID, page)
 }
 
 // Edit set name and description
 // This method requires authentication with 'write' permission.
 func EditMeta(client *flickr.FlickrClient, photosetId, title, description string) (*flickr.BasicResponse, error) {
-	client.Init()
-	client.HTTPVerb = "POST"
-	client.Args.Set("method", "flickr.photosets.editMeta")
-	client.Args.Set("photoset_id", photosetId)
-	client.Args.Set("title", title)
-	if description != "" {
-		client.Args.Set("description", description)
-	}
-
-	client.OAuthSign()
-
-	response := &flickr.BasicResponse{}
-	err := flickr.DoPost(client, response)
-	return response, err
+	return NewService(client).EditMeta(photosetId, title, description)
 }
 
 // Modify the photos in a photoset. Use this method to add, remove and re-order photos.
 // This method requires authentication with 'write' permission.
 func EditPhotos(client *flickr.FlickrClient, photosetId, primaryId string, photoIds []string) (*flickr.BasicResponse, error) {
-	client.Init()
-	client.HTTPVerb = "POST"
-	client.Args.Set("method", "flickr.photosets.editPhotos")
-	client.Args.Set("photoset_id", photosetId)
-	client.Args.Set("primary_photo_id", primaryId)
-	photos := strings.Join(photoIds, ",")
-	client.Args.Set("photo_ids", photos)
-
-	client.OAuthSign()
-
-	response := &flickr.BasicResponse{}
-	err := flickr.DoPost(client, response)
-	return response, err
+	return NewService(client).EditPhotos(photosetId, primaryId, photoIds)
 }
 
 // Gets information about a photoset.
 // This method does not require authentication unless you want to access a private set
 func GetInfo(client *flickr.FlickrClient, authenticate bool, photosetId, ownerID string) (*PhotosetResponse, error) {
-	client.Init()
-	client.Args.Set("method", "flickr.photosets.getInfo")
-	client.Args.Set("photoset_id", photosetId)
-	// this argument is optional but increases query performances
-	if ownerID != "" {
-		client.Args.Set("user_id", ownerID)
-	}
-
-	// sign the client for authentication and authorization
-	if authenticate {
-		client.OAuthSign()
-	} else {
-		client.ApiSign()
-	}
-
-	response := &PhotosetResponse{}
-	err := flickr.DoGet(client, response)
-	return response, err
+	return NewService(client).GetInfo(authenticate, photosetId, ownerID)
 }
 
 // Set the order of photosets for the calling user.
 // Any set IDs not given in the list will be set to appear at the end of the list, ordered by their IDs.
 // This method requires authentication with 'write' permission.
 func OrderSets(client *flickr.FlickrClient, photosetIds []string) (*flickr.BasicResponse, error) {
-	client.Init()
-	client.HTTPVerb = "POST"
-	client.Args.Set("method", "flickr.photosets.orderSets")
-	sets := strings.Join(photosetIds, ",")
-	client.Args.Set("photoset_ids", sets)
-
-	client.OAuthSign()
-
-	response := &flickr.BasicResponse{}
-	err := flickr.DoPost(client, response)
-	return response, err
+	return NewService(client).OrderSets(photosetIds)
 }
 
 // Remove multiple photos from a photoset.
 // This method requires authentication with 'write' permission.
 func RemovePhotos(client *flickr.FlickrClient, photosetId string, photoIds []string) (*flickr.BasicResponse, error) {
-	client.Init()
-	client.HTTPVerb = "POST"
-	client.Args.Set("method", "flickr.photosets.removePhotos")
-	client.Args.Set("photoset_id", photosetId)
-	photos := strings.Join(photoIds, ",")
-	client.Args.Set("photo_ids", photos)
-
-	client.OAuthSign()
-
-	response := &flickr.BasicResponse{}
-	err := flickr.DoPost(client, response)
-	return response, err
+	return NewService(client).RemovePhotos(photosetId, photoIds)
 }
 
 // Alias for EditPhotos
 func ReorderPhotos(client *flickr.FlickrClient, photosetId, primaryId string, photoIds []string) (*flickr.BasicResponse, error) {
-	return EditPhotos(client, photosetId, primaryId, photoIds)
+	return NewService(client).ReorderPhotos(photosetId, primaryId, photoIds)
 }
 
 // Set photoset primary photo
 // This method requires authentication with 'write' permission.
 func SetPrimaryPhoto(client *flickr.FlickrClient, photosetId, primaryId string) (*flickr.BasicResponse, error) {
-	client.Init()
-	client.HTTPVerb = "POST"
-	client.Args.Set("method", "flickr.photosets.setPrimaryPhoto")
-	client.Args.Set("photoset_id", photosetId)
-	client.Args.Set("photo_id", primaryId)
-
-	client.OAuthSign()
-
-	response := &flickr.BasicResponse{}
-	err := flickr.DoPost(client, response)
-	return response, err
+	return NewService(client).SetPrimaryPhoto(photosetId, primaryId)
 }
@@ -0,0 +1,263 @@
+package photosets
+
+import (
+	"strconv"
+	"strings"
+
+	"gopkg.in/masci/flickr.v2"
+)
+
+// Methods of the flickr.photosets namespace
+type Service interface {
+	// Return the public sets belonging to the user with userId.
+	GetList(authenticate bool, userId string, page int) (*PhotosetsListResponse, error)
+	// Add a photo to a photoset
+	AddPhoto(photosetId, photoId string) (*flickr.BasicResponse, error)
+	// Create a photoset specifying its primary photo
+	Create(title, description, primaryPhotoId string) (*PhotosetResponse, error)
+	// Delete a photoset
+	Delete(photosetId string) (*flickr.BasicResponse, error)
+	// Remove a photo from a photoset
+	RemovePhoto(photosetId, photoId string) (*flickr.BasicResponse, error)
+	// Get the photos in a set
+	GetPhotos(authenticate bool, photosetId, ownerID string, page int) (*PhotosListResponse, error)
+	// Edit set name and description
+	EditMeta(photosetId, title, description string) (*flickr.BasicResponse, error)
+	// Modify the photos in a photoset. Use this method to add, remove and re-order photos.
+	EditPhotos(photosetId, primaryId string, photoIds []string) (*flickr.BasicResponse, error)
+	// Gets information about a photoset.
+	GetInfo(authenticate bool, photosetId, ownerID string) (*PhotosetResponse, error)
+	// Set the order of photosets for the calling user.
+	OrderSets(photosetIds []string) (*flickr.BasicResponse, error)
+	// Remove multiple photos from a photoset.
+	RemovePhotos(photosetId string, photoIds []string) (*flickr.BasicResponse, error)
+	// Alias for EditPhotos
+	ReorderPhotos(photosetId, primaryId string, photoIds []string) (*flickr.BasicResponse, error)
+	// Set photoset primary photo
+	SetPrimaryPhoto(photosetId, primaryId string) (*flickr.BasicResponse, error)
+}
+
+// Return a Service managing photosets with client
+func NewService(client *flickr.FlickrClient) Service {
+	return &service{client}
+}
+
+type service struct {
+	client *flickr.FlickrClient
+}
+
+func (s *service) GetList(authenticate bool, userId string, page int) (*PhotosetsListResponse, error) {
+	client := s.client
+	client.Init()
+	client.Args.Set("method", "flickr.photosets.getList")
+	if userId != "" {
+		client.Args.Set("user_id", userId)
+	}
+	// if not provided, flickr defaults this argument to 1
+	if page > 1 {
+		client.Args.Set("page", strconv.Itoa(page))
+	}
+	// perform authentication if requested
+	if authenticate {
+		client.OAuthSign()
+	} else {
+		client.ApiSign()
+	}
+
+	response := &PhotosetsListResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+func (s *service) AddPhoto(photosetId, photoId string) (*flickr.BasicResponse, error) {
+	client := s.client
+	client.Init()
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photosets.addPhoto")
+	client.Args.Set("photoset_id", photosetId)
+	client.Args.Set("photo_id", photoId)
+
+	client.OAuthSign()
+
+	response := &flickr.BasicResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+func (s *service) Create(title, description, primaryPhotoId string) (*PhotosetResponse, error) {
+	client := s.client
+	client.Init()
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photosets.create")
+	client.Args.Set("title", title)
+	client.Args.Set("description", description)
+	client.Args.Set("primary_photo_id", primaryPhotoId)
+
+	client.OAuthSign()
+
+	response := &PhotosetResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+func (s *service) Delete(photosetId string) (*flickr.BasicResponse, error) {
+	client := s.client
+	client.Init()
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photosets.delete")
+	client.Args.Set("photoset_id", photosetId)
+
+	client.OAuthSign()
+
+	response := &flickr.BasicResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+func (s *service) RemovePhoto(photosetId, photoId string) (*flickr.BasicResponse, error) {
+	client := s.client
+	client.Init()
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photosets.removePhoto")
+	client.Args.Set("photoset_id", photosetId)
+	client.Args.Set("photo_id", photoId)
+
+	client.OAuthSign()
+
+	response := &flickr.BasicResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+func (s *service) GetPhotos(authenticate bool, photosetId, ownerID string, page int) (*PhotosListResponse, error) {
+	client := s.client
+	client.Init()
+	client.Args.Set("method", "flickr.photosets.getPhotos")
+	client.Args.Set("photoset_id", photosetId)
+	// this argument is optional but increases query performances
+	if ownerID != "" {
+		client.Args.Set("user_id", ownerID)
+	}
+	// if not provided, flickr defaults this argument to 1
+	if page > 1 {
+		client.Args.Set("page", strconv.Itoa(page))
+	}
+	// sign the client for authentication and authorization
+	if authenticate {
+		client.OAuthSign()
+	} else {
+		client.ApiSign()
+	}
+
+	response := &PhotosListResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+func (s *service) EditMeta(photosetId, title, description string) (*flickr.BasicResponse, error) {
+	client := s.client
+	client.Init()
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photosets.editMeta")
+	client.Args.Set("photoset_id", photosetId)
+	client.Args.Set("title", title)
+	if description != "" {
+		client.Args.Set("description", description)
+	}
+
+	client.OAuthSign()
+
+	response := &flickr.BasicResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+func (s *service) EditPhotos(photosetId, primaryId string, photoIds []string) (*flickr.BasicResponse, error) {
+	client := s.client
+	client.Init()
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photosets.editPhotos")
+	client.Args.Set("photoset_id", photosetId)
+	client.Args.Set("primary_photo_id", primaryId)
+	photos := strings.Join(photoIds, ",")
+	client.Args.Set("photo_ids", photos)
+
+	client.OAuthSign()
+
+	response := &flickr.BasicResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+func (s *service) GetInfo(authenticate bool, photosetId, ownerID string) (*PhotosetResponse, error) {
+	client := s.client
+	client.Init()
+	client.Args.Set("method", "flickr.photosets.getInfo")
+	client.Args.Set("photoset_id", photosetId)
+	// this argument is optional but increases query performances
+	if ownerID != "" {
+		client.Args.Set("user_id", ownerID)
+	}
+
+	// sign the client for authentication and authorization
+	if authenticate {
+		client.OAuthSign()
+	} else {
+		client.ApiSign()
+	}
+
+	response := &PhotosetResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+func (s *service) OrderSets(photosetIds []string) (*flickr.BasicResponse, error) {
+	client := s.client
+	client.Init()
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photosets.orderSets")
+	sets := strings.Join(photosetIds, ",")
+	client.Args.Set("photoset_ids", sets)
+
+	client.OAuthSign()
+
+	response := &flickr.BasicResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+func (s *service) RemovePhotos(photosetId string, photoIds []string) (*flickr.BasicResponse, error) {
+	client := s.client
+	client.Init()
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photosets.removePhotos")
+	client.Args.Set("photoset_id", photosetId)
+	photos := strings.Join(photoIds, ",")
+	client.Args.Set("photo_ids", photos)
+
+	client.OAuthSign()
+
+	response := &flickr.BasicResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+func (s *service) ReorderPhotos(photosetId, primaryId string, photoIds []string) (*flickr.BasicResponse, error) {
+	client := s.client
+	return EditPhotos(client, photosetId, primaryId, photoIds)
+}
+
+func (s *service) SetPrimaryPhoto(photosetId, primaryId string) (*flickr.BasicResponse, error) {
+	client := s.client
+	client.Init()
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photosets.setPrimaryPhoto")
+	client.Args.Set("photoset_id", photosetId)
+	client.Args.Set("photo_id", primaryId)
+
+	client.OAuthSign()
+
+	response := &flickr.BasicResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
@@ -0,0 +1,71 @@
+package places
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// An area delimited by coordinates
+type BoundingBox struct {
+	MinLatitude  float64
+	MinLongitude float64
+	MaxLatitude  float64
+	MaxLongitude float64
+}
+
+// Format the box as the bbox argument of flickr.photos.search,
+// min_lon,min_lat,max_lon,max_lat
+func (b BoundingBox) String() string {
+	coords := []float64{b.MinLongitude, b.MinLatitude, b.MaxLongitude, b.MaxLatitude}
+	s := make([]string, len(coords))
+	for i, c := range coords {
+		s[i] = strconv.FormatFloat(c, 'f', -1, 64)
+	}
+	return strings.Join(s, ",")
+}
+
+// Return the smallest box containing the polylines of the shape
+func (s *ShapeData) BoundingBox() (BoundingBox, error) {
+	var (
+		box   BoundingBox
+		empty = true
+	)
+	for _, polyline := range s.Polylines {
+		for _, point := range strings.Fields(polyline) {
+			latlon := strings.Split(point, ",")
+			if len(latlon) != 2 {
+				return BoundingBox{}, fmt.Errorf("places: invalid point %q", point)
+			}
+			lat, err := strconv.ParseFloat(latlon[0], 64)
+			if err != nil {
+				return BoundingBox{}, fmt.Errorf("places: invalid point %q", point)
+			}
+			lon, err := strconv.ParseFloat(latlon[1], 64)
+			if err != nil {
+				return BoundingBox{}, fmt.Errorf("places: invalid point %q", point)
+			}
+			if empty {
+				box = BoundingBox{lat, lon, lat, lon}
+				empty = false
+				continue
+			}
+			if lat < box.MinLatitude {
+				box.MinLatitude = lat
+			}
+			if lat > box.MaxLatitude {
+				box.MaxLatitude = lat
+			}
+			if lon < box.MinLongitude {
+				box.MinLongitude = lon
+			}
+			if lon > box.MaxLongitude {
+				box.MaxLongitude = lon
+			}
+		}
+	}
+	if empty {
+		return BoundingBox{}, fmt.Errorf("places: shape has no points")
+	}
+	return box, nil
+}
@@ -0,0 +1,26 @@
+package places
+
+import (
+	"testing"
+
+	"gopkg.in/masci/flickr.v2"
+)
+
+func TestBoundingBox(t *testing.T) {
+	shape := &ShapeData{Polylines: []string{
+		"45.427,-73.622 45.481,-73.479 45.703,-73.474",
+		" 45.410,-73.940  45.501,-73.551 ",
+	}}
+	box, err := shape.BoundingBox()
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, box, BoundingBox{MinLatitude: 45.41, MinLongitude: -73.94, MaxLatitude: 45.703, MaxLongitude: -73.474})
+	flickr.Expect(t, box.String(), "-73.94,45.41,-73.474,45.703")
+
+	_, err = (&ShapeData{}).BoundingBox()
+	flickr.Expect(t, err != nil, true)
+
+	for _, polyline := range []string{"45.4", "45.4,abc", "abc,-73.6", "45.4,-73.6,1"} {
+		_, err = (&ShapeData{Polylines: []string{polyline}}).BoundingBox()
+		flickr.Expect(t, err != nil, true)
+	}
+}
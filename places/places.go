@@ -0,0 +1,165 @@
+// Package implementing methods: flickr.places.*
+package places
+
+import (
+	"gopkg.in/masci/flickr.v2"
+)
+
+// Type of a place, as the place_type_id argument
+type PlaceType int
+
+const (
+	NoPlaceTypeSpecified PlaceType = 0
+	Locality             PlaceType = 7
+	Region               PlaceType = 8
+	County               PlaceType = 9
+	Country              PlaceType = 12
+	Neighbourhood        PlaceType = 22
+	Continent            PlaceType = 29
+)
+
+// A place containing another one
+type ParentPlace struct {
+	PlaceId   string  `xml:"place_id,attr"`
+	WoeId     string  `xml:"woeid,attr"`
+	Latitude  float64 `xml:"latitude,attr"`
+	Longitude float64 `xml:"longitude,attr"`
+	PlaceUrl  string  `xml:"place_url,attr"`
+	Name      string  `xml:",chardata"`
+}
+
+// The shape of a place
+type ShapeData struct {
+	// Unix timestamp
+	Created      string  `xml:"created,attr"`
+	Alpha        float64 `xml:"alpha,attr"`
+	CountPoints  int     `xml:"count_points,attr"`
+	CountEdges   int     `xml:"count_edges,attr"`
+	HasDonutHole bool    `xml:"has_donuthole,attr"`
+	IsDonutHole  bool    `xml:"is_donuthole,attr"`
+	// Space separated lat,lon points
+	Polylines []string `xml:"polylines>polyline"`
+	ShapeFile string   `xml:"urls>shapefile"`
+}
+
+type Place struct {
+	PlaceId     string    `xml:"place_id,attr"`
+	WoeId       string    `xml:"woeid,attr"`
+	Latitude    float64   `xml:"latitude,attr"`
+	Longitude   float64   `xml:"longitude,attr"`
+	PlaceUrl    string    `xml:"place_url,attr"`
+	PlaceType   string    `xml:"place_type,attr"`
+	PlaceTypeId PlaceType `xml:"place_type_id,attr"`
+	Timezone    string    `xml:"timezone,attr"`
+	// Full name, set by FindByLatLon and GetInfo
+	Name    string `xml:"name,attr"`
+	WoeName string `xml:"woe_name,attr"`
+	// Set by the methods counting photos
+	PhotoCount   int  `xml:"photo_count,attr"`
+	HasShapeData bool `xml:"has_shapedata,attr"`
+	// Full name in lists of places
+	Text string `xml:",chardata"`
+
+	// The places containing this one, set by GetInfo
+	Neighbourhood ParentPlace `xml:"neighbourhood"`
+	Locality      ParentPlace `xml:"locality"`
+	County        ParentPlace `xml:"county"`
+	Region        ParentPlace `xml:"region"`
+	Country       ParentPlace `xml:"country"`
+
+	// Set by GetInfo when HasShapeData is true
+	ShapeData *ShapeData `xml:"shapedata"`
+}
+
+// Return the places containing this one, from the neighbourhood to the
+// country, skipping the levels Flickr did not return
+func (p *Place) Hierarchy() []ParentPlace {
+	var parents []ParentPlace
+	for _, parent := range []ParentPlace{p.Neighbourhood, p.Locality, p.County, p.Region, p.Country} {
+		if parent.PlaceId != "" || parent.WoeId != "" {
+			parents = append(parents, parent)
+		}
+	}
+	return parents
+}
+
+type PlacesResponse struct {
+	flickr.BasicResponse
+	Places struct {
+		// Set by Find
+		Query string `xml:"query,attr"`
+		// Set by FindByLatLon
+		Latitude  float64 `xml:"latitude,attr"`
+		Longitude float64 `xml:"longitude,attr"`
+		Accuracy  int     `xml:"accuracy,attr"`
+		// Unix timestamps, set by GetTopPlacesList
+		DateStart string  `xml:"date_start,attr"`
+		DateStop  string  `xml:"date_stop,attr"`
+		Total     int     `xml:"total,attr"`
+		Places    []Place `xml:"place"`
+	} `xml:"places"`
+}
+
+type PlaceResponse struct {
+	flickr.BasicResponse
+	Place Place `xml:"place"`
+}
+
+type ShapesResponse struct {
+	flickr.BasicResponse
+	Shapes struct {
+		Total       int         `xml:"total,attr"`
+		PlaceId     string      `xml:"place_id,attr"`
+		WoeId       string      `xml:"woeid,attr"`
+		PlaceType   string      `xml:"place_type,attr"`
+		PlaceTypeId PlaceType   `xml:"place_type_id,attr"`
+		Shapes      []ShapeData `xml:"shapedata"`
+	} `xml:"shapes"`
+}
+
+// Return the places matching query
+func Find(client *flickr.FlickrClient, query string) (*PlacesResponse, error) {
+	return NewService(client).Find(query)
+}
+
+// Return the place at a point, at the level given by accuracy: from 1 (world)
+// to 16 (street), 0 to use the Flickr default
+func FindByLatLon(client *flickr.FlickrClient, lat, lon float64, accuracy int) (*PlacesResponse, error) {
+	return NewService(client).FindByLatLon(lat, lon, accuracy)
+}
+
+// Return information about the place with placeId or woeId, set the other
+// one to ""
+func GetInfo(client *flickr.FlickrClient, placeId, woeId string) (*PlaceResponse, error) {
+	return NewService(client).GetInfo(placeId, woeId)
+}
+
+// Return information about a place from its Flickr url, like /Canada/Quebec/Montreal
+func GetInfoByUrl(client *flickr.FlickrClient, url string) (*PlaceResponse, error) {
+	return NewService(client).GetInfoByUrl(url)
+}
+
+// Return the places within the place with placeId or woeId having public photos
+func GetChildrenWithPhotosPublic(client *flickr.FlickrClient, placeId, woeId string) (*PlacesResponse, error) {
+	return NewService(client).GetChildrenWithPhotosPublic(placeId, woeId)
+}
+
+// Return the places of type placeType where the calling user has geotagged
+// photos, within the place with placeId or woeId when not "". threshold is
+// the minimum number of photos for a place to be returned, 0 to ignore.
+// This method requires authentication with 'read' permission.
+func PlacesForUser(client *flickr.FlickrClient, placeType PlaceType, placeId, woeId string, threshold int) (*PlacesResponse, error) {
+	return NewService(client).PlacesForUser(placeType, placeId, woeId, threshold)
+}
+
+// Return the places of type placeType with the most public photos on date,
+// a YYYY-MM-DD string defaulting to yesterday when "", within the place with
+// placeId or woeId when not ""
+func GetTopPlacesList(client *flickr.FlickrClient, placeType PlaceType, date, placeId, woeId string) (*PlacesResponse, error) {
+	return NewService(client).GetTopPlacesList(placeType, date, placeId, woeId)
+}
+
+// Return all the shapes of the place with placeId or woeId
+func GetShapeHistory(client *flickr.FlickrClient, placeId, woeId string) (*ShapesResponse, error) {
+	return NewService(client).GetShapeHistory(placeId, woeId)
+}
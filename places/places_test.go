@@ -0,0 +1,174 @@
+package places
+
+import (
+	"testing"
+
+	"gopkg.in/masci/flickr.v2"
+	flickErr "gopkg.in/masci/flickr.v2/error"
+)
+
+var infoBody = `<?xml version="1.0" encoding="utf-8" ?>
+	<rsp stat="ok">
+		<place place_id="4hLQygSaBJ92" woeid="3534" latitude="45.512" longitude="-73.554" place_url="/Canada/Quebec/Montreal" place_type="locality" place_type_id="7" timezone="America/Toronto" name="Montreal, Quebec, Canada" woe_name="Montreal" has_shapedata="1">
+			<locality place_id="4hLQygSaBJ92" woeid="3534" latitude="45.512" longitude="-73.554" place_url="/Canada/Quebec/Montreal">Montreal</locality>
+			<county place_id="cFBi9x6bCJ8D5rba1g" woeid="29375198" latitude="45.551" longitude="-73.600" place_url="/cFBi9x6bCJ8D5rba1g">Montréal</county>
+			<region place_id="CrZUvXSbApjI" woeid="2344924" latitude="53.890" longitude="-68.429" place_url="/Canada/Quebec">Quebec</region>
+			<country place_id="EESRy8qbApgaeIkbsA" woeid="23424775" latitude="62.358" longitude="-96.582" place_url="/Canada">Canada</country>
+			<shapedata created="1223513357" alpha="0.012359619140625" count_points="34778" count_edges="52" has_donuthole="1" is_donuthole="1">
+				<polylines>
+					<polyline>45.427,-73.622 45.481,-73.479 45.703,-73.474</polyline>
+					<polyline>45.410,-73.940 45.501,-73.551</polyline>
+				</polylines>
+				<urls>
+					<shapefile>http://farm4.static.flickr.com/3228/shapefiles/3534_20081111_0a8afe03c5.tar.gz</shapefile>
+				</urls>
+			</shapedata>
+		</place>
+	</rsp>`
+
+func TestFind(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	body := `<rsp stat="ok"><places query="Alabama" total="2">
+		<place place_id="VrrjuESbApjeFS4." woeid="2347559" latitude="32.614" longitude="-86.680" place_url="/United+States/Alabama" place_type="region" place_type_id="8" timezone="America/Chicago" woe_name="Alabama">Alabama, US, United States</place>
+		<place place_id="cGHuc0mbApmzEHoP" woeid="2354842" latitude="43.096" longitude="-78.391" place_url="/United+States/New+York/Alabama" place_type="locality" place_type_id="7" timezone="America/New_York" woe_name="Alabama">Alabama, New York, US, United States</place>
+	</places></rsp>`
+	server, client := flickr.FlickrMock(200, body, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := Find(fclient, "Alabama")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, resp.Places.Query, "Alabama")
+	flickr.Expect(t, resp.Places.Total, 2)
+	p := resp.Places.Places[0]
+	flickr.Expect(t, p.PlaceId, "VrrjuESbApjeFS4.")
+	flickr.Expect(t, p.WoeId, "2347559")
+	flickr.Expect(t, p.Latitude, 32.614)
+	flickr.Expect(t, p.PlaceTypeId, Region)
+	flickr.Expect(t, p.Timezone, "America/Chicago")
+	flickr.Expect(t, p.Text, "Alabama, US, United States")
+	flickr.Expect(t, resp.Places.Places[1].PlaceTypeId, Locality)
+	flickr.AssertParamsInBody(t, fclient, []string{"query", "api_sig"})
+}
+
+func TestFindByLatLon(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	body := `<rsp stat="ok"><places latitude="37.76513627957266" longitude="-122.42020770907402" accuracy="16" total="1">
+		<place place_id="Y12JWsKbApmnSQpbQg" woeid="23512048" latitude="37.765" longitude="-122.424" place_url="/Y12JWsKbApmnSQpbQg" place_type="neighbourhood" place_type_id="22" timezone="America/Los_Angeles" name="Mission Dolores, San Francisco, CA, US, United States" woe_name="Mission Dolores" />
+	</places></rsp>`
+	server, client := flickr.FlickrMock(200, body, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := FindByLatLon(fclient, 37.76513627957266, -122.42020770907402, 16)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, resp.Places.Accuracy, 16)
+	flickr.Expect(t, resp.Places.Places[0].Name, "Mission Dolores, San Francisco, CA, US, United States")
+	flickr.Expect(t, resp.Places.Places[0].WoeName, "Mission Dolores")
+	flickr.Expect(t, resp.Places.Places[0].PlaceTypeId, Neighbourhood)
+	flickr.Expect(t, fclient.Args.Get("lat"), "37.76513627957266")
+	flickr.AssertParamsInBody(t, fclient, []string{"lat", "lon", "accuracy"})
+}
+
+func TestGetInfo(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, infoBody, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetInfo(fclient, "", "3534")
+	flickr.Expect(t, err, nil)
+	p := resp.Place
+	flickr.Expect(t, p.Name, "Montreal, Quebec, Canada")
+	flickr.Expect(t, p.HasShapeData, true)
+	flickr.Expect(t, p.Locality.Name, "Montreal")
+	flickr.Expect(t, p.County.Name, "Montréal")
+	flickr.Expect(t, p.Region.PlaceUrl, "/Canada/Quebec")
+	flickr.Expect(t, p.Country.WoeId, "23424775")
+	flickr.Expect(t, p.Country.Latitude, 62.358)
+
+	hierarchy := p.Hierarchy()
+	flickr.Expect(t, len(hierarchy), 4)
+	flickr.Expect(t, hierarchy[0].Name, "Montreal")
+	flickr.Expect(t, hierarchy[3].Name, "Canada")
+
+	flickr.Expect(t, p.ShapeData.CountPoints, 34778)
+	flickr.Expect(t, p.ShapeData.IsDonutHole, true)
+	flickr.Expect(t, len(p.ShapeData.Polylines), 2)
+	flickr.Expect(t, p.ShapeData.ShapeFile, "http://farm4.static.flickr.com/3228/shapefiles/3534_20081111_0a8afe03c5.tar.gz")
+
+	flickr.Expect(t, fclient.Args.Get("place_id"), "")
+	flickr.AssertParamsInBody(t, fclient, []string{"woe_id", "api_sig"})
+
+	resp, err = GetInfoByUrl(fclient, "/Canada/Quebec/Montreal")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, resp.Place.PlaceId, "4hLQygSaBJ92")
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.places.getInfoByUrl")
+	flickr.AssertParamsInBody(t, fclient, []string{"url"})
+}
+
+func TestGetInfoError(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<rsp stat="fail"><err code="2" msg="Not a valid place type" /></rsp>`, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetInfo(fclient, "unknown", "")
+	_, ok := err.(*flickErr.Error)
+	flickr.Expect(t, ok, true)
+	flickr.Expect(t, resp.ErrorCode(), 2)
+	flickr.Expect(t, len(resp.Place.Hierarchy()), 0)
+}
+
+func TestPlacesWithPhotos(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	body := `<rsp stat="ok"><places total="2" date_start="1246320000" date_stop="1246406399">
+		<place place_id="kH8dLOubBZRvX_YZ" woeid="2487956" latitude="37.779" longitude="-122.420" place_url="/United+States/California/San+Francisco" place_type="locality" place_type_id="7" photo_count="156">San Francisco, California</place>
+		<place place_id="2MVdaTCbApgqUUQ" woeid="2442047" latitude="34.053" longitude="-118.245" place_url="/United+States/California/Los+Angeles" place_type="locality" place_type_id="7" photo_count="87">Los Angeles, California</place>
+	</places></rsp>`
+	server, client := flickr.FlickrMock(200, body, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetTopPlacesList(fclient, Locality, "2009-06-30", "", "2347563")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, resp.Places.DateStart, "1246320000")
+	flickr.Expect(t, resp.Places.Places[0].PhotoCount, 156)
+	flickr.Expect(t, fclient.Args.Get("place_type_id"), "7")
+	flickr.AssertParamsInBody(t, fclient, []string{"place_type_id", "date", "woe_id"})
+
+	resp, err = PlacesForUser(fclient, Locality, "", "", 5)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, len(resp.Places.Places), 2)
+	flickr.Expect(t, fclient.Args.Get("woe_id"), "")
+	flickr.AssertParamsInBody(t, fclient, []string{"place_type_id", "threshold", "oauth_signature"})
+
+	resp, err = GetChildrenWithPhotosPublic(fclient, "NsbUWfGbApnPVHk", "")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, resp.Places.Places[1].Text, "Los Angeles, California")
+	flickr.AssertParamsInBody(t, fclient, []string{"place_id"})
+}
+
+func TestGetShapeHistory(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	body := `<rsp stat="ok"><shapes total="2" woeid="3534" place_id="4hLQygSaBJ92" place_type="locality" place_type_id="7">
+		<shapedata created="1223513357" alpha="0.012359619140625" count_points="34778" count_edges="52" is_donuthole="0">
+			<polylines><polyline>45.427,-73.622 45.481,-73.479</polyline></polylines>
+		</shapedata>
+		<shapedata created="1223513300" alpha="0.1" count_points="100" count_edges="10" is_donuthole="0">
+			<polylines><polyline>45.4,-73.6 45.5,-73.4</polyline></polylines>
+		</shapedata>
+	</shapes></rsp>`
+	server, client := flickr.FlickrMock(200, body, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetShapeHistory(fclient, "4hLQygSaBJ92", "")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, resp.Shapes.Total, 2)
+	flickr.Expect(t, resp.Shapes.WoeId, "3534")
+	flickr.Expect(t, resp.Shapes.PlaceTypeId, Locality)
+	flickr.Expect(t, len(resp.Shapes.Shapes), 2)
+	flickr.Expect(t, resp.Shapes.Shapes[1].Created, "1223513300")
+	flickr.Expect(t, resp.Shapes.Shapes[1].Polylines[0], "45.4,-73.6 45.5,-73.4")
+}
@@ -0,0 +1,162 @@
+package places
+
+import (
+	"strconv"
+
+	"gopkg.in/masci/flickr.v2"
+)
+
+// Methods of the flickr.places namespace
+type Service interface {
+	// Return the places matching a query
+	Find(query string) (*PlacesResponse, error)
+	// Return the place at a point
+	FindByLatLon(lat, lon float64, accuracy int) (*PlacesResponse, error)
+	// Return information about a place
+	GetInfo(placeId, woeId string) (*PlaceResponse, error)
+	// Return information about a place from its Flickr url
+	GetInfoByUrl(url string) (*PlaceResponse, error)
+	// Return the places within a place having public photos
+	GetChildrenWithPhotosPublic(placeId, woeId string) (*PlacesResponse, error)
+	// Return the places where the calling user has geotagged photos
+	PlacesForUser(placeType PlaceType, placeId, woeId string, threshold int) (*PlacesResponse, error)
+	// Return the places with the most public photos on a date
+	GetTopPlacesList(placeType PlaceType, date, placeId, woeId string) (*PlacesResponse, error)
+	// Return all the shapes of a place
+	GetShapeHistory(placeId, woeId string) (*ShapesResponse, error)
+}
+
+// Return a Service looking up places with client
+func NewService(client *flickr.FlickrClient) Service {
+	return &service{client}
+}
+
+type service struct {
+	client *flickr.FlickrClient
+}
+
+func (s *service) Find(query string) (*PlacesResponse, error) {
+	client := s.client
+	client.Init()
+	client.Args.Set("method", "flickr.places.find")
+	client.Args.Set("query", query)
+
+	client.ApiSign()
+
+	response := &PlacesResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+func (s *service) FindByLatLon(lat, lon float64, accuracy int) (*PlacesResponse, error) {
+	client := s.client
+	client.Init()
+	client.Args.Set("method", "flickr.places.findByLatLon")
+	client.Args.Set("lat", strconv.FormatFloat(lat, 'f', -1, 64))
+	client.Args.Set("lon", strconv.FormatFloat(lon, 'f', -1, 64))
+	if accuracy > 0 {
+		client.Args.Set("accuracy", strconv.Itoa(accuracy))
+	}
+
+	client.ApiSign()
+
+	response := &PlacesResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+func (s *service) GetInfo(placeId, woeId string) (*PlaceResponse, error) {
+	client := s.client
+	client.Init()
+	client.Args.Set("method", "flickr.places.getInfo")
+	setPlace(client, placeId, woeId)
+
+	client.ApiSign()
+
+	response := &PlaceResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+func (s *service) GetInfoByUrl(url string) (*PlaceResponse, error) {
+	client := s.client
+	client.Init()
+	client.Args.Set("method", "flickr.places.getInfoByUrl")
+	client.Args.Set("url", url)
+
+	client.ApiSign()
+
+	response := &PlaceResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+func (s *service) GetChildrenWithPhotosPublic(placeId, woeId string) (*PlacesResponse, error) {
+	client := s.client
+	client.Init()
+	client.Args.Set("method", "flickr.places.getChildrenWithPhotosPublic")
+	setPlace(client, placeId, woeId)
+
+	client.ApiSign()
+
+	response := &PlacesResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+func (s *service) PlacesForUser(placeType PlaceType, placeId, woeId string, threshold int) (*PlacesResponse, error) {
+	client := s.client
+	client.Init()
+	client.Args.Set("method", "flickr.places.placesForUser")
+	client.Args.Set("place_type_id", strconv.Itoa(int(placeType)))
+	setPlace(client, placeId, woeId)
+	if threshold > 0 {
+		client.Args.Set("threshold", strconv.Itoa(threshold))
+	}
+
+	client.OAuthSign()
+
+	response := &PlacesResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+func (s *service) GetTopPlacesList(placeType PlaceType, date, placeId, woeId string) (*PlacesResponse, error) {
+	client := s.client
+	client.Init()
+	client.Args.Set("method", "flickr.places.getTopPlacesList")
+	client.Args.Set("place_type_id", strconv.Itoa(int(placeType)))
+	if date != "" {
+		client.Args.Set("date", date)
+	}
+	setPlace(client, placeId, woeId)
+
+	client.ApiSign()
+
+	response := &PlacesResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+func (s *service) GetShapeHistory(placeId, woeId string) (*ShapesResponse, error) {
+	client := s.client
+	client.Init()
+	client.Args.Set("method", "flickr.places.getShapeHistory")
+	setPlace(client, placeId, woeId)
+
+	client.ApiSign()
+
+	response := &ShapesResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+// Set the place_id and woe_id arguments, the empty ones are ignored
+func setPlace(client *flickr.FlickrClient, placeId, woeId string) {
+	if placeId != "" {
+		client.Args.Set("place_id", placeId)
+	}
+	if woeId != "" {
+		client.Args.Set("woe_id", woeId)
+	}
+}
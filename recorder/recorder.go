@@ -0,0 +1,387 @@
+// Package recorder provides an http.RoundTripper recording Flickr interactions
+// into cassette files and replaying them, to build regression tests from real
+// sessions and run them offline.
+//
+// Before being stored, interactions are redacted: OAuth signatures, nonces and
+// timestamps are dropped, while api keys, tokens and verifiers are replaced
+// with the Redacted placeholder, both in requests and responses. Requests are
+// replayed by matching the HTTP verb, the endpoint and the normalized params,
+// Flickr method name included.
+package recorder
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// Placeholder for values removed from cassettes
+const Redacted = "REDACTED"
+
+// How a Recorder handles requests
+type Mode int
+
+const (
+	// Answer requests with the interactions stored in the cassette, failing
+	// requests that were not recorded
+	ModeReplay Mode = iota
+	// Perform requests and store the interactions in the cassette
+	ModeRecord
+)
+
+// Params changing at every request, not stored
+var volatileParams = []string{
+	"api_sig",
+	"oauth_nonce",
+	"oauth_signature",
+	"oauth_timestamp",
+}
+
+// Params carrying credentials, stored as Redacted
+var secretParams = []string{
+	"api_key",
+	"auth_token",
+	"oauth_consumer_key",
+	"oauth_token",
+	"oauth_verifier",
+}
+
+// Credentials returned by the OAuth endpoints and auth.oauth methods
+var (
+	tokenPattern        = regexp.MustCompile(`(oauth_token(?:_secret)?|oauth_verifier)(="|=)[^&"\s]*`)
+	tokenElementPattern = regexp.MustCompile(`<token>[^<]*</token>`)
+)
+
+// A request as stored in a cassette
+type Request struct {
+	// HTTP verb
+	Verb string `json:"verb"`
+	// Endpoint, without query string
+	Url string `json:"url"`
+	// Flickr method called, if any
+	Method string `json:"method,omitempty"`
+	// Redacted params from the query string and the body, urlencoded and
+	// sorted by key. File parts of multipart bodies are stored as "@filename".
+	Params string `json:"params"`
+}
+
+// A response as stored in a cassette
+type Response struct {
+	StatusCode  int    `json:"status_code"`
+	ContentType string `json:"content_type,omitempty"`
+	Body        string `json:"body"`
+}
+
+// A request along with its response
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// The interactions of a session, in the order they happened
+type Cassette struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// An http.RoundTripper recording or replaying interactions. A Recorder is safe
+// for concurrent use.
+type Recorder struct {
+	Mode Mode
+	// Transport performing requests in ModeRecord, http.DefaultTransport when nil
+	Transport http.RoundTripper
+	// Functions called on every recorded interaction before it is stored, to
+	// redact data other than credentials
+	Filters []func(*Interaction)
+
+	path     string
+	mu       sync.Mutex
+	cassette *Cassette
+	// interactions already replayed
+	used []bool
+}
+
+// Create a recorder using the cassette at path. In ModeReplay the cassette is
+// loaded, in ModeRecord it's written when calling Stop.
+func New(path string, mode Mode) (*Recorder, error) {
+	r := &Recorder{
+		Mode:     mode,
+		path:     path,
+		cassette: &Cassette{},
+	}
+	if mode == ModeReplay {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, r.cassette); err != nil {
+			return nil, fmt.Errorf("recorder: invalid cassette %s: %v", path, err)
+		}
+		r.used = make([]bool, len(r.cassette.Interactions))
+	}
+	return r, nil
+}
+
+// Return an http.Client using the recorder, to set as FlickrClient.HTTPClient
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// Write the cassette when recording, callers should call Stop when finished
+func (r *Recorder) Stop() error {
+	if r.Mode != ModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(r.path, append(data, '\n'), 0644)
+}
+
+// Implement http.RoundTripper, req is left untouched
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	if r.Mode == ModeRecord {
+		return r.record(req)
+	}
+
+	// the body is not sent anywhere else, read it as transports do
+	if req.Body != nil {
+		defer req.Body.Close()
+	}
+	stored, _, err := storedRequest(req, req.Body)
+	if err != nil {
+		return nil, err
+	}
+	return r.replay(req, stored)
+}
+
+// What storedRequest returns
+type parsedRequest struct {
+	stored  *Request
+	secrets []string
+	err     error
+}
+
+func (r *Recorder) record(req *http.Request) (*http.Response, error) {
+	rt := r.Transport
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+
+	parsed := make(chan parsedRequest, 1)
+	out := req
+	switch {
+	case req.Body == nil || req.Body == http.NoBody:
+		stored, secrets, err := storedRequest(req, nil)
+		parsed <- parsedRequest{stored, secrets, err}
+	case req.GetBody != nil:
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		stored, secrets, err := storedRequest(req, body)
+		body.Close()
+		parsed <- parsedRequest{stored, secrets, err}
+	default:
+		// the body can be read once, like the pipe of uploads: parse a
+		// copy of what the transport sends, without holding it in memory
+		pr, pw := io.Pipe()
+		out = req.Clone(req.Context())
+		out.Body = &teeBody{body: req.Body, w: pw}
+		go func() {
+			stored, secrets, err := storedRequest(req, pr)
+			io.Copy(ioutil.Discard, pr)
+			parsed <- parsedRequest{stored, secrets, err}
+		}()
+	}
+
+	res, err := rt.RoundTrip(out)
+	if err != nil {
+		return nil, err
+	}
+	p := <-parsed
+	if p.err != nil {
+		res.Body.Close()
+		return nil, p.err
+	}
+	stored, secrets := p.stored, p.secrets
+
+	body, err := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	// callers get the actual response, only the stored copy is redacted
+	res.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	in := &Interaction{
+		Request: *stored,
+		Response: Response{
+			StatusCode:  res.StatusCode,
+			ContentType: res.Header.Get("Content-Type"),
+			Body:        redactBody(string(body), secrets),
+		},
+	}
+	for _, filter := range r.Filters {
+		filter(in)
+	}
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, in)
+	r.mu.Unlock()
+	return res, nil
+}
+
+func (r *Recorder) replay(req *http.Request, stored *Request) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// identical requests are answered in the order they were recorded
+	for i, in := range r.cassette.Interactions {
+		if r.used[i] || in.Request != *stored {
+			continue
+		}
+		r.used[i] = true
+
+		header := http.Header{}
+		if in.Response.ContentType != "" {
+			header.Set("Content-Type", in.Response.ContentType)
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", in.Response.StatusCode, http.StatusText(in.Response.StatusCode)),
+			StatusCode:    in.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          ioutil.NopCloser(strings.NewReader(in.Response.Body)),
+			ContentLength: int64(len(in.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("recorder: no interaction recorded for %s %s %s", stored.Verb, stored.Url, stored.Params)
+}
+
+// Build the stored version of a request whose body is read from body, along
+// with the credentials it carries so that they can be redacted from the
+// response
+func storedRequest(req *http.Request, body io.Reader) (*Request, []string, error) {
+	params, err := requestParams(req, body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, p := range volatileParams {
+		params.Del(p)
+	}
+	var secrets []string
+	for _, p := range secretParams {
+		if v := params.Get(p); v != "" {
+			secrets = append(secrets, v)
+			params.Set(p, Redacted)
+		}
+	}
+
+	u := *req.URL
+	u.RawQuery = ""
+	u.Fragment = ""
+	return &Request{
+		Verb:   req.Method,
+		Url:    u.String(),
+		Method: params.Get("method"),
+		Params: params.Encode(),
+	}, secrets, nil
+}
+
+// Collect the params of a request from the query string and body, a reader
+// of the request body or nil. Uploaded files are skipped, not read in memory.
+func requestParams(req *http.Request, body io.Reader) (url.Values, error) {
+	params := req.URL.Query()
+	if body == nil {
+		return params, nil
+	}
+
+	mediaType, mediaParams, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	switch mediaType {
+	case "application/x-www-form-urlencoded":
+		data, err := ioutil.ReadAll(body)
+		if err != nil {
+			return nil, err
+		}
+		form, err := url.ParseQuery(string(data))
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range form {
+			params[k] = append(params[k], v...)
+		}
+	case "multipart/form-data":
+		mr := multipart.NewReader(body, mediaParams["boundary"])
+		for {
+			part, err := mr.NextPart()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			if part.FileName() != "" {
+				params.Add(part.FormName(), "@"+part.FileName())
+				continue
+			}
+			value, err := ioutil.ReadAll(part)
+			if err != nil {
+				return nil, err
+			}
+			params.Add(part.FormName(), string(value))
+		}
+	}
+	return params, nil
+}
+
+// A request body copying what the transport reads to w
+type teeBody struct {
+	body io.ReadCloser
+	w    *io.PipeWriter
+}
+
+func (t *teeBody) Read(p []byte) (int, error) {
+	n, err := t.body.Read(p)
+	if n > 0 {
+		t.w.Write(p[:n])
+	}
+	if err != nil {
+		t.w.CloseWithError(err)
+	}
+	return n, err
+}
+
+func (t *teeBody) Close() error {
+	t.w.CloseWithError(errBodyClosed)
+	return t.body.Close()
+}
+
+var errBodyClosed = errors.New("recorder: request body closed before the end")
+
+// Remove credentials from a response body
+func redactBody(body string, secrets []string) string {
+	for _, s := range secrets {
+		body = strings.Replace(body, s, Redacted, -1)
+	}
+	body = tokenPattern.ReplaceAllString(body, "${1}${2}"+Redacted)
+	return tokenElementPattern.ReplaceAllString(body, "<token>"+Redacted+"</token>")
+}
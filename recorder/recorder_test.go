@@ -0,0 +1,211 @@
+package recorder
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/masci/flickr.v2"
+	"gopkg.in/masci/flickr.v2/flickrtest"
+	"gopkg.in/masci/flickr.v2/photos"
+	"gopkg.in/masci/flickr.v2/test"
+)
+
+func tempCassette(t *testing.T) (string, func()) {
+	dir, err := ioutil.TempDir("", "recorder")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return filepath.Join(dir, "cassette.json"), func() { os.RemoveAll(dir) }
+}
+
+func TestRecordReplay(t *testing.T) {
+	path, cleanup := tempCassette(t)
+	defer cleanup()
+
+	// record a session against a fake Flickr
+	s := flickrtest.NewServer()
+	u := s.AddUser(flickrtest.User{Username: "gopher"})
+	photo, err := s.AddPhoto(flickrtest.Photo{Owner: u.NSID, Title: "Gopher", IsPublic: true})
+	flickr.Expect(t, err, nil)
+
+	rec, err := New(path, ModeRecord)
+	flickr.Expect(t, err, nil)
+	rec.Transport = s.HTTPClient().Transport
+	client := s.UserClient(u.NSID, "write")
+	client.HTTPClient = rec.Client()
+	token := client.OAuthToken
+
+	login, err := test.Login(client)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, login.User.Username, "gopher")
+	err = photos.AddTags(client, photo.ID, []string{"foo"})
+	flickr.Expect(t, err, nil)
+	info, err := photos.GetInfo(client, photo.ID, "")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, len(info.Photo.Tags), 1)
+	flickr.Expect(t, rec.Stop(), nil)
+	s.Close()
+
+	data, err := ioutil.ReadFile(path)
+	flickr.Expect(t, err, nil)
+	for _, leaked := range []string{s.APIKey, token, "oauth_signature=", "oauth_nonce="} {
+		flickr.Expect(t, strings.Contains(string(data), leaked), false)
+	}
+	flickr.Expect(t, strings.Contains(string(data), "flickr.photos.addTags"), true)
+
+	// replay it offline, credentials don't need to match
+	rec, err = New(path, ModeReplay)
+	flickr.Expect(t, err, nil)
+	client = flickr.NewFlickrClient("another-key", "another-secret")
+	client.Endpoints = s.Endpoints()
+	client.OAuthToken = "another-token"
+	client.OAuthTokenSecret = "another-token-secret"
+	client.HTTPClient = rec.Client()
+
+	login, err = test.Login(client)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, login.User.Username, "gopher")
+	err = photos.AddTags(client, photo.ID, []string{"foo"})
+	flickr.Expect(t, err, nil)
+	info, err = photos.GetInfo(client, photo.ID, "")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, info.Photo.Title, "Gopher")
+
+	// every interaction is replayed once
+	_, err = test.Login(client)
+	flickr.Expect(t, err != nil, true)
+	// params must match
+	_, err = photos.GetInfo(client, "123", "")
+	flickr.Expect(t, err != nil, true)
+}
+
+// The request passed to RoundTrip is sent as is
+func TestRecordRequestUntouched(t *testing.T) {
+	path, cleanup := tempCassette(t)
+	defer cleanup()
+	s := flickrtest.NewServer()
+	defer s.Close()
+
+	rec, err := New(path, ModeRecord)
+	flickr.Expect(t, err, nil)
+	rec.Transport = s.HTTPClient().Transport
+	req, err := http.NewRequest("POST", s.Endpoints().API, strings.NewReader("method=flickr.test.echo&foo=bar&api_key="+s.APIKey))
+	flickr.Expect(t, err, nil)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	body := req.Body
+
+	res, err := rec.RoundTrip(req)
+	flickr.Expect(t, err, nil)
+	data, err := ioutil.ReadAll(res.Body)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, strings.Contains(string(data), "bar"), true)
+	flickr.Expect(t, req.Body, body)
+	flickr.Expect(t, rec.cassette.Interactions[0].Request.Params, "api_key="+Redacted+"&foo=bar&method=flickr.test.echo")
+}
+
+// Uploads are streamed, their params are recorded without the photo
+func TestRecordUpload(t *testing.T) {
+	path, cleanup := tempCassette(t)
+	defer cleanup()
+	s := flickrtest.NewServer()
+	defer s.Close()
+	u := s.AddUser(flickrtest.User{Username: "gopher"})
+
+	rec, err := New(path, ModeRecord)
+	flickr.Expect(t, err, nil)
+	rec.Transport = s.HTTPClient().Transport
+	client := s.UserClient(u.NSID, "write")
+	params := flickr.NewUploadParams()
+	params.Title = "Gopher"
+	resp, err := flickr.UploadReaderWithClient(client, strings.NewReader("photo data"), "gopher.jpg", params, rec.Client())
+	flickr.Expect(t, err, nil)
+	_, ok := s.Photo(resp.ID)
+	flickr.Expect(t, ok, true)
+
+	stored := rec.cassette.Interactions[0].Request.Params
+	flickr.Expect(t, strings.Contains(stored, "photo=%40gopher.jpg"), true)
+	flickr.Expect(t, strings.Contains(stored, "title=Gopher"), true)
+	flickr.Expect(t, strings.Contains(stored, "photo data"), false)
+}
+
+func TestRecordOAuthFlow(t *testing.T) {
+	path, cleanup := tempCassette(t)
+	defer cleanup()
+
+	s := flickrtest.NewServer()
+	defer s.Close()
+	u := s.AddUser(flickrtest.User{Username: "gopher"})
+
+	rec, err := New(path, ModeRecord)
+	flickr.Expect(t, err, nil)
+	rec.Transport = s.HTTPClient().Transport
+	client := s.Client()
+	client.HTTPClient = rec.Client()
+
+	reqTok, err := flickr.GetRequestToken(client)
+	flickr.Expect(t, err, nil)
+	verifier, err := s.Authorize(reqTok.OauthToken, u.NSID, "read")
+	flickr.Expect(t, err, nil)
+	accessTok, err := flickr.GetAccessToken(client, reqTok, verifier)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, accessTok.UserNsid, u.NSID)
+	flickr.Expect(t, rec.Stop(), nil)
+
+	data, err := ioutil.ReadFile(path)
+	flickr.Expect(t, err, nil)
+	for _, leaked := range []string{reqTok.OauthToken, reqTok.OauthTokenSecret, verifier, accessTok.OAuthToken, accessTok.OAuthTokenSecret} {
+		flickr.Expect(t, strings.Contains(string(data), leaked), false)
+	}
+
+	rec, err = New(path, ModeReplay)
+	flickr.Expect(t, err, nil)
+	client = flickr.NewFlickrClient("another-key", "another-secret")
+	client.Endpoints = s.Endpoints()
+	client.HTTPClient = rec.Client()
+	reqTok, err = flickr.GetRequestToken(client)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, reqTok.OauthToken, Redacted)
+	accessTok, err = flickr.GetAccessToken(client, reqTok, "another-verifier")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, accessTok.UserNsid, u.NSID)
+}
+
+func TestFilters(t *testing.T) {
+	path, cleanup := tempCassette(t)
+	defer cleanup()
+
+	s := flickrtest.NewServer()
+	defer s.Close()
+	u := s.AddUser(flickrtest.User{Username: "gopher"})
+
+	rec, err := New(path, ModeRecord)
+	flickr.Expect(t, err, nil)
+	rec.Transport = s.HTTPClient().Transport
+	rec.Filters = append(rec.Filters, func(in *Interaction) {
+		in.Response.Body = strings.Replace(in.Response.Body, "gopher", "someone", -1)
+	})
+	client := s.UserClient(u.NSID, "read")
+	client.HTTPClient = rec.Client()
+
+	// callers are not affected by filters
+	login, err := test.Login(client)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, login.User.Username, "gopher")
+	flickr.Expect(t, rec.Stop(), nil)
+
+	rec, err = New(path, ModeReplay)
+	flickr.Expect(t, err, nil)
+	client.HTTPClient = rec.Client()
+	login, err = test.Login(client)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, login.User.Username, "someone")
+}
+
+func TestNewMissingCassette(t *testing.T) {
+	_, err := New(filepath.Join(os.TempDir(), "does-not-exist.json"), ModeReplay)
+	flickr.Expect(t, err != nil, true)
+}
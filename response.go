@@ -1,9 +1,16 @@
 package flickr
 
 import (
+	"bufio"
+	"bytes"
 	"encoding/xml"
+	"io"
 	"io/ioutil"
+	"mime"
 	"net/http"
+	"net/url"
+	"strings"
+	"unicode"
 
 	flickErr "gopkg.in/masci/flickr.v2/error"
 )
@@ -28,7 +35,11 @@ type BasicResponse struct {
 		Code    int    `xml:"code,attr"`
 		Message string `xml:"msg,attr"`
 	} `xml:"err"`
-	Extra string `xml:",innerxml"`
+	// Content of the rsp element, only kept when the client KeepRawBody
+	// setting is on
+	Extra string `xml:"-"`
+	// Whole body of the response, only kept along with Extra
+	RawBody string `xml:"-"`
 }
 
 // Return whether a response contains errors
@@ -65,29 +76,148 @@ func (r *BasicResponse) SetErrorMsg(msg string) {
 	r.Error.Message = msg
 }
 
+// Set the raw body of the response, along with Extra when it's XML
+func (r *BasicResponse) SetRawBody(body string) {
+	r.RawBody = body
+	inner := struct {
+		Content string `xml:",innerxml"`
+	}{}
+	if xml.Unmarshal([]byte(body), &inner) == nil {
+		r.Extra = inner.Content
+	}
+}
+
+// Implemented by responses keeping their raw body, like BasicResponse
+type rawBodySetter interface {
+	SetRawBody(string)
+}
+
 // Given an http.Response retrieved from Flickr, unmarshal results
-// into a FlickrResponse struct.
-func parseApiResponse(res *http.Response, r FlickrResponse) error {
+// into a FlickrResponse struct. The body is decoded as it's read, unless
+// keepRaw is set: then it's also stored in the response, see SetRawBody.
+func parseApiResponse(res *http.Response, r FlickrResponse, keepRaw bool) error {
 	defer res.Body.Close()
-	responseBody, err := ioutil.ReadAll(res.Body)
-	if err != nil {
-		return err
+	var body io.Reader = res.Body
+	raw := &bytes.Buffer{}
+	if keepRaw {
+		body = io.TeeReader(body, raw)
 	}
+	reader := bufio.NewReader(body)
 
-	err = xml.Unmarshal(responseBody, r)
-	if err != nil {
+	var apiErr *flickErr.Error
+	if startsWithTag(reader) && isApiContentType(res.Header.Get("Content-Type")) {
+		if err := xml.NewDecoder(reader).Decode(r); err != nil {
+			r.SetErrorStatus(true)
+			r.SetErrorCode(-1)
+			r.SetErrorMsg(err.Error())
+			apiErr = unexpectedResponse(res.StatusCode, err.Error())
+		}
+	} else {
 		// In case of OAuth errors (signature, parameters, etc) Flicker does not
-		// return a REST response but raw text (!), so the unmarshalling could fail.
-		// We need to artificially build a FlickrResponse and manually fill in
-		// the error string.
+		// return a REST response but raw text (!), so we need to artificially
+		// build a FlickrResponse and manually fill in the error string.
+		text, err := ioutil.ReadAll(reader)
+		if err != nil {
+			return err
+		}
 		r.SetErrorStatus(true)
 		r.SetErrorCode(-1)
-		r.SetErrorMsg(string(responseBody))
+		r.SetErrorMsg(string(text))
+		apiErr = nonApiError(res.StatusCode, string(text))
+	}
+	// read what's left, trailing newlines usually, so that the connection
+	// can be reused and the raw body is complete
+	if _, err := io.Copy(ioutil.Discard, reader); err != nil {
+		return err
 	}
 
-	if r.HasErrors() {
-		return flickErr.NewError(flickErr.ApiError, r.ErrorMsg())
+	if setter, ok := r.(rawBodySetter); ok && keepRaw {
+		setter.SetRawBody(raw.String())
 	}
 
-	return nil
+	switch {
+	case apiErr != nil:
+	case r.HasErrors():
+		apiErr = flickErr.NewError(flickErr.ApiError, r.ErrorMsg())
+		apiErr.ApiCode = r.ErrorCode()
+		apiErr.StatusCode = res.StatusCode
+	case !statusOK(res.StatusCode):
+		apiErr = flickErr.NewHTTPError(res.StatusCode)
+		r.SetErrorStatus(true)
+		r.SetErrorCode(-1)
+		r.SetErrorMsg(apiErr.Message)
+	default:
+		return nil
+	}
+	return apiErr
+}
+
+// Return the error of a response that is not a REST one: an OAuth problem, an
+// HTTP error or an unexpected body
+func nonApiError(statusCode int, body string) *flickErr.Error {
+	if oauthProblem(body) != "" {
+		return flickErr.NewOAuthProblem(flickErr.OAuthProblemError, statusCode, body)
+	}
+	return unexpectedResponse(statusCode, body)
+}
+
+// Return the error of a response that can't be understood, described by msg
+func unexpectedResponse(statusCode int, msg string) *flickErr.Error {
+	if !statusOK(statusCode) {
+		return flickErr.NewHTTPError(statusCode)
+	}
+	// keep the message short, it could be a whole HTML page
+	if len(msg) > 200 {
+		msg = msg[:200] + "..."
+	}
+	e := flickErr.NewError(flickErr.UnexpectedResponseError, msg)
+	e.StatusCode = statusCode
+	return e
+}
+
+// Return whether an HTTP status reports success
+func statusOK(statusCode int) bool {
+	return statusCode >= 200 && statusCode < 300
+}
+
+// Return whether a response with the given content type may be a REST
+// response. Flickr answers with XML, or plain text for OAuth problems, while
+// HTML pages come from gateways and proxies. A missing content type is fine.
+func isApiContentType(contentType string) bool {
+	if contentType == "" {
+		return true
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && mediaType != "text/html"
+}
+
+// Return whether err reports that Flickr refused the OAuth timestamp
+func timestampRefused(err error) bool {
+	e, ok := err.(*flickErr.Error)
+	return ok && e.OAuthProblem == "timestamp_refused"
+}
+
+// Return whether the first char of a body, spaces excluded, opens an XML tag.
+// Nothing is consumed from the reader.
+func startsWithTag(reader *bufio.Reader) bool {
+	for n := 1; ; n++ {
+		b, _ := reader.Peek(n)
+		if len(b) < n {
+			return false
+		}
+		if c := b[n-1]; !unicode.IsSpace(rune(c)) {
+			return c == '<'
+		}
+	}
+}
+
+// Extract the "oauth_problem" field from a raw OAuth error body, something like
+// "oauth_problem=timestamp_refused&oauth_acceptable_timestamps=...".
+// Return an empty string if the body doesn't report an OAuth problem.
+func oauthProblem(body string) string {
+	val, err := url.ParseQuery(strings.TrimSpace(body))
+	if err != nil {
+		return ""
+	}
+	return val.Get("oauth_problem")
 }
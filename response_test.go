@@ -3,6 +3,7 @@ package flickr
 import (
 	"encoding/xml"
 	"net/http"
+	"strings"
 	"testing"
 
 	flickErr "gopkg.in/masci/flickr.v2/error"
@@ -69,25 +70,25 @@ func TestParseResponse(t *testing.T) {
 </rsp>`
 
 	flickrResp := &FooResponse{}
-	response := &http.Response{}
+	response := &http.Response{StatusCode: 200}
 	response.Body = NewFakeBody(bodyStr)
 
-	err := parseApiResponse(response, flickrResp)
+	err := parseApiResponse(response, flickrResp, false)
 
 	Expect(t, err, nil)
 	Expect(t, flickrResp.Foo, "Foo!")
 
-	response = &http.Response{}
+	response = &http.Response{StatusCode: 200}
 	response.Body = NewFakeBody("a_non_rest_format_error")
 
-	err = parseApiResponse(response, flickrResp)
+	err = parseApiResponse(response, flickrResp, false)
 	ferr, ok := err.(*flickErr.Error)
 	Expect(t, ok, true)
-	Expect(t, ferr.ErrorCode, 10)
+	Expect(t, ferr.ErrorCode, flickErr.UnexpectedResponseError)
 
-	response = &http.Response{}
+	response = &http.Response{StatusCode: 200}
 	response.Body = NewFakeBody(`<?xml version="1.0" encoding="utf-8" ?><rsp stat="fail"></rsp>`)
-	err = parseApiResponse(response, flickrResp)
+	err = parseApiResponse(response, flickrResp, false)
 	//ferr, ok := err.(*flickErr.Error)
 	//Expect(t, ok, true)
 	//Expect(t, ferr.ErrorCode, 10)
@@ -108,11 +109,82 @@ func TestExtra(t *testing.T) {
 </rsp>`
 
 	flickrResp := &BasicResponse{}
-	response := &http.Response{}
+	response := &http.Response{StatusCode: 200}
 	response.Body = NewFakeBody(bodyStr)
 
-	err := parseApiResponse(response, flickrResp)
+	// raw bodies are not kept by default
+	err := parseApiResponse(response, flickrResp, false)
+	Expect(t, err, nil)
+	Expect(t, flickrResp.Extra, "")
+	Expect(t, flickrResp.RawBody, "")
 
+	flickrResp = &BasicResponse{}
+	response.Body = NewFakeBody(bodyStr)
+	err = parseApiResponse(response, flickrResp, true)
 	Expect(t, err, nil)
-	Expect(t, flickrResp.Extra != "", true)
+	Expect(t, flickrResp.RawBody, bodyStr)
+	// Extra holds the content of rsp, as it always did
+	start := strings.Index(bodyStr, `<rsp stat="ok">`) + len(`<rsp stat="ok">`)
+	Expect(t, flickrResp.Extra, bodyStr[start:strings.LastIndex(bodyStr, "</rsp>")])
+}
+
+func TestParseResponseErrors(t *testing.T) {
+	tests := []struct {
+		status      int
+		contentType string
+		body        string
+		errorCode   int
+		msg         string
+	}{
+		{200, "text/plain", "\noauth_problem=signature_invalid", flickErr.OAuthProblemError, "\noauth_problem=signature_invalid"},
+		{401, "", "oauth_problem=timestamp_refused&oauth_acceptable_timestamps=1-2", flickErr.OAuthProblemError, "oauth_problem=timestamp_refused&oauth_acceptable_timestamps=1-2"},
+		{200, "text/xml", `<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"><foo>`, flickErr.UnexpectedResponseError, "XML syntax error on line 1: unexpected EOF"},
+		{200, "", "", flickErr.UnexpectedResponseError, ""},
+		{502, "text/html", "<html><body><h1>502 Bad Gateway</h1></body></html>", flickErr.HTTPError, "<html><body><h1>502 Bad Gateway</h1></body></html>"},
+		{500, "", `<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"></rsp>`, flickErr.HTTPError, "Flickr returned an HTTP error: 500 Internal Server Error"},
+		{200, "text/html; charset=utf-8", "<html><body>Sign in to the wifi</body></html>", flickErr.UnexpectedResponseError, "<html><body>Sign in to the wifi</body></html>"},
+		{500, "text/xml", `<?xml version="1.0" encoding="utf-8" ?><rsp stat="fail"><err code="105" msg="Service currently unavailable" /></rsp>`, flickErr.ApiError, "Service currently unavailable"},
+	}
+	for _, test := range tests {
+		flickrResp := &FooResponse{}
+		response := &http.Response{StatusCode: test.status, Header: http.Header{}}
+		response.Header.Set("Content-Type", test.contentType)
+		response.Body = NewFakeBody(test.body)
+
+		err := parseApiResponse(response, flickrResp, true)
+		ferr, ok := err.(*flickErr.Error)
+		Expect(t, ok, true)
+		Expect(t, ferr.ErrorCode, test.errorCode)
+		Expect(t, flickrResp.HasErrors(), true)
+		Expect(t, flickrResp.ErrorMsg(), test.msg)
+		Expect(t, flickrResp.RawBody, test.body)
+		if test.errorCode == flickErr.HTTPError {
+			Expect(t, ferr.StatusCode, test.status)
+		}
+	}
+}
+
+func TestParseResponseOAuthProblem(t *testing.T) {
+	response := &http.Response{StatusCode: 401}
+	response.Body = NewFakeBody("oauth_problem=timestamp_refused&oauth_acceptable_timestamps=1-2")
+	err := parseApiResponse(response, &BasicResponse{}, false)
+
+	ferr := err.(*flickErr.Error)
+	Expect(t, ferr.StatusCode, 401)
+	Expect(t, ferr.OAuthProblem, "timestamp_refused")
+	Expect(t, ferr.OAuthParams.Get("oauth_acceptable_timestamps"), "1-2")
+	Expect(t, ferr.Retryable(), true)
+	Expect(t, timestampRefused(err), true)
+}
+
+func TestParseResponseApiError(t *testing.T) {
+	response := &http.Response{StatusCode: 200}
+	response.Body = NewFakeBody(`<?xml version="1.0" encoding="utf-8" ?><rsp stat="fail"><err code="1" msg="Photo not found" /></rsp>`)
+	err := parseApiResponse(response, &BasicResponse{}, false)
+
+	ferr := err.(*flickErr.Error)
+	Expect(t, ferr.ErrorCode, flickErr.ApiError)
+	Expect(t, ferr.ApiCode, 1)
+	Expect(t, ferr.StatusCode, 200)
+	Expect(t, ferr.Retryable(), false)
 }
@@ -0,0 +1,132 @@
+package flickr
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+)
+
+// The OAuth flow
+type AuthService interface {
+	// Retrieve a request token, see GetRequestToken
+	GetRequestToken() (*RequestToken, error)
+	// Return the URL users need to reach to grant permission to the application
+	GetAuthorizeUrl(reqToken *RequestToken) (string, error)
+	// Exchange a request token and its OAuth verifier for an access token
+	GetAccessToken(reqToken *RequestToken, oauthVerifier string) (*OAuthToken, error)
+}
+
+// Photo uploads
+type UploadService interface {
+	// Upload the file at path, see UploadFile
+	UploadFile(path string, optionalParams *UploadParams) (*UploadResponse, error)
+	// Upload a photo read from photoReader
+	UploadReader(photoReader io.Reader, name string, optionalParams *UploadParams) (*UploadResponse, error)
+}
+
+// Return an AuthService running the OAuth flow with client
+func NewAuthService(client *FlickrClient) AuthService {
+	return &authService{client}
+}
+
+type authService struct {
+	client *FlickrClient
+}
+
+func (s *authService) GetRequestToken() (*RequestToken, error) {
+	client := s.client
+	client.EndpointUrl = client.GetEndpoints().RequestToken
+	client.SetOAuthDefaults()
+	client.Args.Set("oauth_consumer_key", client.ApiKey)
+	client.Args.Set("oauth_callback", "oob")
+
+	// we don't have token secret at this stage, pass an empty string
+	client.Sign("")
+
+	body, err := getOAuthResponse(client)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseRequestToken(body)
+}
+
+func (s *authService) GetAuthorizeUrl(reqToken *RequestToken) (string, error) {
+	client := s.client
+	client.EndpointUrl = client.GetEndpoints().Authorize
+	client.Args = url.Values{}
+	client.Args.Set("oauth_token", reqToken.OauthToken)
+	// TODO make permission value parametric
+	client.Args.Set("perms", "delete")
+
+	return client.GetUrl(), nil
+}
+
+func (s *authService) GetAccessToken(reqToken *RequestToken, oauthVerifier string) (*OAuthToken, error) {
+	client := s.client
+	client.EndpointUrl = client.GetEndpoints().AccessToken
+	client.SetOAuthDefaults()
+	client.Args.Set("oauth_verifier", oauthVerifier)
+	client.Args.Set("oauth_consumer_key", client.ApiKey)
+	client.Args.Set("oauth_token", reqToken.OauthToken)
+	// use the request token for signing
+	client.Sign(reqToken.OauthTokenSecret)
+
+	body, err := getOAuthResponse(client)
+	if err != nil {
+		return nil, err
+	}
+
+	accessTok, err := ParseOAuthToken(body)
+
+	// set client params for convenience
+	client.OAuthToken = accessTok.OAuthToken
+	client.OAuthTokenSecret = accessTok.OAuthTokenSecret
+	client.Id = accessTok.UserNsid
+
+	return accessTok, err
+}
+
+// Return an UploadService uploading photos with client. Photos are sent with
+// httpClient, an HTTP/1.1 client is used when nil.
+func NewUploadService(client *FlickrClient, httpClient *http.Client) UploadService {
+	return &uploadService{client, httpClient}
+}
+
+type uploadService struct {
+	client     *FlickrClient
+	httpClient *http.Client
+}
+
+func (s *uploadService) UploadFile(path string, optionalParams *UploadParams) (*UploadResponse, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return s.UploadReader(file, file.Name(), optionalParams)
+}
+
+func (s *uploadService) UploadReader(photoReader io.Reader, name string, optionalParams *UploadParams) (*UploadResponse, error) {
+	client := s.client
+	client.Init()
+	client.EndpointUrl = client.GetEndpoints().Upload
+	client.HTTPVerb = "POST"
+
+	if optionalParams != nil {
+		fillArgsWithParams(client, optionalParams)
+	}
+
+	client.OAuthSign()
+
+	resp, err := postPhoto(client, photoReader, name, s.httpClient)
+	if err != nil {
+		return nil, err
+	}
+
+	apiResp := &UploadResponse{}
+	err = parseApiResponse(resp, apiResp, client.KeepRawBody)
+	return apiResp, err
+}
@@ -0,0 +1,104 @@
+// Package service exposes the wrappers of this library as services of a
+// single Client, each one defined by an interface. Code depending on the
+// interfaces can replace any service with a fake in tests:
+//
+//	c := service.NewClient(apiKey, apiSecret)
+//	c.Photos = fakePhotos{}
+//
+// Each package of the library defines its Service interface along with a
+// NewService function returning the implementation the package functions
+// delegate to, like photos.GetInfo calling photos.NewService(client).GetInfo.
+// The flickr package does the same with AuthService and UploadService.
+// Services built on a FlickrClient use it for every request, so like the
+// client they must not be used concurrently.
+package service
+
+import (
+	"gopkg.in/masci/flickr.v2"
+	"gopkg.in/masci/flickr.v2/auth/oauth"
+	"gopkg.in/masci/flickr.v2/collections"
+	"gopkg.in/masci/flickr.v2/comments"
+	"gopkg.in/masci/flickr.v2/contacts"
+	"gopkg.in/masci/flickr.v2/favorites"
+	"gopkg.in/masci/flickr.v2/galleries"
+	"gopkg.in/masci/flickr.v2/groups"
+	"gopkg.in/masci/flickr.v2/machinetags"
+	"gopkg.in/masci/flickr.v2/people"
+	"gopkg.in/masci/flickr.v2/photos"
+	"gopkg.in/masci/flickr.v2/photos/geo"
+	"gopkg.in/masci/flickr.v2/photosets"
+	"gopkg.in/masci/flickr.v2/places"
+	"gopkg.in/masci/flickr.v2/tags"
+	"gopkg.in/masci/flickr.v2/test"
+)
+
+// A Flickr client grouping methods by service. Services share the same
+// FlickrClient, so a Client must not be used concurrently.
+type Client struct {
+	// The client performing requests, holding credentials and settings
+	FlickrClient *flickr.FlickrClient
+
+	// OAuth flow
+	Auth flickr.AuthService
+	// Legacy tokens exchange and token checks, flickr.auth.oauth namespace
+	OAuth oauth.Service
+	// Photo uploads
+	Upload flickr.UploadService
+	// flickr.photos namespace
+	Photos photos.Service
+	// flickr.photos.geo namespace
+	Geo geo.Service
+	// flickr.photosets namespace
+	Photosets photosets.Service
+	// flickr.collections namespace
+	Collections collections.Service
+	// flickr.photos.comments and flickr.photosets.comments namespaces
+	Comments comments.Service
+	// flickr.contacts namespace
+	Contacts contacts.Service
+	// flickr.favorites namespace
+	Favorites favorites.Service
+	// flickr.galleries namespace
+	Galleries galleries.Service
+	// flickr.groups namespace
+	Groups groups.Service
+	// flickr.machinetags namespace
+	MachineTags machinetags.Service
+	// flickr.people namespace
+	People people.Service
+	// flickr.places namespace
+	Places places.Service
+	// flickr.tags namespace
+	Tags tags.Service
+	// flickr.test namespace
+	Test test.Service
+}
+
+// Create a Client whose services perform requests with the given FlickrClient
+func New(client *flickr.FlickrClient) *Client {
+	return &Client{
+		FlickrClient: client,
+		Auth:         flickr.NewAuthService(client),
+		OAuth:        oauth.NewService(client),
+		Upload:       flickr.NewUploadService(client, nil),
+		Photos:       photos.NewService(client),
+		Geo:          geo.NewService(client),
+		Photosets:    photosets.NewService(client),
+		Collections:  collections.NewService(client),
+		Comments:     comments.NewService(client),
+		Contacts:     contacts.NewService(client),
+		Favorites:    favorites.NewService(client),
+		Galleries:    galleries.NewService(client),
+		Groups:       groups.NewService(client),
+		MachineTags:  machinetags.NewService(client),
+		People:       people.NewService(client),
+		Places:       places.NewService(client),
+		Tags:         tags.NewService(client),
+		Test:         test.NewService(client),
+	}
+}
+
+// Create a Client for the application with the given credentials
+func NewClient(apiKey, apiSecret string) *Client {
+	return New(flickr.NewFlickrClient(apiKey, apiSecret))
+}
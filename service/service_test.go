@@ -0,0 +1,68 @@
+package service
+
+import (
+	"bytes"
+	"testing"
+
+	"gopkg.in/masci/flickr.v2"
+	"gopkg.in/masci/flickr.v2/flickrtest"
+	"gopkg.in/masci/flickr.v2/photos"
+)
+
+// Code depending on the photos service only
+func photoTitle(c *Client, id string) (string, error) {
+	info, err := c.Photos.GetInfo(id, "")
+	if err != nil {
+		return "", err
+	}
+	return info.Photo.Title, nil
+}
+
+type fakePhotos struct {
+	photos.Service
+	titles map[string]string
+}
+
+func (f fakePhotos) GetInfo(id string, secret string) (*photos.PhotoInfoResponse, error) {
+	resp := &photos.PhotoInfoResponse{}
+	resp.Photo.Title = f.titles[id]
+	return resp, nil
+}
+
+func TestFakeService(t *testing.T) {
+	c := NewClient("key", "secret")
+	c.Photos = fakePhotos{titles: map[string]string{"123": "Gopher"}}
+
+	title, err := photoTitle(c, "123")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, title, "Gopher")
+}
+
+func TestServices(t *testing.T) {
+	s := flickrtest.NewServer()
+	defer s.Close()
+	u := s.AddUser(flickrtest.User{Username: "gopher"})
+	c := New(s.UserClient(u.NSID, "write"))
+
+	login, err := c.Test.Login()
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, login.User.Username, "gopher")
+
+	up, err := c.Upload.UploadReader(bytes.NewBufferString("not really a jpeg"), "gopher.jpg", nil)
+	flickr.Expect(t, err, nil)
+
+	title, err := photoTitle(c, up.ID)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, title, "gopher")
+
+	set, err := c.Photosets.Create("Gophers", "", up.ID)
+	flickr.Expect(t, err, nil)
+	list, err := c.Photosets.GetList(true, "", 1)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, len(list.Photosets.Items), 1)
+	flickr.Expect(t, list.Photosets.Items[0].Id, set.Set.Id)
+
+	check, err := c.OAuth.CheckToken(c.FlickrClient.OAuthToken)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, check.OAuth.User.ID, u.NSID)
+}
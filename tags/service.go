@@ -0,0 +1,170 @@
+package tags
+
+import (
+	"strconv"
+
+	"gopkg.in/masci/flickr.v2"
+	"gopkg.in/masci/flickr.v2/people"
+)
+
+// Methods of the flickr.tags namespace
+type Service interface {
+	// Return the tags of a photo
+	GetListPhoto(authenticate bool, photoId string) (*PhotoTagsResponse, error)
+	// Return the tags of a user
+	GetListUser(userId string) (*UserTagsResponse, error)
+	// Return the most used tags of a user
+	GetListUserPopular(userId string, count int) (*UserTagsResponse, error)
+	// Return the raw forms of the tags of the calling user
+	GetListUserRaw(tag string) (*RawTagsResponse, error)
+	// Return the tags used the most in a period
+	GetHotList(period string, count int) (*HotListResponse, error)
+	// Return the tags related to a tag
+	GetRelated(tag string) (*RelatedResponse, error)
+	// Return the clusters of tags used together with a tag
+	GetClusters(tag string) (*ClustersResponse, error)
+	// Return the photos of a cluster of a tag
+	GetClusterPhotos(tag, clusterId string) (*people.PhotoListResponse, error)
+}
+
+// Return a Service listing tags with client
+func NewService(client *flickr.FlickrClient) Service {
+	return &service{client}
+}
+
+type service struct {
+	client *flickr.FlickrClient
+}
+
+func (s *service) GetListPhoto(authenticate bool, photoId string) (*PhotoTagsResponse, error) {
+	client := s.client
+	client.Init()
+	client.Args.Set("method", "flickr.tags.getListPhoto")
+	client.Args.Set("photo_id", photoId)
+
+	if authenticate {
+		client.OAuthSign()
+	} else {
+		client.ApiSign()
+	}
+
+	response := &PhotoTagsResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+func (s *service) GetListUser(userId string) (*UserTagsResponse, error) {
+	client := s.client
+	client.Init()
+	client.Args.Set("method", "flickr.tags.getListUser")
+	if userId != "" {
+		client.Args.Set("user_id", userId)
+	}
+
+	// the tags of other users are public
+	if userId != "" {
+		client.ApiSign()
+	} else {
+		client.OAuthSign()
+	}
+
+	response := &UserTagsResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+func (s *service) GetListUserPopular(userId string, count int) (*UserTagsResponse, error) {
+	client := s.client
+	client.Init()
+	client.Args.Set("method", "flickr.tags.getListUserPopular")
+	if userId != "" {
+		client.Args.Set("user_id", userId)
+	}
+	if count > 0 {
+		client.Args.Set("count", strconv.Itoa(count))
+	}
+
+	// the tags of other users are public
+	if userId != "" {
+		client.ApiSign()
+	} else {
+		client.OAuthSign()
+	}
+
+	response := &UserTagsResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+func (s *service) GetListUserRaw(tag string) (*RawTagsResponse, error) {
+	client := s.client
+	client.Init()
+	client.Args.Set("method", "flickr.tags.getListUserRaw")
+	if tag != "" {
+		client.Args.Set("tag", tag)
+	}
+
+	client.OAuthSign()
+
+	response := &RawTagsResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+func (s *service) GetHotList(period string, count int) (*HotListResponse, error) {
+	client := s.client
+	client.Init()
+	client.Args.Set("method", "flickr.tags.getHotList")
+	if period != "" {
+		client.Args.Set("period", period)
+	}
+	if count > 0 {
+		client.Args.Set("count", strconv.Itoa(count))
+	}
+
+	client.ApiSign()
+
+	response := &HotListResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+func (s *service) GetRelated(tag string) (*RelatedResponse, error) {
+	client := s.client
+	client.Init()
+	client.Args.Set("method", "flickr.tags.getRelated")
+	client.Args.Set("tag", tag)
+
+	client.ApiSign()
+
+	response := &RelatedResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+func (s *service) GetClusters(tag string) (*ClustersResponse, error) {
+	client := s.client
+	client.Init()
+	client.Args.Set("method", "flickr.tags.getClusters")
+	client.Args.Set("tag", tag)
+
+	client.ApiSign()
+
+	response := &ClustersResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+func (s *service) GetClusterPhotos(tag, clusterId string) (*people.PhotoListResponse, error) {
+	client := s.client
+	client.Init()
+	client.Args.Set("method", "flickr.tags.getClusterPhotos")
+	client.Args.Set("tag", tag)
+	client.Args.Set("cluster_id", clusterId)
+
+	client.ApiSign()
+
+	response := &people.PhotoListResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
@@ -0,0 +1,161 @@
+// Package implementing methods: flickr.tags.*
+package tags
+
+import (
+	"strings"
+
+	"gopkg.in/masci/flickr.v2"
+	"gopkg.in/masci/flickr.v2/people"
+)
+
+// Values of the period argument of GetHotList
+const (
+	Day  = "day"
+	Week = "week"
+)
+
+// A tag of a photo
+type PhotoTag struct {
+	Id         string `xml:"id,attr"`
+	Author     string `xml:"author,attr"`
+	AuthorName string `xml:"authorname,attr"`
+	// The tag as entered by the author
+	Raw        string `xml:"raw,attr"`
+	MachineTag bool   `xml:"machine_tag,attr"`
+	// The normalized tag
+	Value string `xml:",chardata"`
+}
+
+type PhotoTagsResponse struct {
+	flickr.BasicResponse
+	Photo struct {
+		Id   string     `xml:"id,attr"`
+		Tags []PhotoTag `xml:"tags>tag"`
+	} `xml:"photo"`
+}
+
+// A normalized tag used by a user
+type UserTag struct {
+	// Number of uses of the tag, set by GetListUserPopular
+	Count int    `xml:"count,attr"`
+	Value string `xml:",chardata"`
+}
+
+type UserTagsResponse struct {
+	flickr.BasicResponse
+	Who struct {
+		Id   string    `xml:"id,attr"`
+		Tags []UserTag `xml:"tags>tag"`
+	} `xml:"who"`
+}
+
+// A normalized tag with the raw forms it was entered with
+type RawTag struct {
+	Clean string   `xml:"clean,attr"`
+	Raw   []string `xml:"raw"`
+}
+
+type RawTagsResponse struct {
+	flickr.BasicResponse
+	Who struct {
+		Id   string   `xml:"id,attr"`
+		Tags []RawTag `xml:"tags>tag"`
+	} `xml:"who"`
+}
+
+type HotTag struct {
+	Score int    `xml:"score,attr"`
+	Value string `xml:",chardata"`
+}
+
+type HotListResponse struct {
+	flickr.BasicResponse
+	HotTags struct {
+		Period string   `xml:"period,attr"`
+		Count  int      `xml:"count,attr"`
+		Tags   []HotTag `xml:"tag"`
+	} `xml:"hottags"`
+}
+
+type RelatedResponse struct {
+	flickr.BasicResponse
+	Related struct {
+		// The tag the related tags were requested for
+		Source string   `xml:"source,attr"`
+		Tags   []string `xml:"tag"`
+	} `xml:"tags"`
+}
+
+// A group of tags often used together with a given tag
+type Cluster struct {
+	Total int      `xml:"total,attr"`
+	Tags  []string `xml:"tag"`
+}
+
+// Return the id of the cluster to pass to GetClusterPhotos, made of its first
+// three tags
+func (c *Cluster) Id() string {
+	tags := c.Tags
+	if len(tags) > 3 {
+		tags = tags[:3]
+	}
+	return strings.Join(tags, "-")
+}
+
+type ClustersResponse struct {
+	flickr.BasicResponse
+	Clusters struct {
+		Source   string    `xml:"source,attr"`
+		Total    int       `xml:"total,attr"`
+		Clusters []Cluster `xml:"cluster"`
+	} `xml:"clusters"`
+}
+
+// Return the tags of a photo
+// This method requires authentication to retrieve the tags of private photos.
+func GetListPhoto(client *flickr.FlickrClient, authenticate bool, photoId string) (*PhotoTagsResponse, error) {
+	return NewService(client).GetListPhoto(authenticate, photoId)
+}
+
+// Return the tags of a user, userId defaults to the calling user when ""
+// This method requires authentication to retrieve the tags of the calling user,
+// requests about other users are only signed with the api secret.
+func GetListUser(client *flickr.FlickrClient, userId string) (*UserTagsResponse, error) {
+	return NewService(client).GetListUser(userId)
+}
+
+// Return the most used tags of a user with their counts, userId defaults to
+// the calling user when "" and count to 10 when 0
+// This method requires authentication to retrieve the tags of the calling user,
+// requests about other users are only signed with the api secret.
+func GetListUserPopular(client *flickr.FlickrClient, userId string, count int) (*UserTagsResponse, error) {
+	return NewService(client).GetListUserPopular(userId, count)
+}
+
+// Return the raw forms of the tags of the calling user, only the ones of tag
+// when not ""
+// This method requires authentication with 'read' permission.
+func GetListUserRaw(client *flickr.FlickrClient, tag string) (*RawTagsResponse, error) {
+	return NewService(client).GetListUserRaw(tag)
+}
+
+// Return the tags used the most in the last Day or Week, period defaults to
+// Day when "" and count to 20 when 0
+func GetHotList(client *flickr.FlickrClient, period string, count int) (*HotListResponse, error) {
+	return NewService(client).GetHotList(period, count)
+}
+
+// Return the tags related to tag, based on clustered usage analysis
+func GetRelated(client *flickr.FlickrClient, tag string) (*RelatedResponse, error) {
+	return NewService(client).GetRelated(tag)
+}
+
+// Return the clusters of tags used together with tag
+func GetClusters(client *flickr.FlickrClient, tag string) (*ClustersResponse, error) {
+	return NewService(client).GetClusters(tag)
+}
+
+// Return the photos of a cluster of tag, clusterId is returned by Cluster.Id
+func GetClusterPhotos(client *flickr.FlickrClient, tag, clusterId string) (*people.PhotoListResponse, error) {
+	return NewService(client).GetClusterPhotos(tag, clusterId)
+}
@@ -0,0 +1,178 @@
+package tags
+
+import (
+	"testing"
+
+	"gopkg.in/masci/flickr.v2"
+)
+
+func TestGetListPhoto(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	body := `<rsp stat="ok"><photo id="2619"><tags>
+		<tag id="156" author="12037949754@N01" authorname="Bees" raw="Tag 1" machine_tag="0">tag1</tag>
+		<tag id="157" author="12037949754@N01" authorname="Bees" raw="upcoming:event=81334" machine_tag="1">upcoming:event=81334</tag>
+	</tags></photo></rsp>`
+	server, client := flickr.FlickrMock(200, body, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetListPhoto(fclient, false, "2619")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, resp.Photo.Id, "2619")
+	flickr.Expect(t, len(resp.Photo.Tags), 2)
+	tag := resp.Photo.Tags[0]
+	flickr.Expect(t, tag.Id, "156")
+	flickr.Expect(t, tag.AuthorName, "Bees")
+	flickr.Expect(t, tag.Raw, "Tag 1")
+	flickr.Expect(t, tag.Value, "tag1")
+	flickr.Expect(t, tag.MachineTag, false)
+	flickr.Expect(t, resp.Photo.Tags[1].MachineTag, true)
+	flickr.AssertParamsInBody(t, fclient, []string{"photo_id", "api_sig"})
+
+	_, err = GetListPhoto(fclient, true, "2619")
+	flickr.Expect(t, err, nil)
+	flickr.AssertParamsInBody(t, fclient, []string{"photo_id", "oauth_signature"})
+	flickr.Expect(t, fclient.Args.Get("api_sig"), "")
+}
+
+func TestGetListUser(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	body := `<rsp stat="ok"><who id="12037949754@N01"><tags>
+		<tag count="10">bar</tag>
+		<tag count="11">foo</tag>
+	</tags></who></rsp>`
+	server, client := flickr.FlickrMock(200, body, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	// the calling user's tags require authentication
+	resp, err := GetListUser(fclient, "")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, resp.Who.Id, "12037949754@N01")
+	flickr.Expect(t, resp.Who.Tags[0].Value, "bar")
+	flickr.Expect(t, fclient.Args.Get("user_id"), "")
+	flickr.Expect(t, fclient.Args.Get("oauth_signature") != "", true)
+	flickr.Expect(t, fclient.Args.Get("api_sig"), "")
+
+	// the ones of other users don't
+	_, err = GetListUser(fclient, "12037949754@N01")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("api_sig") != "", true)
+	flickr.Expect(t, fclient.Args.Get("oauth_signature"), "")
+	flickr.AssertParamsInBody(t, fclient, []string{"user_id", "api_sig"})
+}
+
+func TestGetListUserPopular(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	body := `<rsp stat="ok"><who id="12037949754@N01"><tags>
+		<tag count="10">bar</tag>
+		<tag count="11">foo</tag>
+	</tags></who></rsp>`
+	server, client := flickr.FlickrMock(200, body, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetListUserPopular(fclient, "12037949754@N01", 2)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, resp.Who.Tags[1].Value, "foo")
+	flickr.Expect(t, resp.Who.Tags[1].Count, 11)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.tags.getListUserPopular")
+	flickr.Expect(t, fclient.Args.Get("oauth_signature"), "")
+	flickr.AssertParamsInBody(t, fclient, []string{"user_id", "count", "api_sig"})
+
+	_, err = GetListUserPopular(fclient, "", 0)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("count"), "")
+	flickr.Expect(t, fclient.Args.Get("api_sig"), "")
+	flickr.AssertParamsInBody(t, fclient, []string{"oauth_signature"})
+}
+
+func TestGetListUserRaw(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	body := `<rsp stat="ok"><who id="12037949754@N01"><tags>
+		<tag clean="foo"><raw>foo</raw><raw>Foo</raw><raw>fOo</raw></tag>
+	</tags></who></rsp>`
+	server, client := flickr.FlickrMock(200, body, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetListUserRaw(fclient, "foo")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, len(resp.Who.Tags), 1)
+	flickr.Expect(t, resp.Who.Tags[0].Clean, "foo")
+	flickr.Expect(t, len(resp.Who.Tags[0].Raw), 3)
+	flickr.Expect(t, resp.Who.Tags[0].Raw[2], "fOo")
+	flickr.AssertParamsInBody(t, fclient, []string{"tag"})
+}
+
+func TestGetHotList(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	body := `<rsp stat="ok"><hottags period="week" count="2">
+		<tag score="20">northerncalifornia</tag>
+		<tag score="18">top20</tag>
+	</hottags></rsp>`
+	server, client := flickr.FlickrMock(200, body, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetHotList(fclient, Week, 2)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, resp.HotTags.Period, "week")
+	flickr.Expect(t, resp.HotTags.Count, 2)
+	flickr.Expect(t, resp.HotTags.Tags[0].Score, 20)
+	flickr.Expect(t, resp.HotTags.Tags[1].Value, "top20")
+	flickr.AssertParamsInBody(t, fclient, []string{"period", "count"})
+}
+
+func TestGetRelated(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	body := `<rsp stat="ok"><tags source="london">
+		<tag>england</tag>
+		<tag>thames</tag>
+	</tags></rsp>`
+	server, client := flickr.FlickrMock(200, body, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetRelated(fclient, "london")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, resp.Related.Source, "london")
+	flickr.Expect(t, len(resp.Related.Tags), 2)
+	flickr.Expect(t, resp.Related.Tags[1], "thames")
+	flickr.AssertParamsInBody(t, fclient, []string{"tag"})
+}
+
+func TestGetClusters(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	body := `<rsp stat="ok"><clusters source="cows" total="2">
+		<cluster total="4"><tag>farm</tag><tag>animals</tag><tag>cattle</tag><tag>field</tag></cluster>
+		<cluster total="2"><tag>green</tag><tag>grass</tag></cluster>
+	</clusters></rsp>`
+	server, client := flickr.FlickrMock(200, body, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetClusters(fclient, "cows")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, resp.Clusters.Source, "cows")
+	flickr.Expect(t, resp.Clusters.Total, 2)
+	flickr.Expect(t, resp.Clusters.Clusters[0].Total, 4)
+	flickr.Expect(t, resp.Clusters.Clusters[0].Id(), "farm-animals-cattle")
+	flickr.Expect(t, resp.Clusters.Clusters[1].Id(), "green-grass")
+}
+
+func TestGetClusterPhotos(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	body := `<rsp stat="ok"><photos>
+		<photo id="2636" owner="47058503995@N01" secret="a123456" server="2" farm="1" title="cows in a field" ispublic="1" isfriend="0" isfamily="0" />
+	</photos></rsp>`
+	server, client := flickr.FlickrMock(200, body, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetClusterPhotos(fclient, "cows", "farm-animals-cattle")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, len(resp.Photos.Photos), 1)
+	flickr.Expect(t, resp.Photos.Photos[0].Title, "cows in a field")
+	flickr.AssertParamsInBody(t, fclient, []string{"tag", "cluster_id"})
+}
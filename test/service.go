@@ -0,0 +1,59 @@
+package test
+
+import (
+	"gopkg.in/masci/flickr.v2"
+)
+
+// Methods of the flickr.test namespace
+type Service interface {
+	// A testing method which checks if the caller is logged in then returns their username.
+	Login() (*LoginResponse, error)
+	// Noop method
+	Null() (*flickr.BasicResponse, error)
+	// A testing method which echo's all parameters back in the response.
+	Echo() (*EchoResponse, error)
+}
+
+// Return a Service testing calls with client
+func NewService(client *flickr.FlickrClient) Service {
+	return &service{client}
+}
+
+type service struct {
+	client *flickr.FlickrClient
+}
+
+func (s *service) Login() (*LoginResponse, error) {
+	client := s.client
+	client.Init()
+	client.SetOAuthDefaults()
+	client.Args.Set("method", "flickr.test.login")
+	client.OAuthSign()
+
+	loginResponse := &LoginResponse{}
+	err := flickr.DoGet(client, loginResponse)
+	return loginResponse, err
+}
+
+func (s *service) Null() (*flickr.BasicResponse, error) {
+	client := s.client
+	client.Init()
+	client.SetOAuthDefaults()
+	client.Args.Set("method", "flickr.test.null")
+	client.OAuthSign()
+
+	response := &flickr.BasicResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+func (s *service) Echo() (*EchoResponse, error) {
+	client := s.client
+	client.EndpointUrl = client.GetEndpoints().API
+	client.Args.Set("method", "flickr.test.echo")
+	client.Args.Set("oauth_consumer_key", client.ApiKey)
+
+	response := &EchoResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
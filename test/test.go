@@ -31,37 +31,17 @@ type EchoResponse struct {
 // A testing method which checks if the caller is logged in then returns their username.
 // This method requires authentication with 'read' permission.
 func Login(client *flickr.FlickrClient) (*LoginResponse, error) {
-	client.Init()
-	client.SetOAuthDefaults()
-	client.Args.Set("method", "flickr.test.login")
-	client.OAuthSign()
-
-	loginResponse := &LoginResponse{}
-	err := flickr.DoGet(client, loginResponse)
-	return loginResponse, err
+	return NewService(client).Login()
 }
 
 // Noop method
 // This method requires authentication with 'read' permission.
 func Null(client *flickr.FlickrClient) (*flickr.BasicResponse, error) {
-	client.Init()
-	client.SetOAuthDefaults()
-	client.Args.Set("method", "flickr.test.null")
-	client.OAuthSign()
-
-	response := &flickr.BasicResponse{}
-	err := flickr.DoGet(client, response)
-	return response, err
+	return NewService(client).Null()
 }
 
 // A testing method which echo's all parameters back in the response.
 // This method does not require authentication.
 func Echo(client *flickr.FlickrClient) (*EchoResponse, error) {
-	client.EndpointUrl = flickr.API_ENDPOINT
-	client.Args.Set("method", "flickr.test.echo")
-	client.Args.Set("oauth_consumer_key", client.ApiKey)
-
-	response := &EchoResponse{}
-	err := flickr.DoGet(client, response)
-	return response, err
+	return NewService(client).Echo()
 }
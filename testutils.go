@@ -11,6 +11,7 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 )
 
 func Expect(t *testing.T, a interface{}, b interface{}) {
@@ -35,6 +36,9 @@ func GetTestClient() *FlickrClient {
 		HTTPVerb:    "GET",
 		Args:        args,
 		ApiSecret:   "1a3c208e172d3edc",
+		// keep nonce and timestamp stable when the client signs again
+		Clock:          func() time.Time { return time.Unix(1316657628, 0) },
+		NonceGenerator: func() string { return "C2F26CD5C075BA9050AD8EE90644CF29" },
 	}
 }
 
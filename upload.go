@@ -1,6 +1,7 @@
 package flickr
 
 import (
+	"context"
 	"crypto/rand"
 	"crypto/tls"
 	"fmt"
@@ -8,7 +9,6 @@ import (
 	"log"
 	"mime/multipart"
 	"net/http"
-	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
@@ -127,32 +127,21 @@ func fillArgsWithParams(client *FlickrClient, params *UploadParams) {
 // default preferences.
 // This call must be signed with write permissions
 func UploadFile(client *FlickrClient, path string, optionalParams *UploadParams) (*UploadResponse, error) {
-	file, err := os.Open(path)
-	if err != nil {
-		return nil, err
-	}
-	defer file.Close()
-
-	return UploadReader(client, file, file.Name(), optionalParams)
+	return NewUploadService(client, nil).UploadFile(path, optionalParams)
 }
 
 // UploadReader does same as UploadFile but the photo file is passed as an io.Reader instead of a file path
 func UploadReader(client *FlickrClient, photoReader io.Reader, name string, optionalParams *UploadParams) (*UploadResponse, error) {
-	return UploadReaderWithClient(client, photoReader, name, optionalParams, nil)
+	return NewUploadService(client, nil).UploadReader(photoReader, name, optionalParams)
 }
 
 // UploadReaderWithClient does same as UploadReader but allows passing a custom httpClient
 func UploadReaderWithClient(client *FlickrClient, photoReader io.Reader, name string, optionalParams *UploadParams, httpClient *http.Client) (*UploadResponse, error) {
-	client.Init()
-	client.EndpointUrl = UPLOAD_ENDPOINT
-	client.HTTPVerb = "POST"
-
-	if optionalParams != nil {
-		fillArgsWithParams(client, optionalParams)
-	}
-
-	client.OAuthSign()
+	return NewUploadService(client, httpClient).UploadReader(photoReader, name, optionalParams)
+}
 
+// Send the photo along with the signed client Args to client.EndpointUrl
+func postPhoto(client *FlickrClient, photoReader io.Reader, name string, httpClient *http.Client) (*http.Response, error) {
 	// write request body in a Pipe
 	boundary := randomBoundary()
 	r, w := io.Pipe()
@@ -168,7 +157,7 @@ func UploadReaderWithClient(client *FlickrClient, photoReader io.Reader, name st
 	req.Header.Set("content-type", "multipart/form-data; boundary="+boundary)
 	req.ContentLength = -1 // unknown
 
-	if (httpClient == nil) {
+	if httpClient == nil {
 		// Create a Transport to explicitly use the http1.1 client
 		// TODO: for some reason, when we use the http2 client flickr API responds
 		// with HTTP: 411 (No Content Length : POST) whereas it should be ok to
@@ -184,12 +173,7 @@ func UploadReaderWithClient(client *FlickrClient, photoReader io.Reader, name st
 	}
 
 	// perform upload request streaming the file
-	resp, err := httpClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-
-	apiResp := &UploadResponse{}
-	err = parseApiResponse(resp, apiResp)
-	return apiResp, err
+	// the photo was streamed from a reader and cannot be sent again, we can't
+	// retry on a refused timestamp but the next requests will be corrected
+	return client.send(context.Background(), req, httpClient)
 }
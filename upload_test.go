@@ -76,6 +76,7 @@ func TestUploadFileKo(t *testing.T) {
 	server, client := FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?><rsp stat="fail"></rsp>`, "")
 	defer server.Close()
 	fclient.HTTPClient = client
+	fclient.Endpoints.Upload = server.URL
 
 	fooFile, err := ioutil.TempFile("", "flickr.go")
 	defer fooFile.Close()
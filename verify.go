@@ -0,0 +1,286 @@
+package flickr
+
+import (
+	"container/heap"
+	"crypto/hmac"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Default window around the verifier clock in which OAuth timestamps are accepted
+const DefaultTimestampWindow = 5 * time.Minute
+
+// Problems reported by OAuthVerifier, as defined by the OAuth Problem Reporting
+// extension Flickr uses in its OAuth responses
+const (
+	ProblemParameterAbsent         = "parameter_absent"
+	ProblemVersionRejected         = "version_rejected"
+	ProblemSignatureMethodRejected = "signature_method_rejected"
+	ProblemConsumerKeyUnknown      = "consumer_key_unknown"
+	ProblemTokenRejected           = "token_rejected"
+	ProblemTimestampRefused        = "timestamp_refused"
+	ProblemNonceUsed               = "nonce_used"
+	ProblemSignatureInvalid        = "signature_invalid"
+	ProblemParameterRejected       = "parameter_rejected"
+)
+
+// Params every OAuth signed request must carry
+var requiredOAuthParams = []string{
+	"oauth_consumer_key",
+	"oauth_nonce",
+	"oauth_signature",
+	"oauth_signature_method",
+	"oauth_timestamp",
+}
+
+// Error describing why an incoming request failed the OAuth verification
+type VerificationError struct {
+	// One of the Problem* constants
+	Problem string
+	// Human readable details
+	Detail string
+	// Required OAuth params missing from the request
+	ParametersAbsent []string
+	// Range of acceptable timestamps, in the "min-max" form
+	AcceptableTimestamps string
+	// Signature computed by the verifier and signature carried by the request
+	ExpectedSignature string
+	ReceivedSignature string
+	// Base string the expected signature was computed on, compare it with the
+	// one used by the client to find out what differs
+	BaseString string
+}
+
+// Implement error interface
+func (e *VerificationError) Error() string {
+	msg := "OAuth verification failed: " + e.Problem
+	if e.Detail != "" {
+		msg += ": " + e.Detail
+	}
+	if e.BaseString != "" {
+		msg += fmt.Sprintf(" (expected signature %q over base string %q, got %q)",
+			e.ExpectedSignature, e.BaseString, e.ReceivedSignature)
+	}
+	return msg
+}
+
+// Encode the error as the body of an OAuth response, the same way Flickr does
+func (e *VerificationError) Encode() string {
+	val := url.Values{}
+	val.Set("oauth_problem", e.Problem)
+	if len(e.ParametersAbsent) > 0 {
+		val.Set("oauth_parameters_absent", strings.Join(e.ParametersAbsent, "&"))
+	}
+	if e.AcceptableTimestamps != "" {
+		val.Set("oauth_acceptable_timestamps", e.AcceptableTimestamps)
+	}
+	return val.Encode()
+}
+
+// Verify OAuth signatures of incoming requests: it's the inverse of
+// FlickrClient.Sign, useful to build local stand-ins of Flickr and push receivers.
+// An OAuthVerifier is safe for concurrent use.
+type OAuthVerifier struct {
+	// Key and secret of the application signing requests. If ConsumerKey is
+	// empty, requests are not checked against it.
+	ConsumerKey    string
+	ConsumerSecret string
+	// Return the secret of an OAuth token, or false if the token is unknown
+	TokenSecret func(token string) (string, bool)
+	// Window around the verifier clock in which timestamps are accepted,
+	// DefaultTimestampWindow when zero
+	TimestampWindow time.Duration
+	// Return the current time, defaults to time.Now when nil
+	Clock func() time.Time
+	// Return the URL requests were signed for, without query. Defaults to the
+	// URL of the incoming request, useful to override when behind a proxy.
+	RequestUrl func(r *http.Request) string
+
+	mu sync.Mutex
+	// nonces seen within the timestamp window, with their timestamp
+	nonces map[string]int64
+	// the same nonces, oldest first
+	expiring nonceHeap
+}
+
+// A nonce and its timestamp
+type usedNonce struct {
+	nonce     string
+	timestamp int64
+}
+
+// Nonces ordered by timestamp, implementing heap.Interface
+type nonceHeap []usedNonce
+
+func (h nonceHeap) Len() int            { return len(h) }
+func (h nonceHeap) Less(i, j int) bool  { return h[i].timestamp < h[j].timestamp }
+func (h nonceHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *nonceHeap) Push(x interface{}) { *h = append(*h, x.(usedNonce)) }
+func (h *nonceHeap) Pop() interface{} {
+	old := *h
+	n := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return n
+}
+
+// Create a verifier for requests signed by the given application
+func NewOAuthVerifier(consumerKey, consumerSecret string, tokenSecret func(token string) (string, bool)) *OAuthVerifier {
+	return &OAuthVerifier{
+		ConsumerKey:    consumerKey,
+		ConsumerSecret: consumerSecret,
+		TokenSecret:    tokenSecret,
+	}
+}
+
+func (v *OAuthVerifier) now() time.Time {
+	if v.Clock != nil {
+		return v.Clock()
+	}
+	return time.Now()
+}
+
+func (v *OAuthVerifier) window() time.Duration {
+	if v.TimestampWindow > 0 {
+		return v.TimestampWindow
+	}
+	return DefaultTimestampWindow
+}
+
+// Return the URL of an incoming request without query, normalized as
+// required to compute the base string
+func defaultRequestUrl(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	host := strings.ToLower(r.Host)
+	if h, port, err := net.SplitHostPort(host); err == nil {
+		if (scheme == "http" && port == "80") || (scheme == "https" && port == "443") {
+			host = h
+		}
+	}
+	return scheme + "://" + host + r.URL.EscapedPath()
+}
+
+// Collect the params of a request, from the query string and the body,
+// either urlencoded or multipart as sent by DoPost
+func requestParams(r *http.Request) (url.Values, error) {
+	err := r.ParseMultipartForm(32 << 20)
+	if err != nil && err != http.ErrNotMultipart {
+		return nil, err
+	}
+
+	params := url.Values{}
+	for k, v := range r.Form {
+		params[k] = append([]string(nil), v...)
+	}
+	return params, nil
+}
+
+// Verify the OAuth signature of a request. The request body is parsed, so its
+// params are available through r.Form afterwards. A *VerificationError is
+// returned when verification fails.
+func (v *OAuthVerifier) Verify(r *http.Request) error {
+	params, err := requestParams(r)
+	if err != nil {
+		return &VerificationError{Problem: ProblemParameterRejected, Detail: err.Error()}
+	}
+
+	var absent []string
+	for _, p := range requiredOAuthParams {
+		if params.Get(p) == "" {
+			absent = append(absent, p)
+		}
+	}
+	if len(absent) > 0 {
+		return &VerificationError{
+			Problem:          ProblemParameterAbsent,
+			Detail:           "missing " + strings.Join(absent, ", "),
+			ParametersAbsent: absent,
+		}
+	}
+
+	if version := params.Get("oauth_version"); version != "" && version != "1.0" {
+		return &VerificationError{Problem: ProblemVersionRejected, Detail: "unsupported version " + version}
+	}
+	if method := params.Get("oauth_signature_method"); method != "HMAC-SHA1" {
+		return &VerificationError{Problem: ProblemSignatureMethodRejected, Detail: "unsupported method " + method}
+	}
+	consumerKey := params.Get("oauth_consumer_key")
+	if v.ConsumerKey != "" && consumerKey != v.ConsumerKey {
+		return &VerificationError{Problem: ProblemConsumerKeyUnknown, Detail: "unknown key " + consumerKey}
+	}
+
+	token := params.Get("oauth_token")
+	tokenSecret := ""
+	if token != "" {
+		ok := false
+		if v.TokenSecret != nil {
+			tokenSecret, ok = v.TokenSecret(token)
+		}
+		if !ok {
+			return &VerificationError{Problem: ProblemTokenRejected, Detail: "unknown token " + token}
+		}
+	}
+
+	now := v.now().Unix()
+	window := int64(v.window() / time.Second)
+	timestamp, err := strconv.ParseInt(params.Get("oauth_timestamp"), 10, 64)
+	if err != nil || timestamp < now-window || timestamp > now+window {
+		return &VerificationError{
+			Problem:              ProblemTimestampRefused,
+			Detail:               fmt.Sprintf("timestamp %s is not within %d seconds of %d", params.Get("oauth_timestamp"), window, now),
+			AcceptableTimestamps: fmt.Sprintf("%d-%d", now-window, now+window),
+		}
+	}
+
+	received := params.Get("oauth_signature")
+	params.Del("oauth_signature")
+	requestUrl := defaultRequestUrl(r)
+	if v.RequestUrl != nil {
+		requestUrl = v.RequestUrl(r)
+	}
+	base := signingBaseString(r.Method, requestUrl, params)
+	expected := hmacSignature(v.ConsumerSecret, tokenSecret, base)
+	if !hmac.Equal([]byte(expected), []byte(received)) {
+		return &VerificationError{
+			Problem:           ProblemSignatureInvalid,
+			ExpectedSignature: expected,
+			ReceivedSignature: received,
+			BaseString:        base,
+		}
+	}
+
+	// nonces are checked last, so that invalid requests can't burn them
+	if !v.useNonce(consumerKey+"&"+token+"&"+params.Get("oauth_nonce"), timestamp, now-window) {
+		return &VerificationError{Problem: ProblemNonceUsed, Detail: "nonce " + params.Get("oauth_nonce") + " already used"}
+	}
+
+	return nil
+}
+
+// Record a nonce, return false if it was already used. Nonces with a
+// timestamp older than oldest can't be replayed anymore and are forgotten.
+func (v *OAuthVerifier) useNonce(nonce string, timestamp, oldest int64) bool {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if v.nonces == nil {
+		v.nonces = map[string]int64{}
+	}
+	for len(v.expiring) > 0 && v.expiring[0].timestamp < oldest {
+		delete(v.nonces, heap.Pop(&v.expiring).(usedNonce).nonce)
+	}
+	if _, used := v.nonces[nonce]; used {
+		return false
+	}
+	v.nonces[nonce] = timestamp
+	heap.Push(&v.expiring, usedNonce{nonce, timestamp})
+	return true
+}
@@ -0,0 +1,184 @@
+package flickr
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Start a server verifying incoming requests, the last verification error is
+// stored in the returned pointer
+func verifyingServer(v *OAuthVerifier) (*httptest.Server, *error) {
+	var verr error
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		verr = v.Verify(r)
+		if verr != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(verr.(*VerificationError).Encode()))
+			return
+		}
+		w.Write([]byte(`<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"></rsp>`))
+	}))
+	return server, &verr
+}
+
+func newVerifier() *OAuthVerifier {
+	return NewOAuthVerifier("apikey", "apisecret", func(token string) (string, bool) {
+		if token == "token" {
+			return "tokensecret", true
+		}
+		return "", false
+	})
+}
+
+func signedClient(endpoint string) *FlickrClient {
+	client := NewFlickrClient("apikey", "apisecret")
+	client.OAuthToken = "token"
+	client.OAuthTokenSecret = "tokensecret"
+	client.Init()
+	client.EndpointUrl = endpoint
+	client.Args.Set("method", "flickr.test.login")
+	client.Args.Set("title", "A title with spaces & symbols")
+	return client
+}
+
+func verificationProblem(err error) string {
+	if verr, ok := err.(*VerificationError); ok {
+		return verr.Problem
+	}
+	return ""
+}
+
+func TestVerifyGet(t *testing.T) {
+	server, verr := verifyingServer(newVerifier())
+	defer server.Close()
+
+	client := signedClient(server.URL + "/services/rest")
+	client.OAuthSign()
+	err := DoGet(client, &BasicResponse{})
+	Expect(t, err, nil)
+	Expect(t, *verr, nil)
+
+	// replaying the same request is refused
+	DoGet(client, &BasicResponse{})
+	Expect(t, verificationProblem(*verr), ProblemNonceUsed)
+}
+
+func TestVerifyPost(t *testing.T) {
+	server, verr := verifyingServer(newVerifier())
+	defer server.Close()
+
+	client := signedClient(server.URL + "/services/rest")
+	client.HTTPVerb = "POST"
+	client.OAuthSign()
+	err := DoPost(client, &BasicResponse{})
+	Expect(t, err, nil)
+	Expect(t, *verr, nil)
+}
+
+func TestVerifySignatureInvalid(t *testing.T) {
+	server, verr := verifyingServer(newVerifier())
+	defer server.Close()
+
+	client := signedClient(server.URL + "/services/rest")
+	client.OAuthSign()
+	client.Args.Set("title", "tampered")
+	DoGet(client, &BasicResponse{})
+
+	e, ok := (*verr).(*VerificationError)
+	Expect(t, ok, true)
+	Expect(t, e.Problem, ProblemSignatureInvalid)
+	Expect(t, e.ReceivedSignature, client.Args.Get("oauth_signature"))
+	client.Args.Del("oauth_signature")
+	Expect(t, e.BaseString, client.getSigningBaseString())
+	Expect(t, e.ExpectedSignature, client.getSignature("tokensecret"))
+	Expect(t, strings.Contains(e.Error(), e.BaseString), true)
+}
+
+func TestVerifyTimestampRefused(t *testing.T) {
+	v := newVerifier()
+	v.Clock = func() time.Time { return time.Unix(1316657628, 0) }
+	server, verr := verifyingServer(v)
+	defer server.Close()
+
+	client := signedClient(server.URL + "/services/rest")
+	client.Clock = func() time.Time { return time.Unix(1316657628+301, 0) }
+	client.OAuthSign()
+	DoGet(client, &BasicResponse{})
+
+	e, ok := (*verr).(*VerificationError)
+	Expect(t, ok, true)
+	Expect(t, e.Problem, ProblemTimestampRefused)
+	Expect(t, e.AcceptableTimestamps, "1316657328-1316657928")
+	Expect(t, strings.Contains(e.Encode(), "oauth_acceptable_timestamps=1316657328-1316657928"), true)
+}
+
+func TestVerifyClockSkewCorrection(t *testing.T) {
+	v := newVerifier()
+	skewed := func() time.Time { return time.Now().Add(time.Hour) }
+	v.Clock = skewed
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Date", skewed().UTC().Format(http.TimeFormat))
+		if err := v.Verify(r); err != nil {
+			w.Write([]byte(err.(*VerificationError).Encode()))
+			return
+		}
+		w.Write([]byte(`<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"></rsp>`))
+	}))
+	defer server.Close()
+
+	client := signedClient(server.URL + "/services/rest")
+	client.OAuthSign()
+	err := DoGet(client, &BasicResponse{})
+	Expect(t, err, nil)
+}
+
+func TestUseNonce(t *testing.T) {
+	v := newVerifier()
+	Expect(t, v.useNonce("a", 30, 0), true)
+	Expect(t, v.useNonce("b", 10, 0), true)
+	Expect(t, v.useNonce("c", 20, 0), true)
+	Expect(t, v.useNonce("b", 10, 0), false)
+
+	// nonces older than the window are forgotten, oldest first
+	Expect(t, v.useNonce("d", 40, 15), true)
+	Expect(t, len(v.nonces), 3)
+	Expect(t, len(v.expiring), 3)
+	Expect(t, v.useNonce("c", 20, 15), false)
+	Expect(t, v.useNonce("e", 40, 35), true)
+	Expect(t, len(v.nonces), 2)
+	Expect(t, v.nonces["d"], int64(40))
+	Expect(t, v.nonces["e"], int64(40))
+}
+
+func TestVerifyRejections(t *testing.T) {
+	server, verr := verifyingServer(newVerifier())
+	defer server.Close()
+
+	client := signedClient(server.URL + "/services/rest")
+	client.OAuthToken = "unknown"
+	client.OAuthSign()
+	DoGet(client, &BasicResponse{})
+	Expect(t, verificationProblem(*verr), ProblemTokenRejected)
+
+	client = signedClient(server.URL + "/services/rest")
+	client.ApiKey = "otherkey"
+	client.OAuthSign()
+	DoGet(client, &BasicResponse{})
+	Expect(t, verificationProblem(*verr), ProblemConsumerKeyUnknown)
+
+	client = signedClient(server.URL + "/services/rest")
+	client.OAuthSign()
+	client.Args.Set("oauth_signature_method", "PLAINTEXT")
+	DoGet(client, &BasicResponse{})
+	Expect(t, verificationProblem(*verr), ProblemSignatureMethodRejected)
+
+	client = signedClient(server.URL + "/services/rest")
+	client.OAuthSign()
+	client.Args.Del("oauth_nonce")
+	DoGet(client, &BasicResponse{})
+	Expect(t, verificationProblem(*verr), ProblemParameterAbsent)
+	Expect(t, strings.Join((*verr).(*VerificationError).ParametersAbsent, ","), "oauth_nonce")
+}